@@ -0,0 +1,47 @@
+package bastion
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// APIError is an unexpected response of the API.
+type APIError struct {
+	StatusCode int
+	Body       string
+	// message describes the expected response, like "api doesn't return OK"
+	message string
+}
+
+func newAPIError(message string, statusCode int, body string) *APIError {
+	return &APIError{
+		StatusCode: statusCode,
+		Body:       body,
+		message:    message,
+	}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %d with body:\n%s", e.message, e.StatusCode, e.Body)
+}
+
+// Parse returns the message of the {"error": "...", "description": "..."} envelope
+// of the body, or an empty string if the body isn't an error envelope.
+func (e *APIError) Parse() string {
+	var envelope struct {
+		Error       string `json:"error"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal([]byte(e.Body), &envelope); err != nil {
+		return ""
+	}
+	parts := make([]string, 0, 2)
+	for _, v := range []string{envelope.Error, envelope.Description} {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+
+	return strings.Join(parts, ": ")
+}
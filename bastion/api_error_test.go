@@ -0,0 +1,101 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorParse(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"error": {body: `{"error": "Invalid port"}`, expected: "Invalid port"},
+		"error description": {
+			body:     `{"error": "Bad Request", "description": "port: invalid"}`,
+			expected: "Bad Request: port: invalid",
+		},
+		"description only": {body: `{"description": "port: invalid"}`, expected: "port: invalid"},
+		"empty envelope":   {body: `{}`},
+		"not json":         {body: `<html>Bad Gateway</html>`},
+		"list":             {body: `[]`},
+		"empty":            {body: ``},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiErr := newAPIError("api doesn't return OK", http.StatusBadRequest, tt.body)
+			if message := apiErr.Parse(); message != tt.expected {
+				t.Errorf("expected message %q, got %q", tt.expected, message)
+			}
+			expectedError := "api doesn't return OK: 400 with body:\n" + tt.body
+			if apiErr.Error() != expectedError {
+				t.Errorf("expected error %q, got %q", expectedError, apiErr.Error())
+			}
+		})
+	}
+}
+
+func TestAPIErrorStatusCode(t *testing.T) {
+	tests := map[string]struct {
+		code int
+		call func(c *Client) error
+	}{
+		"search forbidden": {
+			code: http.StatusForbidden,
+			call: func(c *Client) error {
+				_, _, err := searchResourceDeviceService(context.Background(), "1", "SSH", c)
+
+				return err
+			},
+		},
+		"read config server error": {
+			code: http.StatusInternalServerError,
+			call: func(c *Client) error {
+				_, err := readConfigNTPOptions(context.Background(), c)
+
+				return err
+			},
+		},
+		"read not found is not an error": {
+			code: http.StatusNotFound,
+			call: func(c *Client) error {
+				_, err := readDeviceServiceOptions(context.Background(), "1", "2", c)
+
+				return err
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.code)
+				_, _ = w.Write([]byte(`{"error": "` + http.StatusText(tt.code) + `"}`))
+			})
+			err := tt.call(c)
+			if tt.code == http.StatusNotFound {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an *APIError, got %v", err)
+			}
+			if apiErr.StatusCode != tt.code {
+				t.Errorf("expected status code %d, got %d", tt.code, apiErr.StatusCode)
+			}
+			if apiErr.Parse() != http.StatusText(tt.code) {
+				t.Errorf("expected message %q, got %q", http.StatusText(tt.code), apiErr.Parse())
+			}
+			diags := diagFromAPIError(err)
+			if !strings.HasSuffix(diags[0].Summary, http.StatusText(tt.code)) {
+				t.Errorf("expected the message of the body in the summary, got %q", diags[0].Summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package bastion
+
+import (
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const cacheMaxEntries = 1000
+
+// responseCache is an in-memory LRU cache of the GET responses with a TTL,
+// the key is the request path (with the query).
+type responseCache struct {
+	mutex      sync.RWMutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+	now        func() time.Time
+}
+
+type responseCacheEntry struct {
+	uri     string
+	body    string
+	code    int
+	header  http.Header
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration, maxEntries int) *responseCache {
+	return &responseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// get returns the cached response of uri if it isn't expired.
+func (rc *responseCache) get(uri string) (responseCacheEntry, bool) {
+	rc.mutex.RLock()
+	elem, ok := rc.entries[uri]
+	var entry responseCacheEntry
+	if ok {
+		entry = *elem.Value.(*responseCacheEntry)
+	}
+	rc.mutex.RUnlock()
+	if !ok {
+		return entry, false
+	}
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	// the entry may have been removed since the read lock was released
+	if elem, ok = rc.entries[uri]; !ok {
+		return entry, false
+	}
+	if rc.now().After(entry.expires) {
+		rc.removeElement(elem)
+
+		return entry, false
+	}
+	rc.lru.MoveToFront(elem)
+
+	return entry, true
+}
+
+func (rc *responseCache) set(uri, body string, code int, header http.Header) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	entry := &responseCacheEntry{
+		uri:     uri,
+		body:    body,
+		code:    code,
+		header:  header,
+		expires: rc.now().Add(rc.ttl),
+	}
+	if elem, ok := rc.entries[uri]; ok {
+		elem.Value = entry
+		rc.lru.MoveToFront(elem)
+
+		return
+	}
+	rc.entries[uri] = rc.lru.PushFront(entry)
+	for rc.lru.Len() > rc.maxEntries {
+		rc.removeElement(rc.lru.Back())
+	}
+}
+
+// invalidate removes the cached responses of the paths overlapping with uri,
+// i.e. the objects under the path of uri and the parent objects or collections of uri.
+func (rc *responseCache) invalidate(uri string) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	for key, elem := range rc.entries {
+		if cachePathsOverlap(key, uri) {
+			rc.removeElement(elem)
+		}
+	}
+}
+
+func (rc *responseCache) removeElement(elem *list.Element) {
+	delete(rc.entries, elem.Value.(*responseCacheEntry).uri)
+	rc.lru.Remove(elem)
+}
+
+func cachePathsOverlap(uriA, uriB string) bool {
+	pathA := cachePath(uriA)
+	pathB := cachePath(uriB)
+
+	return strings.HasPrefix(pathA, pathB) || strings.HasPrefix(pathB, pathA)
+}
+
+// cachePath returns the path of uri without the query and with a leading and trailing '/',
+// so the prefix comparison is done on whole path segments.
+func cachePath(uri string) string {
+	path, _, _ := strings.Cut(uri, "?")
+
+	return "/" + strings.Trim(path, "/") + "/"
+}
@@ -0,0 +1,205 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache_ttl(t *testing.T) {
+	now := time.Now()
+	rc := newResponseCache(time.Minute, cacheMaxEntries)
+	rc.now = func() time.Time { return now }
+	rc.set("/devices/1", `{"id":"1"}`, http.StatusOK, nil)
+	if _, ok := rc.get("/devices/1"); !ok {
+		t.Fatal("expected a cached response before the ttl")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, ok := rc.get("/devices/1"); ok {
+		t.Fatal("expected no cached response after the ttl")
+	}
+	if len(rc.entries) != 0 || rc.lru.Len() != 0 {
+		t.Fatalf("expected the expired entry to be removed, got %d entries", len(rc.entries))
+	}
+}
+
+func TestResponseCache_lru(t *testing.T) {
+	rc := newResponseCache(time.Minute, 2)
+	rc.set("/devices/1", "1", http.StatusOK, nil)
+	rc.set("/devices/2", "2", http.StatusOK, nil)
+	// use /devices/1 so /devices/2 is the least recently used
+	if _, ok := rc.get("/devices/1"); !ok {
+		t.Fatal("expected /devices/1 in cache")
+	}
+	rc.set("/devices/3", "3", http.StatusOK, nil)
+	if _, ok := rc.get("/devices/2"); ok {
+		t.Error("expected /devices/2 to be evicted")
+	}
+	for _, uri := range []string{"/devices/1", "/devices/3"} {
+		if _, ok := rc.get(uri); !ok {
+			t.Errorf("expected %s in cache", uri)
+		}
+	}
+}
+
+func TestResponseCache_invalidate(t *testing.T) {
+	tests := map[string]struct {
+		uri         string
+		invalidated []string
+		kept        []string
+	}{
+		"update of a service": {
+			uri: "/devices/1/services/2?force=true",
+			invalidated: []string{
+				"/devices/?q=device_name=d1", "/devices/1", "/devices/1/services/?q=service_name=s2",
+				"/devices/1/services/2",
+			},
+			kept: []string{"/devices/10", "/devices/3/services/4", "/devicesgroups/", "/users/?q=user_name=u1"},
+		},
+		"creation of a device": {
+			uri: "/devices/",
+			invalidated: []string{
+				"/devices/?q=device_name=d1", "/devices/1", "/devices/10", "/devices/1/services/2",
+				"/devices/1/services/?q=service_name=s2", "/devices/3/services/4",
+			},
+			kept: []string{"/devicesgroups/", "/users/?q=user_name=u1"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rc := newResponseCache(time.Minute, cacheMaxEntries)
+			for _, uri := range append(append([]string{}, tt.invalidated...), tt.kept...) {
+				rc.set(uri, "", http.StatusOK, nil)
+			}
+			rc.invalidate(tt.uri)
+			for _, uri := range tt.invalidated {
+				if _, ok := rc.get(uri); ok {
+					t.Errorf("expected %s to be invalidated", uri)
+				}
+			}
+			for _, uri := range tt.kept {
+				if _, ok := rc.get(uri); !ok {
+					t.Errorf("expected %s to be kept", uri)
+				}
+			}
+		})
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		}
+	})
+	c.cache = newResponseCache(time.Minute, cacheMaxEntries)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := c.newRequest(ctx, "/devices/1", http.MethodGet, nil); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+	// concurrent first requests can all miss the cache, the next ones must hit it
+	firstRequests := requests.Load()
+	for range 5 {
+		if _, _, err := c.newRequest(ctx, "/devices/1", http.MethodGet, nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if got := requests.Load(); got != firstRequests {
+		t.Fatalf("expected the GET requests to be served by the cache, got %d requests instead of %d",
+			got, firstRequests)
+	}
+	if _, _, err := c.newRequest(ctx, "/devices/1", http.MethodPut, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := c.newRequest(ctx, "/devices/1", http.MethodGet, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, expected := requests.Load(), firstRequests+2; got != expected {
+		t.Fatalf("expected the GET request after a PUT to be sent, got %d requests instead of %d", got, expected)
+	}
+}
+
+func TestClientCachePollingLoops(t *testing.T) {
+	tests := map[string]struct {
+		uri   string
+		pages []string
+		poll  func(context.Context, *Client) error
+	}{
+		"search after create": {
+			uri:   "/devices/d1/services/?q=service_name=SSH",
+			pages: []string{`[]`, `[{"id":"s1","service_name":"SSH"}]`},
+			poll: func(ctx context.Context, c *Client) error {
+				_, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+					return searchResourceDeviceService(ctx, "d1", "SSH", c)
+				})
+				if err == nil && !ex {
+					return errors.New("service not found")
+				}
+
+				return err
+			},
+		},
+		"device service ready": {
+			uri:   "/devices/d1/services/s1",
+			pages: []string{`{"id":"s1","status":"initializing"}`, `{"id":"s1","status":"ready"}`},
+			poll: func(ctx context.Context, c *Client) error {
+				return waitDeviceServiceReady(ctx, "d1", "s1", time.Minute, c)
+			},
+		},
+		"HA pair synchronized": {
+			uri: "/config/ha",
+			pages: []string{
+				`{"peer_address":"192.0.2.2","sync_status":"connecting"}`,
+				`{"peer_address":"192.0.2.2","sync_status":"synchronized"}`,
+			},
+			poll: func(ctx context.Context, c *Client) error {
+				_, err := waitHAConfigurationSynchronized(ctx, time.Minute, c)
+
+				return err
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if path, _, _ := strings.Cut(tt.uri, "?"); r.URL.Path != "/api/v3.12"+path {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				page := tt.pages[min(int(requests.Add(1))-1, len(tt.pages)-1)]
+				_, _ = w.Write([]byte(page))
+			})
+			// as with cache_ttl_seconds set in the provider configuration
+			c.cache = newResponseCache(time.Minute, cacheMaxEntries)
+			ctx := context.Background()
+			// the first response is in the cache before the loop starts
+			if _, _, err := c.newRequest(ctx, tt.uri, http.MethodGet, nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := tt.poll(ctx, c); err != nil {
+				t.Fatalf("expected the loop to see the change behind the cache, got: %s", err)
+			}
+			if got := requests.Load(); got != int32(len(tt.pages)) {
+				t.Errorf("expected %d requests, got %d", len(tt.pages), got)
+			}
+		})
+	}
+}
@@ -9,20 +9,41 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-cleanhttp"
+	"golang.org/x/mod/semver"
 )
 
 // Information to connect on Wallix bastion.
 type Client struct {
-	bastionPort       int
-	bastionAPIVersion string
-	bastionIP         string
-	bastionToken      string
-	bastionUser       string
-	bastionPwd        string
+	bastionPort        int
+	bastionAPIVersion  string
+	bastionAPIBasePath string
+	// header with the user name for the authentication with the token
+	bastionAPIUserHeader string
+	bastionIP            string
+	bastionToken         string
+	bastionUser          string
+	bastionPwd           string
+	cache                *responseCache
+	// additional api versions allowed by the provider configuration
+	supportedAPIVersions []string
+	// authenticate with the password when the token is rejected
+	authRefresh bool
+	// request only the fields used by the read helpers with the fields query parameter
+	fieldsSelection bool
+	tokenExpired    atomic.Bool
+}
+
+// versionsValid returns the api versions known by the provider
+// with the additional versions of the provider configuration.
+func (c *Client) versionsValid() []string {
+	return append(defaultVersionsValid(), c.supportedAPIVersions...)
 }
 
 var defaultHTTPClient *http.Client //nolint:gochecknoglobals
@@ -33,27 +54,210 @@ func init() { //nolint:gochecknoinits
 	defaultHTTPClient = &http.Client{Transport: transport}
 }
 
+type cacheBypassKey struct{}
+
+// withoutCache returns a context whose GET requests skip the response cache,
+// for the loops polling the Bastion until a change is seen. The fresh responses still update the cache.
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
 func (c *Client) newRequest(ctx context.Context, uri string, method string, jsonBody interface{}) (string, int, error) {
-	body := new(bytes.Buffer)
-	err := json.NewEncoder(body).Encode(jsonBody)
+	body, code, _, err := c.doRequest(ctx, uri, method, jsonBody)
+
+	return body, code, err
+}
+
+// uriWithFields adds the fields query parameter to uri when the field selection is enabled,
+// so the API returns only the fields used by the caller.
+func (c *Client) uriWithFields(uri string, fields ...string) string {
+	if !c.fieldsSelection || len(fields) == 0 {
+		return uri
+	}
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+
+	return uri + separator + "fields=" + strings.Join(fields, ",")
+}
+
+// newRequestPaged is like newRequest for listing endpoints which return a JSON array,
+// it follows the next pages with the "next" link header or with an offset until
+// the X-Total-Count header is reached, and returns the JSON array of all the results.
+func (c *Client) newRequestPaged(
+	ctx context.Context, uri string, method string, jsonBody interface{},
+) (
+	string, int, error,
+) {
+	body, code, header, err := c.doRequest(ctx, uri, method, jsonBody)
+	if err != nil || code != http.StatusOK {
+		return body, code, err
+	}
+	var results []json.RawMessage
+	if err := json.Unmarshal([]byte(body), &results); err != nil {
+		// not a listing, return the body as is
+		return body, code, nil //nolint:nilerr
+	}
+	for page := len(results); page > 0; {
+		nextURI, ok, err := c.nextPageURI(uri, header, len(results))
+		if err != nil {
+			return "", http.StatusInternalServerError, err
+		}
+		if !ok {
+			break
+		}
+		body, code, header, err = c.doRequest(ctx, nextURI, method, jsonBody)
+		if err != nil || code != http.StatusOK {
+			return body, code, err
+		}
+		var pageResults []json.RawMessage
+		if err := json.Unmarshal([]byte(body), &pageResults); err != nil {
+			return "", http.StatusInternalServerError, fmt.Errorf("unmarshaling json page: %w", err)
+		}
+		results = append(results, pageResults...)
+		page = len(pageResults)
+	}
+	allResults, err := json.Marshal(results)
+	if err != nil {
+		return "", http.StatusInternalServerError, fmt.Errorf("marshaling json pages: %w", err)
+	}
+
+	return string(allResults), code, nil
+}
+
+// nextPageURI returns the uri of the next page with the headers of the current page
+// and the number of results already received.
+func (c *Client) nextPageURI(uri string, header http.Header, count int) (string, bool, error) {
+	if next := nextLinkHeader(header.Values("Link")); next != "" {
+		nextURL, err := url.Parse(next)
+		if err != nil {
+			return "", false, fmt.Errorf("parsing next link header: %w", err)
+		}
+		nextURI := strings.TrimPrefix(nextURL.Path, c.bastionAPIBasePath+"/"+c.bastionAPIVersion)
+		if nextURL.RawQuery != "" {
+			nextURI += "?" + nextURL.RawQuery
+		}
+
+		return nextURI, true, nil
+	}
+	totalCount := header.Get("X-Total-Count")
+	if totalCount == "" {
+		return "", false, nil
+	}
+	total, err := strconv.Atoi(totalCount)
+	if err != nil {
+		return "", false, fmt.Errorf("parsing X-Total-Count header: %w", err)
+	}
+	if count >= total {
+		return "", false, nil
+	}
+	uriURL, err := url.Parse(uri)
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("decoding json: %w", err)
+		return "", false, fmt.Errorf("parsing uri: %w", err)
+	}
+	query := uriURL.Query()
+	query.Set("offset", strconv.Itoa(count))
+	uriURL.RawQuery = query.Encode()
+
+	return uriURL.String(), true, nil
+}
+
+// nextLinkHeader returns the target of the rel="next" link in the Link headers (RFC 8288).
+func nextLinkHeader(links []string) string {
+	for _, header := range links {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(key, "rel") && strings.Trim(value, `"`) == "next" {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
 	}
-	url := "https://" + c.bastionIP + ":" + strconv.Itoa(c.bastionPort) + "/api/" + c.bastionAPIVersion
+
+	return ""
+}
+
+// doRequest sends the request, with the response from the cache for a GET request if the cache is enabled
+// and the context isn't from withoutCache.
+func (c *Client) doRequest(
+	ctx context.Context, uri string, method string, jsonBody interface{},
+) (
+	string, int, http.Header, error,
+) {
+	reqURL := c.apiURL() + "/" + c.bastionAPIVersion
 	if strings.HasPrefix(uri, "/") {
-		url += uri
+		reqURL += uri
 	} else {
-		url += "/" + uri
+		reqURL += "/" + uri
+	}
+	if c.cache == nil {
+		return c.sendRequest(ctx, reqURL, method, jsonBody)
+	}
+	if method == http.MethodGet && ctx.Value(cacheBypassKey{}) == nil {
+		if entry, ok := c.cache.get(uri); ok {
+			return entry.body, entry.code, entry.header, nil
+		}
+	}
+	body, code, header, err := c.sendRequest(ctx, reqURL, method, jsonBody)
+	switch {
+	case method != http.MethodGet:
+		// invalidate even on error as the request may have been applied
+		c.cache.invalidate(uri)
+	case err == nil && code == http.StatusOK:
+		c.cache.set(uri, body, code, header)
+	}
+
+	return body, code, header, err
+}
+
+// apiURL returns the url of the API without the version.
+func (c *Client) apiURL() string {
+	return "https://" + c.bastionIP + ":" + strconv.Itoa(c.bastionPort) + c.bastionAPIBasePath
+}
+
+// sendRequest sends the request with the token, or with the password if the token isn't set.
+// When the token is rejected with a 401 and authRefresh is enabled with a password,
+// the request is sent again with the password, which is used for all the next requests.
+func (c *Client) sendRequest(
+	ctx context.Context, reqURL string, method string, jsonBody interface{},
+) (
+	string, int, http.Header, error,
+) {
+	withToken := c.bastionToken != "" && !c.tokenExpired.Load()
+	body, code, header, err := c.sendRequestAuth(ctx, reqURL, method, jsonBody, withToken)
+	if err != nil || code != http.StatusUnauthorized || !withToken || !c.authRefresh || c.bastionPwd == "" {
+		return body, code, header, err
+	}
+	c.tokenExpired.Store(true)
+
+	return c.sendRequestAuth(ctx, reqURL, method, jsonBody, false)
+}
+
+func (c *Client) sendRequestAuth(
+	ctx context.Context, reqURL string, method string, jsonBody interface{}, withToken bool,
+) (
+	string, int, http.Header, error,
+) {
+	body := new(bytes.Buffer)
+	err := json.NewEncoder(body).Encode(jsonBody)
+	if err != nil {
+		return "", http.StatusInternalServerError, nil, fmt.Errorf("decoding json: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("preparing http request: %w", err)
+		return "", http.StatusInternalServerError, nil, fmt.Errorf("preparing http request: %w", err)
 	}
 	req.Header.Add("Content-Type", "application/json; charset=utf-8")
 	req.Header.Add("User-Agent", "terraform-provider-wallix-bastion")
-	if c.bastionToken != "" {
+	if withToken {
 		req.Header.Add("X-Auth-Key", c.bastionToken)
-		req.Header.Add("X-Auth-User", c.bastionUser)
+		req.Header.Add(c.bastionAPIUserHeader, c.bastionUser)
 	} else {
 		rawcreds := c.bastionUser + ":" + c.bastionPwd
 		encodedcreds := base64.StdEncoding.EncodeToString([]byte(rawcreds))
@@ -61,13 +265,50 @@ func (c *Client) newRequest(ctx context.Context, uri string, method string, json
 	}
 	resp, err := defaultHTTPClient.Do(req)
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("sending http request: %w", err)
+		return "", http.StatusInternalServerError, nil, fmt.Errorf("sending http request: %w", err)
 	}
 	defer resp.Body.Close()
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("reading http response: %w", err)
+		return "", http.StatusInternalServerError, nil, fmt.Errorf("reading http response: %w", err)
+	}
+
+	return string(respBody), resp.StatusCode, resp.Header, nil
+}
+
+// detectAPIVersion returns the most recent api version supported by the provider
+// which isn't above the version of the Bastion returned by GET /about.
+func (c *Client) detectAPIVersion(ctx context.Context) (string, error) {
+	body, code, _, err := c.sendRequest(ctx, c.apiURL()+"/about", http.MethodGet, nil)
+	if err != nil {
+		return "", err
+	}
+	if code != http.StatusOK {
+		return "", newAPIError("api doesn't return OK on /about", code, body)
+	}
+
+	return parseAPIVersion(body, c.versionsValid())
+}
+
+func parseAPIVersion(aboutBody string, validVersions []string) (string, error) {
+	var about struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(aboutBody), &about); err != nil {
+		return "", fmt.Errorf("unmarshaling json: %w", err)
+	}
+	version := "v" + strings.TrimPrefix(strings.TrimSpace(about.Version), "v")
+	if !semver.IsValid(version) {
+		return "", fmt.Errorf("invalid version %q in /about response", about.Version)
+	}
+	validVersions = slices.Clone(validVersions)
+	slices.SortFunc(validVersions, semver.Compare)
+	for i := len(validVersions) - 1; i >= 0; i-- {
+		if semver.Compare(validVersions[i], version) <= 0 {
+			return validVersions[i], nil
+		}
 	}
 
-	return string(respBody), resp.StatusCode, nil
+	return "", fmt.Errorf("api version %s of the Bastion not supported by the provider (supported: %v)",
+		version, validVersions)
 }
@@ -0,0 +1,393 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t testing.TB, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("splitting test server address: %s", err)
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatalf("parsing test server port: %s", err)
+	}
+
+	return &Client{
+		bastionIP:            host,
+		bastionPort:          portNumber,
+		bastionAPIBasePath:   "/api",
+		bastionAPIUserHeader: defaultAPIUserHeader,
+		bastionAPIVersion:    VersionWallixAPI312,
+		bastionUser:          "admin",
+		bastionToken:         "token",
+	}
+}
+
+func testPageItems(first, count int) []map[string]string {
+	items := make([]map[string]string, count)
+	for i := range items {
+		items[i] = map[string]string{"id": strconv.Itoa(first + i)}
+	}
+
+	return items
+}
+
+func TestNewRequestPaged(t *testing.T) {
+	tests := map[string]struct {
+		handler  http.HandlerFunc
+		expected int
+		code     int
+	}{
+		"next link header": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				if page < 2 {
+					w.Header().Set("Link",
+						fmt.Sprintf(`</api/v3.12/devices/?q=x&page=%d>; rel="next", </api/v3.12/devices/>; rel="first"`,
+							page+1))
+				}
+				_ = json.NewEncoder(w).Encode(testPageItems(page*2, 2))
+			},
+			expected: 6,
+			code:     http.StatusOK,
+		},
+		"total count header": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				if r.URL.Query().Get("q") != "device_name=x" {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+				w.Header().Set("X-Total-Count", "5")
+				_ = json.NewEncoder(w).Encode(testPageItems(offset, min(2, 5-offset)))
+			},
+			expected: 5,
+			code:     http.StatusOK,
+		},
+		"single page": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(testPageItems(0, 3))
+			},
+			expected: 3,
+			code:     http.StatusOK,
+		},
+		"error on next page": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("offset") != "" {
+					w.WriteHeader(http.StatusInternalServerError)
+
+					return
+				}
+				w.Header().Set("X-Total-Count", "4")
+				_ = json.NewEncoder(w).Encode(testPageItems(0, 2))
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, tt.handler)
+			body, code, err := c.newRequestPaged(context.Background(), "/devices/?q=device_name=x", http.MethodGet, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if code != tt.code {
+				t.Fatalf("expected code %d, got %d with body: %s", tt.code, code, body)
+			}
+			if code != http.StatusOK {
+				return
+			}
+			var results []map[string]string
+			if err := json.Unmarshal([]byte(body), &results); err != nil {
+				t.Fatalf("unmarshaling result: %s", err)
+			}
+			if len(results) != tt.expected {
+				t.Fatalf("expected %d results, got %d: %s", tt.expected, len(results), body)
+			}
+			for i, v := range results {
+				if v["id"] != strconv.Itoa(i) {
+					t.Errorf("expected id %d at index %d, got %s", i, i, v["id"])
+				}
+			}
+		})
+	}
+}
+
+func TestNewRequestPaged_notList(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Total-Count", "10")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+	body, code, err := c.newRequestPaged(context.Background(), "/devices/1", http.MethodGet, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if code != http.StatusOK || body != `{"id":"1"}` {
+		t.Fatalf("expected body returned as is, got %d: %s", code, body)
+	}
+}
+
+func TestParseAPIVersion(t *testing.T) {
+	tests := map[string]struct {
+		body      string
+		supported []string
+		expected  string
+		wantErr   bool
+	}{
+		"v3.12":            {body: `{"version": "3.12"}`, expected: VersionWallixAPI312},
+		"v3.8 prefixed":    {body: `{"version": "v3.8"}`, expected: VersionWallixAPI38},
+		"more recent":      {body: `{"version": "3.14"}`, expected: VersionWallixAPI312},
+		"between":          {body: `{"version": "3.10"}`, expected: VersionWallixAPI38},
+		"too old":          {body: `{"version": "3.6"}`, wantErr: true},
+		"invalid":          {body: `{"version": "latest"}`, wantErr: true},
+		"missing":          {body: `{}`, wantErr: true},
+		"not json":         {body: `<html></html>`, wantErr: true},
+		"other fields":     {body: `{"product": "bastion", "version": "3.12", "build": "1"}`, expected: VersionWallixAPI312},
+		"with whitespace":  {body: `{"version": " 3.8 "}`, expected: VersionWallixAPI38},
+		"supported extra":  {body: `{"version": "3.14"}`, supported: []string{"v3.14"}, expected: "v3.14"},
+		"extra too recent": {body: `{"version": "3.12"}`, supported: []string{"v3.14"}, expected: VersionWallixAPI312},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := parseAPIVersion(tt.body, append(defaultVersionsValid(), tt.supported...))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got version %s", version)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if version != tt.expected {
+				t.Fatalf("expected version %s, got %s", tt.expected, version)
+			}
+		})
+	}
+}
+
+func TestDetectAPIVersion(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/about" || r.Header.Get("X-Auth-Key") != "token" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"version": "3.12"}`))
+	})
+	c.bastionAPIVersion = ""
+	version, err := c.detectAPIVersion(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if version != VersionWallixAPI312 {
+		t.Fatalf("expected version %s, got %s", VersionWallixAPI312, version)
+	}
+
+	c = newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	if _, err := c.detectAPIVersion(context.Background()); err == nil {
+		t.Fatal("expected an error when /about doesn't return OK")
+	}
+}
+
+func TestConfigClientAPIVersion(t *testing.T) {
+	tests := map[string]struct {
+		code        int
+		about       string
+		expected    string
+		expectWarn  bool
+		warnMatches string
+	}{
+		"detected": {
+			code:     http.StatusOK,
+			about:    `{"version": "3.12"}`,
+			expected: VersionWallixAPI312,
+		},
+		"about not found": {
+			code:        http.StatusNotFound,
+			expected:    VersionWallixAPI38,
+			expectWarn:  true,
+			warnMatches: "api doesn't return OK on /about",
+		},
+		"unknown version": {
+			code:        http.StatusOK,
+			about:       `{"version": "2.0"}`,
+			expected:    VersionWallixAPI38,
+			expectWarn:  true,
+			warnMatches: "not supported by the provider",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/about" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				w.WriteHeader(tt.code)
+				_, _ = w.Write([]byte(tt.about))
+			})
+			config := Config{
+				bastionIP:            c.bastionIP,
+				bastionPort:          c.bastionPort,
+				bastionAPIBasePath:   c.bastionAPIBasePath,
+				bastionAPIUserHeader: c.bastionAPIUserHeader,
+				bastionUser:          c.bastionUser,
+				bastionToken:         c.bastionToken,
+			}
+			client, diags := config.Client(context.Background())
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if client.bastionAPIVersion != tt.expected {
+				t.Errorf("expected version %s, got %s", tt.expected, client.bastionAPIVersion)
+			}
+			if tt.expectWarn != (len(diags) == 1) {
+				t.Fatalf("expected warning %t, got %v", tt.expectWarn, diags)
+			}
+			if tt.expectWarn && !strings.Contains(diags[0].Detail, tt.warnMatches) {
+				t.Errorf("expected the warning to contain %q, got %q", tt.warnMatches, diags[0].Detail)
+			}
+		})
+	}
+}
+
+func TestClientAuthRefresh(t *testing.T) {
+	tests := map[string]struct {
+		authRefresh  bool
+		password     string
+		expectedCode int
+		expectedAuth []string
+	}{
+		"refresh with password": {
+			authRefresh:  true,
+			password:     "secret",
+			expectedCode: http.StatusOK,
+			expectedAuth: []string{"token", "basic", "basic"},
+		},
+		"refresh disabled": {
+			password:     "secret",
+			expectedCode: http.StatusUnauthorized,
+			expectedAuth: []string{"token", "token"},
+		},
+		"no password": {
+			authRefresh:  true,
+			expectedCode: http.StatusUnauthorized,
+			expectedAuth: []string{"token", "token"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var auths []string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Auth-Key") != "" {
+					auths = append(auths, "token")
+					// the token has expired
+					w.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+				user, password, ok := r.BasicAuth()
+				if !ok || user != "admin" || password != "secret" {
+					auths = append(auths, "invalid")
+					w.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+				auths = append(auths, "basic")
+				_, _ = w.Write([]byte(`[]`))
+			})
+			c.authRefresh = tt.authRefresh
+			c.bastionPwd = tt.password
+			for range 2 {
+				body, code, err := c.newRequest(context.Background(), "/users/", http.MethodGet, nil)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if code != tt.expectedCode {
+					t.Fatalf("expected code %d, got %d with body: %s", tt.expectedCode, code, body)
+				}
+			}
+			if fmt.Sprint(auths) != fmt.Sprint(tt.expectedAuth) {
+				t.Errorf("expected authentications %v, got %v", tt.expectedAuth, auths)
+			}
+		})
+	}
+}
+
+func TestClientAPIUserHeader(t *testing.T) {
+	for _, header := range []string{defaultAPIUserHeader, "X-User"} {
+		t.Run(header, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(header) != "admin" || r.Header.Get("X-Auth-Key") != "token" {
+					w.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+				_, _ = w.Write([]byte(`[]`))
+			})
+			c.bastionAPIUserHeader = header
+			_, code, err := c.newRequest(context.Background(), "/users/", http.MethodGet, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if code != http.StatusOK {
+				t.Errorf("expected the user in the %s header, got code %d", header, code)
+			}
+		})
+	}
+}
+
+func TestClientFieldsSelection(t *testing.T) {
+	tests := map[string]struct {
+		fieldsSelection bool
+		expectedFields  string
+	}{
+		"disabled": {},
+		"enabled":  {fieldsSelection: true, expectedFields: "id"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("q"); got != "service_name=SSH" {
+					t.Errorf("expected the search query to be kept, got %q", got)
+				}
+				if got := r.URL.Query().Get("fields"); got != tt.expectedFields {
+					t.Errorf("expected fields %q, got %q", tt.expectedFields, got)
+				}
+				_, _ = w.Write([]byte(`[{"id":"s1"}]`))
+			})
+			c.fieldsSelection = tt.fieldsSelection
+			id, ex, err := searchResourceDeviceService(context.Background(), "d1", "SSH", c)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !ex || id != "s1" {
+				t.Errorf("expected service s1, got %q (exists %t)", id, ex)
+			}
+		})
+	}
+	c := &Client{fieldsSelection: true}
+	if got := c.uriWithFields("/devices/"); got != "/devices/" {
+		t.Errorf("expected uri without fields when none is requested, got %q", got)
+	}
+	if got := c.uriWithFields("/devices/d1/services/", "id", "port"); got != "/devices/d1/services/?fields=id,port" {
+		t.Errorf("unexpected uri %q", got)
+	}
+}
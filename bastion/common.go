@@ -1,16 +1,132 @@
 package bastion
 
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	searchAfterCreateFirstDelay = 250 * time.Millisecond
+	searchAfterCreateTimeout    = 5 * time.Second
+)
+
 type jsonRestriction struct {
 	Action      string `json:"action"`
 	Rules       string `json:"rules"`
 	SubProtocol string `json:"subprotocol"`
 }
 
+func restrictionSubProtocolsValid() []string {
+	return []string{
+		"SSH_SHELL_SESSION",
+		"SSH_REMOTE_COMMAND",
+		"SSH_SCP_UP",
+		"SSH_SCP_DOWN",
+		"SFTP_SESSION",
+		"RLOGIN",
+		"TELNET",
+		"RDP",
+	}
+}
+
 type jsonCredential struct {
-	ID         string `json:"id,omitempty"`
-	Type       string `json:"type,omitempty"`
-	Password   string `json:"password,omitempty"`
-	PrivateKey string `json:"private_key,omitempty"`
-	PublicKey  string `json:"public_key,omitempty"`
-	Passphrase string `json:"passphrase,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Password    string `json:"password,omitempty"`
+	PrivateKey  string `json:"private_key,omitempty"`
+	PublicKey   string `json:"public_key,omitempty"`
+	Passphrase  string `json:"passphrase,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+	AutoChange  *bool  `json:"auto_change,omitempty"`
+}
+
+// forceNewWriteOnlyIfInState forces a new resource on a change of an attribute never returned by the API
+// only when the value is in state, the empty value left by an import is replaced in place by the next apply.
+func forceNewWriteOnlyIfInState(key string) schema.CustomizeDiffFunc {
+	return customdiff.ForceNewIf(key, func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+		oldValue, _ := d.GetChange(key)
+
+		return oldValue.(string) != ""
+	})
+}
+
+// patchSupported returns true if the api version accepts PATCH requests with partial objects.
+func patchSupported(apiVersion string) bool {
+	return semver.Compare(apiVersion, VersionWallixAPI312) >= 0
+}
+
+// prepareJSONPatch reduces the full object jsonData to the fields which have a change in the plan,
+// so that a PATCH request preserves the attributes not managed by the provider.
+// The json keys of jsonData must be the names of the attributes in the schema.
+func prepareJSONPatch(d *schema.ResourceData, jsonData interface{}) (map[string]interface{}, error) {
+	body, err := json.Marshal(jsonData)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling json: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	for k := range fields {
+		if !d.HasChange(k) {
+			delete(fields, k)
+		}
+	}
+
+	return fields, nil
+}
+
+// validatePEM returns a SchemaValidateFunc which checks that the value is a PEM block
+// with one of the blockTypes.
+func validatePEM(blockTypes ...string) schema.SchemaValidateFunc {
+	return func(val interface{}, key string) ([]string, []error) {
+		v, ok := val.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", key)}
+		}
+		block, _ := pem.Decode([]byte(v))
+		if block == nil {
+			return nil, []error{fmt.Errorf("%s is not a PEM encoded value", key)}
+		}
+		if !slices.Contains(blockTypes, block.Type) {
+			return nil, []error{fmt.Errorf("%s has a PEM block of type %q, expected %s",
+				key, block.Type, strings.Join(blockTypes, " or "))}
+		}
+
+		return nil, nil
+	}
+}
+
+// searchAfterCreate calls search until the object is found, with an exponential delay between the calls
+// bounded by searchAfterCreateTimeout, as a clustered appliance can return a new object with a replication lag.
+// The context given to search skips the response cache, which would return the first result again.
+func searchAfterCreate(
+	ctx context.Context, search func(context.Context) (string, bool, error),
+) (
+	string, bool, error,
+) {
+	delay := searchAfterCreateFirstDelay
+	deadline := time.Now().Add(searchAfterCreateTimeout)
+	searchCtx := withoutCache(ctx)
+	for {
+		id, ex, err := search(searchCtx)
+		if err != nil || ex || time.Now().Add(delay).After(deadline) {
+			return id, ex, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", false, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
 }
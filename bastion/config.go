@@ -1,28 +1,64 @@
 package bastion
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 // Config: provider config.
 type Config struct {
-	bastionPort       int
-	bastionAPIVersion string
-	bastionIP         string
-	bastionToken      string
-	bastionUser       string
-	bastionPwd        string
+	bastionPort          int
+	cacheTTLSeconds      int
+	bastionAPIVersion    string
+	bastionAPIBasePath   string
+	bastionAPIUserHeader string
+	bastionIP            string
+	bastionToken         string
+	bastionUser          string
+	bastionPwd           string
+	// additional api versions allowed with the 'supported_api_versions' attribute
+	supportedAPIVersions []string
+	authRefresh          bool
+	fieldsSelection      bool
 }
 
 // Client: read information to connect on wallix bastion.
-func (c *Config) Client() (*Client, diag.Diagnostics) {
+// The api version is detected on the Bastion when it isn't set,
+// the previous default version v3.8 is used with a warning when the detection fails.
+func (c *Config) Client(ctx context.Context) (*Client, diag.Diagnostics) {
 	cl := &Client{
-		bastionIP:         c.bastionIP,
-		bastionPort:       c.bastionPort,
-		bastionToken:      c.bastionToken,
-		bastionUser:       c.bastionUser,
-		bastionAPIVersion: c.bastionAPIVersion,
-		bastionPwd:        c.bastionPwd,
+		bastionIP:            c.bastionIP,
+		bastionPort:          c.bastionPort,
+		bastionToken:         c.bastionToken,
+		bastionUser:          c.bastionUser,
+		bastionAPIVersion:    c.bastionAPIVersion,
+		bastionAPIBasePath:   c.bastionAPIBasePath,
+		bastionAPIUserHeader: c.bastionAPIUserHeader,
+		bastionPwd:           c.bastionPwd,
+		supportedAPIVersions: c.supportedAPIVersions,
+		authRefresh:          c.authRefresh,
+		fieldsSelection:      c.fieldsSelection,
+	}
+	if c.cacheTTLSeconds > 0 {
+		cl.cache = newResponseCache(time.Duration(c.cacheTTLSeconds)*time.Second, cacheMaxEntries)
+	}
+	if cl.bastionAPIVersion == "" {
+		apiVersion, err := cl.detectAPIVersion(ctx)
+		if err != nil {
+			cl.bastionAPIVersion = VersionWallixAPI38
+
+			return cl, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Unable to detect the api version of the Bastion",
+				Detail: fmt.Sprintf("Detecting the api version with GET /about failed: %s\n\n"+
+					"The provider uses the api version %s, set 'api_version' to use another version "+
+					"or to skip the detection.", err, VersionWallixAPI38),
+			}}
+		}
+		cl.bastionAPIVersion = apiVersion
 	}
 
 	return cl, nil
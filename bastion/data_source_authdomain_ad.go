@@ -47,31 +47,31 @@ func dataSourceAuthDomainAD() *schema.Resource {
 	}
 }
 
-func dataSourceAuthDomainAdVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func dataSourceAuthDomainAdVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_authdomain not available with api version %s", version)
+	return fmt.Errorf("data source wallix-bastion_authdomain not available with api version %s", c.bastionAPIVersion)
 }
 
 func dataSourceAuthDomainADRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := dataSourceAuthDomainAdVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := dataSourceAuthDomainAdVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceAuthDomainAD(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s doesn't exists", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s doesn't exists", d.Get("domain_name").(string)))
 	}
 	cfg, err := readAuthDomainADOptions(ctx, id, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	fillSourceAuthDomainAD(d, cfg)
 	d.SetId(id)
@@ -56,24 +56,24 @@ func dataSourceConfigoption() *schema.Resource {
 	}
 }
 
-func dataSourceConfigoptionVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func dataSourceConfigoptionVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_configoption not available with api version %s", version)
+	return fmt.Errorf("data source wallix-bastion_configoption not available with api version %s", c.bastionAPIVersion)
 }
 
 func dataSourceConfigoptionRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := dataSourceConfigoptionVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := dataSourceConfigoptionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readConfigoption(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	fillConfigoption(d, cfg)
 	d.SetId(cfg.ID)
@@ -101,7 +101,7 @@ func readConfigoption(
 		return result, err
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
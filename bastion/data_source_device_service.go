@@ -0,0 +1,148 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDeviceService() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDeviceServiceRead,
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"device_id", "device_name"},
+			},
+			"device_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"device_id", "device_name"},
+			},
+			"service_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"connection_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"global_domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"subprotocols": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"jump_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"jump_service": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tls_enable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"tls_min_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tls_ciphers": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDeviceServiceVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("data source wallix-bastion_device_service not available with api version %s", c.bastionAPIVersion)
+}
+
+func dataSourceDeviceServiceRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceDeviceServiceVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	deviceID := d.Get("device_id").(string)
+	if deviceID == "" {
+		id, ex, err := searchResourceDevice(ctx, d.Get("device_name").(string), m)
+		if err != nil {
+			return diagFromAPIError(err)
+		}
+		if !ex {
+			return diagFromAPIError(fmt.Errorf("device_name %s doesn't exists", d.Get("device_name").(string)))
+		}
+		deviceID = id
+	}
+	cfgDevice, err := readDeviceOptions(ctx, deviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfgDevice.ID == "" {
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", deviceID))
+	}
+	serviceID, ex, err := searchResourceDeviceService(ctx, deviceID, d.Get("service_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("service_name %s doesn't exists on device %s",
+			d.Get("service_name").(string), cfgDevice.DeviceName))
+	}
+	cfg, err := readDeviceServiceOptions(ctx, deviceID, serviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		return diagFromAPIError(fmt.Errorf("service_name %s doesn't exists on device %s",
+			d.Get("service_name").(string), cfgDevice.DeviceName))
+	}
+	if tfErr := d.Set("device_id", deviceID); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("device_name", cfgDevice.DeviceName); tfErr != nil {
+		panic(tfErr)
+	}
+	if err := fillDeviceService(d, cfg); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(cfg.ID)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDeviceService_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeviceServiceConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceDeviceServiceConfigData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.wallix-bastion_device_service.testacc_dataDeviceService", "id",
+						"wallix-bastion_device_service.testacc_dataDeviceService", "id"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_device_service.testacc_dataDeviceService",
+						"port", "2222"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_device_service.testacc_dataDeviceService",
+						"subprotocols.#", "1"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_device_service.testacc_dataDeviceService",
+						"description", "testacc dataDeviceService"),
+					resource.TestCheckResourceAttrPair(
+						"data.wallix-bastion_device_service.testacc_dataDeviceServiceByName", "device_id",
+						"wallix-bastion_device.testacc_dataDeviceService", "id"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccDataSourceDeviceServiceConfigCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_dataDeviceService" {
+  device_name = "testacc_dataDeviceService"
+  host        = "testacc_dataservice.device"
+}
+resource "wallix-bastion_device_service" "testacc_dataDeviceService" {
+  device_id         = wallix-bastion_device.testacc_dataDeviceService.id
+  service_name      = "testacc_dataDeviceService"
+  connection_policy = "SSH"
+  port              = 2222
+  protocol          = "SSH"
+  subprotocols      = ["SSH_SHELL_SESSION"]
+  description       = "testacc dataDeviceService"
+}
+`
+}
+
+func testAccDataSourceDeviceServiceConfigData() string {
+	return testAccDataSourceDeviceServiceConfigCreate() + `
+data "wallix-bastion_device_service" "testacc_dataDeviceService" {
+  device_id    = wallix-bastion_device.testacc_dataDeviceService.id
+  service_name = wallix-bastion_device_service.testacc_dataDeviceService.service_name
+}
+data "wallix-bastion_device_service" "testacc_dataDeviceServiceByName" {
+  device_name  = wallix-bastion_device.testacc_dataDeviceService.device_name
+  service_name = wallix-bastion_device_service.testacc_dataDeviceService.service_name
+}
+`
+}
@@ -0,0 +1,98 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDeviceServices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDeviceServicesRead,
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"services": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"connection_policy": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDeviceServicesVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("data source wallix-bastion_device_services not available with api version %s", c.bastionAPIVersion)
+}
+
+func dataSourceDeviceServicesRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceDeviceServicesVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfgDevice, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfgDevice.ID == "" {
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
+	}
+	services, err := listDeviceServices(ctx, d.Get("device_id").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillSourceDeviceServices(d, services)
+	d.SetId(d.Get("device_id").(string))
+
+	return nil
+}
+
+func fillSourceDeviceServices(d *schema.ResourceData, jsonData []jsonDeviceService) {
+	services := make([]map[string]interface{}, len(jsonData))
+	for i, v := range jsonData {
+		services[i] = map[string]interface{}{
+			"id":                v.ID,
+			"service_name":      v.ServiceName,
+			"protocol":          v.Protocol,
+			"port":              v.Port,
+			"connection_policy": v.ConnectionPolicy,
+		}
+	}
+	if tfErr := d.Set("services", services); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,58 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDeviceServices_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeviceServicesConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceDeviceServicesConfigData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wallix-bastion_device_services.testacc_dataDeviceServices",
+						"services.#", "2"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccDataSourceDeviceServicesConfigCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_dataDeviceServices" {
+  device_name = "testacc_dataDeviceServices"
+  host        = "testacc_dataservices.device"
+}
+resource "wallix-bastion_device_service" "testacc_dataDeviceServices_ssh" {
+  device_id         = wallix-bastion_device.testacc_dataDeviceServices.id
+  service_name      = "testacc_dataDeviceServices_ssh"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+}
+resource "wallix-bastion_device_service" "testacc_dataDeviceServices_rdp" {
+  device_id         = wallix-bastion_device.testacc_dataDeviceServices.id
+  service_name      = "testacc_dataDeviceServices_rdp"
+  connection_policy = "RDP"
+  port              = 3389
+  protocol          = "RDP"
+}
+`
+}
+
+func testAccDataSourceDeviceServicesConfigData() string {
+	return testAccDataSourceDeviceServicesConfigCreate() + `
+data "wallix-bastion_device_services" "testacc_dataDeviceServices" {
+  device_id = wallix-bastion_device.testacc_dataDeviceServices.id
+}
+`
+}
@@ -0,0 +1,148 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type jsonDataSourceDevice struct {
+	ID         string                    `json:"id"`
+	Alias      string                    `json:"alias"`
+	DeviceName string                    `json:"device_name"`
+	Host       string                    `json:"host"`
+	Tags       []jsonDataSourceDeviceTag `json:"tags"`
+}
+
+type jsonDataSourceDeviceTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func dataSourceDevices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"alias": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDevicesVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("data source wallix-bastion_devices not available with api version %s", c.bastionAPIVersion)
+}
+
+func dataSourceDevicesRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceDevicesVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	devices, err := listDevices(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	// The API doesn't support filtering on tags, so the filter is applied here
+	tags := d.Get("tags").(map[string]interface{})
+	devices = slices.DeleteFunc(devices, func(v jsonDataSourceDevice) bool {
+		return !matchDataSourceDeviceTags(v, tags)
+	})
+	fillSourceDevices(d, devices)
+	tagsID := make([]string, 0, len(tags))
+	for k, v := range tags {
+		tagsID = append(tagsID, k+"="+v.(string))
+	}
+	slices.Sort(tagsID)
+	d.SetId("devices" + strings.Join(tagsID, ","))
+
+	return nil
+}
+
+// matchDataSourceDeviceTags returns true if the device has all the tags with the same value.
+func matchDataSourceDeviceTags(device jsonDataSourceDevice, tags map[string]interface{}) bool {
+	for k, v := range tags {
+		if !slices.ContainsFunc(device.Tags, func(t jsonDataSourceDeviceTag) bool {
+			return t.Key == k && t.Value == v.(string)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func listDevices(
+	ctx context.Context, m interface{},
+) (
+	[]jsonDataSourceDevice, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/devices/?sort=device_name", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonDataSourceDevice
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return results, nil
+}
+
+func fillSourceDevices(d *schema.ResourceData, jsonData []jsonDataSourceDevice) {
+	devices := make([]map[string]interface{}, len(jsonData))
+	for i, v := range jsonData {
+		devices[i] = map[string]interface{}{
+			"id":          v.ID,
+			"device_name": v.DeviceName,
+			"host":        v.Host,
+			"alias":       v.Alias,
+		}
+	}
+	if tfErr := d.Set("devices", devices); tfErr != nil {
+		panic(tfErr)
+	}
+}
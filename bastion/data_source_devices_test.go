@@ -0,0 +1,57 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDevices_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDevicesConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceDevicesConfigData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.wallix-bastion_devices.testacc_dataDevices",
+						"devices.#"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_devices.testacc_dataDevicesUnknownTag",
+						"devices.#", "0"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccDataSourceDevicesConfigCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_dataDevices" {
+  device_name = "testacc_dataDevices"
+  host        = "testacc_datadevices.device"
+  alias       = "testacc_dataDevices_alias"
+}
+`
+}
+
+func testAccDataSourceDevicesConfigData() string {
+	return `
+resource "wallix-bastion_device" "testacc_dataDevices" {
+  device_name = "testacc_dataDevices"
+  host        = "testacc_datadevices.device"
+  alias       = "testacc_dataDevices_alias"
+}
+
+data "wallix-bastion_devices" "testacc_dataDevices" {}
+
+data "wallix-bastion_devices" "testacc_dataDevicesUnknownTag" {
+  tags = {
+    testacc_dataDevices = "unknown"
+  }
+}
+`
+}
@@ -53,31 +53,31 @@ func dataSourceDomain() *schema.Resource {
 	}
 }
 
-func dataSourceDomainVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func dataSourceDomainVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_domain not available with api version %s", version)
+	return fmt.Errorf("data source wallix-bastion_domain not available with api version %s", c.bastionAPIVersion)
 }
 
 func dataSourceDomainRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := dataSourceDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := dataSourceDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceDomain(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s doesn't exists", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s doesn't exists", d.Get("domain_name").(string)))
 	}
 	cfg, err := readDomainOptions(ctx, id, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	fillSourceDomain(d, cfg)
 	d.SetId(id)
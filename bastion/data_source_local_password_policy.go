@@ -96,24 +96,25 @@ func dataSourceLocalPasswordPolicy() *schema.Resource {
 	}
 }
 
-func dataSourceLocalPasswordPolicyVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func dataSourceLocalPasswordPolicyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_local_password_policy not available with api version %s", version)
+	return fmt.Errorf("data source wallix-bastion_local_password_policy not available with api version %s",
+		c.bastionAPIVersion)
 }
 
 func dataSourceLocalPasswordPolicyRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := dataSourceLocalPasswordPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := dataSourceLocalPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readLocalPasswordPolicyOptions(ctx, d.Get("password_policy_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	fillLocalPasswordPolicy(d, cfg)
 	d.SetId(cfg.ID)
@@ -133,7 +134,7 @@ func readLocalPasswordPolicyOptions(
 		return jsonLocalPasswordPolicy{}, err
 	}
 	if code != http.StatusOK {
-		return jsonLocalPasswordPolicy{}, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return jsonLocalPasswordPolicy{}, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonLocalPasswordPolicy
 	err = json.Unmarshal([]byte(body), &results)
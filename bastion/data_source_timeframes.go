@@ -0,0 +1,153 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTimeframes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTimeframesRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"timeframes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timeframe_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_overtimable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"periods": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start_date": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"end_date": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"start_time": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"end_time": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"week_days": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTimeframesVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("data source wallix-bastion_timeframes not available with api version %s", c.bastionAPIVersion)
+}
+
+func dataSourceTimeframesRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceTimeframesVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	timeframes, err := listTimeframes(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	namePrefix := d.Get("name_prefix").(string)
+	timeframes = slices.DeleteFunc(timeframes, func(v jsonTimeframe) bool {
+		return !strings.HasPrefix(v.TimeframeName, namePrefix)
+	})
+	slices.SortFunc(timeframes, func(a, b jsonTimeframe) int {
+		return strings.Compare(a.TimeframeName, b.TimeframeName)
+	})
+	fillSourceTimeframes(d, timeframes)
+	d.SetId("timeframes" + namePrefix)
+
+	return nil
+}
+
+func listTimeframes(
+	ctx context.Context, m interface{},
+) (
+	[]jsonTimeframe, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/timeframes/?sort=timeframe_name", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonTimeframe
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return results, nil
+}
+
+func fillSourceTimeframes(d *schema.ResourceData, jsonData []jsonTimeframe) {
+	timeframes := make([]map[string]interface{}, len(jsonData))
+	for i, v := range jsonData {
+		periods := make([]map[string]interface{}, len(v.Periods))
+		for ii, p := range v.Periods {
+			periods[ii] = map[string]interface{}{
+				"start_date": p.StartDate,
+				"end_date":   p.EndDate,
+				"start_time": p.StartTime,
+				"end_time":   p.EndTime,
+				"week_days":  p.WeekDays,
+			}
+		}
+		timeframes[i] = map[string]interface{}{
+			"timeframe_name": v.TimeframeName,
+			"description":    v.Description,
+			"is_overtimable": v.IsOvertimable,
+			"periods":        periods,
+		}
+	}
+	if tfErr := d.Set("timeframes", timeframes); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,75 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTimeframes_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTimeframesConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceTimeframesConfigData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wallix-bastion_timeframes.testacc_dataTimeframes",
+						"timeframes.#", "2"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_timeframes.testacc_dataTimeframes",
+						"timeframes.0.timeframe_name", "testacc_dataTimeframes_a"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_timeframes.testacc_dataTimeframes",
+						"timeframes.1.timeframe_name", "testacc_dataTimeframes_b"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_timeframes.testacc_dataTimeframes",
+						"timeframes.1.periods.#", "1"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccDataSourceTimeframesConfigCreate() string {
+	return `
+resource "wallix-bastion_timeframe" "testacc_dataTimeframes_b" {
+  timeframe_name = "testacc_dataTimeframes_b"
+  periods {
+    start_date = "2020-01-01"
+    end_date   = "2020-02-02"
+    start_time = "08:00"
+    end_time   = "12:00"
+    week_days  = ["monday"]
+  }
+}
+resource "wallix-bastion_timeframe" "testacc_dataTimeframes_a" {
+  timeframe_name = "testacc_dataTimeframes_a"
+  description    = "testacc dataTimeframes"
+}
+`
+}
+
+func testAccDataSourceTimeframesConfigData() string {
+	return `
+resource "wallix-bastion_timeframe" "testacc_dataTimeframes_b" {
+  timeframe_name = "testacc_dataTimeframes_b"
+  periods {
+    start_date = "2020-01-01"
+    end_date   = "2020-02-02"
+    start_time = "08:00"
+    end_time   = "12:00"
+    week_days  = ["monday"]
+  }
+}
+resource "wallix-bastion_timeframe" "testacc_dataTimeframes_a" {
+  timeframe_name = "testacc_dataTimeframes_a"
+  description    = "testacc dataTimeframes"
+}
+
+data "wallix-bastion_timeframes" "testacc_dataTimeframes" {
+  name_prefix = "testacc_dataTimeframes_"
+}
+`
+}
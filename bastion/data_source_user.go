@@ -0,0 +1,118 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserRead,
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"profile": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"groups": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"is_disabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceUserVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("data source wallix-bastion_user not available with api version %s", c.bastionAPIVersion)
+}
+
+func dataSourceUserRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, ex, err := searchDataSourceUser(ctx, d.Get("user_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("user_name %s doesn't exists", d.Get("user_name").(string)))
+	}
+	fillSourceUser(d, cfg)
+	d.SetId(cfg.UserName)
+
+	return nil
+}
+
+func searchDataSourceUser(
+	ctx context.Context, userName string, m interface{},
+) (
+	jsonUser, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/users/?q=user_name="+userName, http.MethodGet, nil)
+	if err != nil {
+		return jsonUser{}, false, err
+	}
+	if code != http.StatusOK {
+		return jsonUser{}, false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonUser
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return jsonUser{}, false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	for _, v := range results {
+		if v.UserName == userName {
+			return v, true, nil
+		}
+	}
+
+	return jsonUser{}, false, nil
+}
+
+func fillSourceUser(d *schema.ResourceData, jsonData jsonUser) {
+	if tfErr := d.Set("email", jsonData.Email); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("display_name", jsonData.DisplayName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("profile", jsonData.Profile); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("groups", jsonData.Groups); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("is_disabled", jsonData.IsDisabled); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -4,9 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -63,7 +61,7 @@ func dataSourceVersionRead(
 ) diag.Diagnostics {
 	cfg, err := readVersionOptions(ctx, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	fillSourceVersion(d, cfg)
 	d.SetId("version")
@@ -78,28 +76,14 @@ func readVersionOptions(
 ) {
 	c := m.(*Client)
 	var result jsonVersion
-	url := "https://" + c.bastionIP + ":" + strconv.Itoa(c.bastionPort) + "/api/version"
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	req.Header.Add("Content-Type", "application/json; charset=utf-8")
-	req.Header.Add("X-Auth-Key", c.bastionToken)
-	req.Header.Add("X-Auth-User", c.bastionUser)
+	body, code, _, err := c.sendRequest(ctx, c.apiURL()+"/version", http.MethodGet, nil)
 	if err != nil {
-		return result, fmt.Errorf("preparing http request: %w", err)
+		return result, err
 	}
-	resp, err := defaultHTTPClient.Do(req)
-	if err != nil {
-		return result, fmt.Errorf("sending http request: %w", err)
-	}
-	defer resp.Body.Close()
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return result, fmt.Errorf("reading http response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", resp.StatusCode, string(respBody))
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
-	err = json.Unmarshal(respBody, &result)
+	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
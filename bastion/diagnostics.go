@@ -0,0 +1,76 @@
+package bastion
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// apiErrorHint is a suggested fix for the errors matching pattern, about the attribute.
+type apiErrorHint struct {
+	pattern   *regexp.Regexp
+	attribute string
+	hint      string
+}
+
+func apiErrorHints() []apiErrorHint {
+	return []apiErrorHint{
+		{
+			pattern: regexp.MustCompile(
+				`(?i)connection[ _]?polic(y|ies).*(not found|doesn't exist|does not exist|invalid|unknown)`),
+			attribute: "connection_policy",
+			hint: "Check that connection_policy is the name of an existing connection policy " +
+				"(e.g. managed with the wallix-bastion_connection_policy resource) " +
+				"and that its protocol matches the protocol of the service.",
+		},
+		{
+			// the device must be followed by its name or ID, not e.g. by the device_id of another object
+			pattern:   regexp.MustCompile(`(?i)\bdevice( with ID)? \S+ (not found|doesn't exists?|does not exist)`),
+			attribute: "device_id",
+			hint: "Check that device_id is the id of an existing device (e.g. wallix-bastion_device.<name>.id). " +
+				"If the device has been deleted outside of Terraform, " +
+				"run 'terraform apply -refresh-only' to update the state.",
+		},
+		{
+			pattern:   regexp.MustCompile(`(?i)port.*(already (used|in use|exists))`),
+			attribute: "port",
+			hint: "Another service of the device already uses this port with the same protocol, " +
+				"change the port or remove the other service.",
+		},
+	}
+}
+
+// diagFromAPIError converts err to diagnostics like diag.FromErr and, for the known errors
+// of the Bastion, adds a Detail with a suggested fix and the path of the attribute in cause.
+// When err is an *APIError with an error envelope, the Summary is the message of the envelope
+// and the Detail the full body of the response, after the suggested fix if any.
+func diagFromAPIError(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	diags := diag.FromErr(err)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if message := apiErr.Parse(); message != "" {
+			diags[0].Summary = fmt.Sprintf("%s: %d: %s", apiErr.message, apiErr.StatusCode, message)
+			diags[0].Detail = "body:\n" + apiErr.Body
+		}
+	}
+	for _, v := range apiErrorHints() {
+		if v.pattern.MatchString(err.Error()) {
+			if diags[0].Detail != "" {
+				diags[0].Detail = v.hint + "\n\n" + diags[0].Detail
+			} else {
+				diags[0].Detail = v.hint
+			}
+			diags[0].AttributePath = cty.GetAttrPath(v.attribute)
+
+			break
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,87 @@
+package bastion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestDiagFromAPIError(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		attribute string
+	}{
+		"invalid connection policy": {
+			err: errors.New("api doesn't return OK or NoContent: 400 with body:\n" +
+				"{\"error\": \"Connection policy 'SSHX' not found\"}"),
+			attribute: "connection_policy",
+		},
+		"missing device": {
+			err:       errors.New("device with ID 123 doesn't exists"),
+			attribute: "device_id",
+		},
+		"missing device from the api": {
+			err: errors.New("api doesn't return OK or NoContent: 404 with body:\n" +
+				"{\"error\": \"Device 'srv1' not found\"}"),
+			attribute: "device_id",
+		},
+		"missing service on a device": {
+			err: errors.New("service_name SSH on device_id d1 not found after POST"),
+		},
+		"port in use": {
+			err: errors.New("api doesn't return OK or NoContent: 400 with body:\n" +
+				"{\"error\": \"Port 22 already in use\"}"),
+			attribute: "port",
+		},
+		"unknown error": {
+			err: errors.New("api doesn't return OK: 500 with body:\ninternal error"),
+		},
+		"api error without envelope": {
+			err: newAPIError("api doesn't return OK", 502, "<html>Bad Gateway</html>"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := diagFromAPIError(tt.err)
+			if len(diags) != 1 || !diags.HasError() {
+				t.Fatalf("expected one error diagnostic, got %v", diags)
+			}
+			if diags[0].Summary != tt.err.Error() {
+				t.Errorf("expected summary %q, got %q", tt.err.Error(), diags[0].Summary)
+			}
+			if tt.attribute == "" {
+				if diags[0].Detail != "" || diags[0].AttributePath != nil {
+					t.Errorf("expected no hint, got %q on %v", diags[0].Detail, diags[0].AttributePath)
+				}
+
+				return
+			}
+			if diags[0].Detail == "" {
+				t.Error("expected a hint in Detail")
+			}
+			if !diags[0].AttributePath.Equals(cty.GetAttrPath(tt.attribute)) {
+				t.Errorf("expected attribute path %s, got %v", tt.attribute, diags[0].AttributePath)
+			}
+		})
+	}
+	apiErr := newAPIError("api doesn't return OK or NoContent", 400,
+		"{\"error\": \"Bad Request\", \"description\": \"Port 22 already in use\"}")
+	diags := diagFromAPIError(fmt.Errorf("adding service: %w", apiErr))
+	expected := "api doesn't return OK or NoContent: 400: Bad Request: Port 22 already in use"
+	if diags[0].Summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, diags[0].Summary)
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("port")) {
+		t.Errorf("expected attribute path port, got %v", diags[0].AttributePath)
+	}
+	if !strings.HasPrefix(diags[0].Detail, "Another service of the device already uses this port") ||
+		!strings.HasSuffix(diags[0].Detail, "body:\n"+apiErr.Body) {
+		t.Errorf("expected the hint followed by the body in Detail, got %q", diags[0].Detail)
+	}
+	if diags := diagFromAPIError(nil); diags != nil {
+		t.Errorf("expected no diagnostics for a nil error, got %v", diags)
+	}
+}
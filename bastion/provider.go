@@ -3,14 +3,24 @@ package bastion
 import (
 	"context"
 	"math"
+	"os"
+	"regexp"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
 	VersionWallixAPI38  = "v3.8"
 	VersionWallixAPI312 = "v3.12"
+
+	defaultAPIBasePath   = "/api"
+	defaultAPIUserHeader = "X-Auth-User"
+
+	// internal flag to request only the fields used by the read helpers, not a provider attribute
+	fieldsSelectionEnv = "WALLIX_BASTION_FIELDS_SELECTION"
 )
 
 func defaultVersionsValid() []string {
@@ -52,20 +62,64 @@ func Provider() *schema.Provider {
 			"api_version": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("WALLIX_BASTION_API_VERSION", VersionWallixAPI38),
+				DefaultFunc: schema.EnvDefaultFunc("WALLIX_BASTION_API_VERSION", nil),
+			},
+			"api_base_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WALLIX_BASTION_API_BASE_PATH", defaultAPIBasePath),
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(/|/.*[^/])?$`),
+					"must be empty or '/' for no prefix, or start with a '/' and not end with a '/'"),
+			},
+			"api_user_header": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WALLIX_BASTION_API_USER_HEADER", defaultAPIUserHeader),
+				// token of RFC 9110
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$"),
+					"must be a valid HTTP header name"),
+			},
+			"cache_ttl_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("WALLIX_BASTION_CACHE_TTL_SECONDS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"auth_refresh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WALLIX_BASTION_AUTH_REFRESH", true),
+			},
+			"supported_api_versions": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^v[0-9]+\.[0-9]+$`),
+						"must be an api version like 'v3.14'"),
+				},
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"wallix-bastion_configoption":          dataSourceConfigoption(),
+			"wallix-bastion_device_service":        dataSourceDeviceService(),
+			"wallix-bastion_device_services":       dataSourceDeviceServices(),
+			"wallix-bastion_devices":               dataSourceDevices(),
 			"wallix-bastion_domain":                dataSourceDomain(),
 			"wallix-bastion_local_password_policy": dataSourceLocalPasswordPolicy(),
+			"wallix-bastion_timeframes":            dataSourceTimeframes(),
+			"wallix-bastion_user":                  dataSourceUser(),
 			"wallix-bastion_version":               dataSourceVersion(),
 			"wallix-bastion_authdomain_ad":         dataSourceAuthDomainAD(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
+			"wallix-bastion_account_credential_rotation":           resourceAccountCredentialRotation(),
+			"wallix-bastion_admin_account":                         resourceAdminAccount(),
+			"wallix-bastion_apikey":                                resourceAPIKey(),
 			"wallix-bastion_application":                           resourceApplication(),
 			"wallix-bastion_application_localdomain":               resourceApplicationLocalDomain(),
 			"wallix-bastion_application_localdomain_account":       resourceApplicationLocalDomainAccount(),
+			"wallix-bastion_approval":                              resourceApproval(),
 			"wallix-bastion_authdomain_ad":                         resourceAuthDomainAD(),
 			"wallix-bastion_authdomain_azuread":                    resourceAuthDomainAzureAD(),
 			"wallix-bastion_authdomain_ldap":                       resourceAuthDomainLdap(),
@@ -74,45 +128,107 @@ func Provider() *schema.Provider {
 			"wallix-bastion_authorization":                         resourceAuthorization(),
 			"wallix-bastion_checkout_policy":                       resourceCheckoutPolicy(),
 			"wallix-bastion_cluster":                               resourceCluster(),
+			"wallix-bastion_command_detection_rule":                resourceCommandDetectionRule(),
+			"wallix-bastion_config_audit_retention":                resourceConfigAuditRetention(),
+			"wallix-bastion_config_backup":                         resourceConfigBackup(),
+			"wallix-bastion_config_local_password_policy":          resourceConfigLocalPasswordPolicy(),
+			"wallix-bastion_config_login_banner":                   resourceConfigLoginBanner(),
+			"wallix-bastion_config_ntp":                            resourceConfigNTP(),
+			"wallix-bastion_config_password_policy":                resourceConfigPasswordPolicy(),
+			"wallix-bastion_config_rdp_proxy":                      resourceConfigRDPProxy(),
+			"wallix-bastion_config_session_options":                resourceConfigSessionOptions(),
+			"wallix-bastion_config_smtp":                           resourceConfigSMTP(),
+			"wallix-bastion_config_snmp":                           resourceConfigSNMP(),
+			"wallix-bastion_config_ssh":                            resourceConfigSSH(),
+			"wallix-bastion_config_ssh_proxy_algorithms":           resourceConfigSSHProxyAlgorithms(),
+			"wallix-bastion_config_syslog":                         resourceConfigSyslog(),
+			"wallix-bastion_config_syslog_destination":             resourceConfigSyslogDestination(),
+			"wallix-bastion_config_user_authentication_policy":     resourceConfigUserAuthenticationPolicy(),
+			"wallix-bastion_config_webui":                          resourceConfigWebUI(),
 			"wallix-bastion_config_x509":                           resourceConfigX509(),
+			"wallix-bastion_config_x509_user_ca":                   resourceConfigX509UserCA(),
 			"wallix-bastion_connection_message":                    resourceConnectionMessage(),
 			"wallix-bastion_connection_policy":                     resourceConnectionPolicy(),
+			"wallix-bastion_data_transfer_limit":                   resourceDataTransferLimit(),
 			"wallix-bastion_device":                                resourceDevice(),
+			"wallix-bastion_device_ca":                             resourceDeviceCA(),
+			"wallix-bastion_device_certificate_validation":         resourceDeviceCertificateValidation(),
+			"wallix-bastion_device_hostkey":                        resourceDeviceHostKey(),
 			"wallix-bastion_device_localdomain":                    resourceDeviceLocalDomain(),
 			"wallix-bastion_device_localdomain_account":            resourceDeviceLocalDomainAccount(),
 			"wallix-bastion_device_localdomain_account_credential": resourceDeviceLocalDomainAccountCredential(),
 			"wallix-bastion_device_service":                        resourceDeviceService(),
+			"wallix-bastion_device_services":                       resourceDeviceServices(),
 			"wallix-bastion_domain":                                resourceDomain(),
 			"wallix-bastion_domain_account":                        resourceDomainAccount(),
 			"wallix-bastion_domain_account_credential":             resourceDomainAccountCredential(),
+			"wallix-bastion_encryption_initialization":             resourceEncryptionInitialization(),
+			"wallix-bastion_external_vault":                        resourceExternalVault(),
 			"wallix-bastion_externalauth_kerberos":                 resourceExternalAuthKerberos(),
 			"wallix-bastion_externalauth_ldap":                     resourceExternalAuthLdap(),
+			"wallix-bastion_externalauth_openid":                   resourceExternalAuthOpenID(),
 			"wallix-bastion_externalauth_radius":                   resourceExternalAuthRadius(),
 			"wallix-bastion_externalauth_saml":                     resourceExternalAuthSaml(),
 			"wallix-bastion_externalauth_tacacs":                   resourceExternalAuthTacacs(),
 			"wallix-bastion_encryption":                            resourceEncryption(),
+			"wallix-bastion_ha_configuration":                      resourceHAConfiguration(),
+			"wallix-bastion_ldap_mapping":                          resourceLdapMapping(),
+			"wallix-bastion_license":                               resourceLicense(),
+			"wallix-bastion_masking_policy":                        resourceMaskingPolicy(),
+			"wallix-bastion_notification":                          resourceNotification(),
+			"wallix-bastion_password_change_plugin":                resourcePasswordChangePlugin(),
 			"wallix-bastion_profile":                               resourceProfile(),
+			"wallix-bastion_restriction":                           resourceRestriction(),
+			"wallix-bastion_scan":                                  resourceScan(),
+			"wallix-bastion_scanjob":                               resourceScanjob(),
+			"wallix-bastion_session_notification":                  resourceSessionNotification(),
+			"wallix-bastion_target":                                resourceTarget(),
 			"wallix-bastion_targetgroup":                           resourceTargetGroup(),
+			"wallix-bastion_targetgroup_session_account":           resourceTargetGroupSessionAccount(),
 			"wallix-bastion_timeframe":                             resourceTimeframe(),
 			"wallix-bastion_user":                                  resourceUser(),
 			"wallix-bastion_usergroup":                             resourceUserGroup(),
+			"wallix-bastion_usergroup_user":                        resourceUserGroupUser(),
 		},
 		ConfigureContextFunc: configureProvider,
 	}
 }
 
+// normalizeAPIBasePath returns an empty prefix for "/" as the paths of the API are appended after a '/'.
+func normalizeAPIBasePath(basePath string) string {
+	if basePath == "/" {
+		return ""
+	}
+
+	return basePath
+}
+
 func configureProvider(
-	_ context.Context, d *schema.ResourceData,
+	ctx context.Context, d *schema.ResourceData,
 ) (
 	interface{}, diag.Diagnostics,
 ) {
 	config := Config{
-		bastionAPIVersion: d.Get("api_version").(string),
-		bastionIP:         d.Get("ip").(string),
-		bastionPort:       d.Get("port").(int),
-		bastionToken:      d.Get("token").(string),
-		bastionUser:       d.Get("user").(string),
-		bastionPwd:        d.Get("password").(string),
+		bastionAPIVersion:    d.Get("api_version").(string),
+		bastionAPIBasePath:   normalizeAPIBasePath(d.Get("api_base_path").(string)),
+		bastionAPIUserHeader: d.Get("api_user_header").(string),
+		bastionIP:            d.Get("ip").(string),
+		bastionPort:          d.Get("port").(int),
+		bastionToken:         d.Get("token").(string),
+		bastionUser:          d.Get("user").(string),
+		bastionPwd:           d.Get("password").(string),
+		cacheTTLSeconds:      d.Get("cache_ttl_seconds").(int),
+		authRefresh:          d.Get("auth_refresh").(bool),
+	}
+	if v := os.Getenv(fieldsSelectionEnv); v != "" {
+		fieldsSelection, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, diag.Errorf("invalid value %q for %s: %s", v, fieldsSelectionEnv, err)
+		}
+		config.fieldsSelection = fieldsSelection
+	}
+	for _, v := range d.Get("supported_api_versions").([]interface{}) {
+		config.supportedAPIVersions = append(config.supportedAPIVersions, v.(string))
 	}
 
 	if config.bastionIP == "" {
@@ -125,5 +241,5 @@ func configureProvider(
 		return nil, diag.Errorf("invalid value %d for 'port' configuration to configure provider", config.bastionPort)
 	}
 
-	return config.Client()
+	return config.Client(ctx)
 }
@@ -0,0 +1,51 @@
+package bastion
+
+import "testing"
+
+func TestProviderAPIBasePath(t *testing.T) {
+	tests := map[string]struct {
+		basePath   string
+		expectPath string
+		expectErr  bool
+	}{
+		"default": {
+			basePath:   "/api",
+			expectPath: "/api",
+		},
+		"nested": {
+			basePath:   "/bastion/api",
+			expectPath: "/bastion/api",
+		},
+		"empty": {
+			basePath:   "",
+			expectPath: "",
+		},
+		"root": {
+			basePath:   "/",
+			expectPath: "",
+		},
+		"trailing slash": {
+			basePath:  "/api/",
+			expectErr: true,
+		},
+		"without leading slash": {
+			basePath:  "api",
+			expectErr: true,
+		},
+	}
+	validate := Provider().Schema["api_base_path"].ValidateFunc
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, errs := validate(tt.basePath, "api_base_path")
+			if tt.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, errs)
+			}
+			if tt.expectErr {
+				return
+			}
+			if got := normalizeAPIBasePath(tt.basePath); got != tt.expectPath {
+				t.Errorf("expected base path %q, got %q", tt.expectPath, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonAccountCredentialRotation struct {
+	Status string `json:"status"`
+}
+
+func resourceAccountCredentialRotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountCredentialRotationCreate,
+		ReadContext:   resourceAccountCredentialRotationRead,
+		DeleteContext: resourceAccountCredentialRotationDelete,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rotated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAccountCredentialRotationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_account_credential_rotation not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceAccountCredentialRotationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAccountCredentialRotationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	rotatedAt := time.Now().UTC()
+	status, err := rotateAccountCredential(ctx, d.Get("account_id").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	// the ID encodes the rotation time so that each taint/replace triggers a new rotation
+	d.SetId(d.Get("account_id").(string) + "/" + strconv.FormatInt(rotatedAt.Unix(), 10))
+	if tfErr := d.Set("rotated_at", rotatedAt.Format(time.RFC3339)); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("status", status); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return resourceAccountCredentialRotationRead(ctx, d, m)
+}
+
+func resourceAccountCredentialRotationRead(
+	_ context.Context, _ *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAccountCredentialRotationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	// A rotation is a one-shot action, there is nothing to refresh from the API
+	return nil
+}
+
+func resourceAccountCredentialRotationDelete(
+	_ context.Context, d *schema.ResourceData, _ interface{},
+) diag.Diagnostics {
+	// A rotation can't be undone, so we simply remove the resource from the Terraform state
+	d.SetId("")
+
+	return nil
+}
+
+func rotateAccountCredential(
+	ctx context.Context, accountID string, m interface{},
+) (
+	string, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/accountchangepassword/"+accountID, http.MethodPost, nil)
+	if err != nil {
+		return "", err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return "", newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+	if strings.TrimSpace(body) == "" {
+		return "requested", nil
+	}
+	var result jsonAccountCredentialRotation
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return "", fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if result.Status == "" {
+		return "requested", nil
+	}
+
+	return result.Status, nil
+}
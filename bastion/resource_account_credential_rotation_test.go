@@ -0,0 +1,52 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceAccountCredentialRotation_basic(t *testing.T) {
+	resourceName := "wallix-bastion_account_credential_rotation.testacc_AccountCredentialRotation"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAccountCredentialRotationCreate("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "rotated_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+			{
+				Config: testAccResourceAccountCredentialRotationCreate("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "triggers.run", "2"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceAccountCredentialRotationCreate(run string) string {
+	return `
+resource "wallix-bastion_domain" "testacc_AccountCredentialRotation" {
+  domain_name      = "testacc_AccountCredentialRotation"
+  domain_real_name = "testacc.rotation"
+}
+resource "wallix-bastion_domain_account" "testacc_AccountCredentialRotation" {
+  domain_id     = wallix-bastion_domain.testacc_AccountCredentialRotation.id
+  account_name  = "testacc_AccountCredentialRotation"
+  account_login = "testacc_AccountCredentialRotation"
+}
+resource "wallix-bastion_account_credential_rotation" "testacc_AccountCredentialRotation" {
+  account_id = wallix-bastion_domain_account.testacc_AccountCredentialRotation.id
+  triggers = {
+    run = "` + run + `"
+  }
+}
+`
+}
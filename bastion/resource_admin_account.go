@@ -0,0 +1,317 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAdminAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAdminAccountCreate,
+		ReadContext:   resourceAdminAccountRead,
+		UpdateContext: resourceAdminAccountUpdate,
+		DeleteContext: resourceAdminAccountDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAdminAccountImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"login": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"profile": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"is_disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"user_auths": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAdminAccountVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_admin_account not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceAdminAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAdminAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	ex, err := checkResourceUserExists(ctx, d.Get("login").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("login %s already exists", d.Get("login").(string)))
+	}
+	if err := checkAdminAccountProfile(ctx, d.Get("profile").(string), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := addAdminAccount(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(d.Get("login").(string))
+
+	return resourceAdminAccountRead(ctx, d, m)
+}
+
+func resourceAdminAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAdminAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readUserOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.UserName == "" {
+		d.SetId("")
+	} else {
+		fillAdminAccount(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceAdminAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceAdminAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("profile") {
+		if err := checkAdminAccountProfile(ctx, d.Get("profile").(string), m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	if err := updateAdminAccount(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceAdminAccountRead(ctx, d, m)
+}
+
+func resourceAdminAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAdminAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := checkAdminAccountNotLast(ctx, d.Id(), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteAdminAccount(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceAdminAccountImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceAdminAccountVersionCheck(c); err != nil {
+		return nil, err
+	}
+	cfg, err := readUserOptions(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.UserName == "" {
+		return nil, fmt.Errorf("don't find login with id %s (id must be <login>)", d.Id())
+	}
+	if err := checkAdminAccountProfile(ctx, cfg.Profile, m); err != nil {
+		return nil, fmt.Errorf("user %s is not an administrator: %w", d.Id(), err)
+	}
+	fillAdminAccount(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+// isAdminProfile returns true if the profile gives the administration of the Bastion,
+// i.e. the modification of the system settings.
+func isAdminProfile(profile jsonProfile) bool {
+	return profile.GuiFeatures.SystemSettings != nil && *profile.GuiFeatures.SystemSettings == "modify"
+}
+
+func listAdminProfiles(ctx context.Context, m interface{}) ([]string, error) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/profiles/", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonProfile
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	profiles := make([]string, 0, len(results))
+	for _, v := range results {
+		if isAdminProfile(v) {
+			profiles = append(profiles, v.ProfileName)
+		}
+	}
+
+	return profiles, nil
+}
+
+func checkAdminAccountProfile(ctx context.Context, profile string, m interface{}) error {
+	profiles, err := listAdminProfiles(ctx, m)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(profiles, profile) {
+		return fmt.Errorf("profile %s doesn't exist or doesn't allow to modify the system settings (admin profiles: %v)",
+			profile, profiles)
+	}
+
+	return nil
+}
+
+// checkAdminAccountNotLast returns an error if login is the last enabled administrator,
+// deleting it would lock the administration of the Bastion.
+func checkAdminAccountNotLast(ctx context.Context, login string, m interface{}) error {
+	profiles, err := listAdminProfiles(ctx, m)
+	if err != nil {
+		return err
+	}
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/users/", http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonUser
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return fmt.Errorf("unmarshaling json: %w", err)
+	}
+	for _, v := range results {
+		if v.UserName != login && !v.IsDisabled && slices.Contains(profiles, v.Profile) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("login %s is the last enabled administrator of the Bastion, it can't be deleted", login)
+}
+
+func addAdminAccount(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareAdminAccountJSON(d, true)
+	body, code, err := c.newRequest(ctx, "/users/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateAdminAccount(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareAdminAccountJSON(d, false)
+	body, code, err := c.newRequest(ctx, "/users/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteAdminAccount(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/users/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareAdminAccountJSON(d *schema.ResourceData, newResource bool) jsonUser {
+	jsonData := jsonUser{
+		UserName:   d.Get("login").(string),
+		Email:      d.Get("email").(string),
+		Profile:    d.Get("profile").(string),
+		IsDisabled: d.Get("is_disabled").(bool),
+		UserAuths:  []string{"local"},
+	}
+	if newResource || d.HasChange("password") {
+		jsonData.Password = d.Get("password").(string)
+	}
+	if listUserAuths := d.Get("user_auths").(*schema.Set).List(); len(listUserAuths) > 0 {
+		jsonData.UserAuths = make([]string, len(listUserAuths))
+		for i, v := range listUserAuths {
+			jsonData.UserAuths[i] = v.(string)
+		}
+	}
+
+	return jsonData
+}
+
+// fillAdminAccount sets all the attributes except password which is never returned by the API.
+func fillAdminAccount(d *schema.ResourceData, jsonData jsonUser) {
+	if tfErr := d.Set("login", jsonData.UserName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("email", jsonData.Email); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("profile", jsonData.Profile); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("is_disabled", jsonData.IsDisabled); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("user_auths", jsonData.UserAuths); tfErr != nil {
+		panic(tfErr)
+	}
+}
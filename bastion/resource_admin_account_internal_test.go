@@ -0,0 +1,66 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckAdminAccountNotLast(t *testing.T) {
+	modify := "modify"
+	view := "view"
+	profiles := []jsonProfile{{ProfileName: "product_administrator"}, {ProfileName: "auditor"}}
+	profiles[0].GuiFeatures.SystemSettings = &modify
+	profiles[1].GuiFeatures.SystemSettings = &view
+	tests := map[string]struct {
+		users    []jsonUser
+		errMatch string
+	}{
+		"other admin": {
+			users: []jsonUser{
+				{UserName: "admin", Profile: "product_administrator"},
+				{UserName: "admin2", Profile: "product_administrator"},
+			},
+		},
+		"last admin": {
+			users: []jsonUser{
+				{UserName: "admin", Profile: "product_administrator"},
+				{UserName: "audit", Profile: "auditor"},
+			},
+			errMatch: "login admin is the last enabled administrator of the Bastion",
+		},
+		"other admin disabled": {
+			users: []jsonUser{
+				{UserName: "admin", Profile: "product_administrator"},
+				{UserName: "admin2", Profile: "product_administrator", IsDisabled: true},
+			},
+			errMatch: "login admin is the last enabled administrator of the Bastion",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v3.12/profiles/":
+					_ = json.NewEncoder(w).Encode(profiles)
+				case "/api/v3.12/users/":
+					_ = json.NewEncoder(w).Encode(tt.users)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			err := checkAdminAccountNotLast(context.Background(), "admin", c)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceAdminAccount_basic(t *testing.T) {
+	resourceName := "wallix-bastion_admin_account.testacc_AdminAccount"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAdminAccountCreate(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "testacc_AdminAccount"),
+					resource.TestCheckResourceAttr(resourceName, "profile", "product_administrator"),
+				),
+			},
+			{
+				Config: testAccResourceAdminAccountCreate(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "is_disabled", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "testacc_AdminAccount",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceAdminAccountCreate(disabled bool) string {
+	isDisabled := "false"
+	if disabled {
+		isDisabled = "true"
+	}
+
+	return `
+resource "wallix-bastion_admin_account" "testacc_AdminAccount" {
+  login       = "testacc_AdminAccount"
+  email       = "testacc_adminaccount@none.none"
+  profile     = "product_administrator"
+  password    = "Testacc_AdminAccount1!"
+  is_disabled = ` + isDisabled + `
+}
+`
+}
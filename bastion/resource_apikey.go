@@ -0,0 +1,215 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonAPIKey struct {
+	ID             string `json:"id,omitempty"`
+	UserName       string `json:"user_name"`
+	Description    string `json:"description"`
+	ExpirationDate string `json:"expiration_date,omitempty"`
+	Key            string `json:"key,omitempty"`
+}
+
+func resourceAPIKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAPIKeyCreate,
+		ReadContext:   resourceAPIKeyRead,
+		DeleteContext: resourceAPIKeyDelete,
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"expiration_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}$`),
+					"must be in format \"yyyy-mm-dd hh:mm\""),
+			},
+			"rotate_when_changed": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceAPIKeyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_apikey not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceAPIKeyCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAPIKeyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	ex, err := checkResourceUserExists(ctx, d.Get("user_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("user_name %s doesn't exists", d.Get("user_name").(string)))
+	}
+	result, err := addAPIKey(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(result.ID)
+	// the key is only returned on creation
+	if tfErr := d.Set("key", result.Key); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return resourceAPIKeyRead(ctx, d, m)
+}
+
+func resourceAPIKeyRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAPIKeyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readAPIKeyOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillAPIKey(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceAPIKeyDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAPIKeyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteAPIKey(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func addAPIKey(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) (
+	jsonAPIKey, error,
+) {
+	c := m.(*Client)
+	var result jsonAPIKey
+	jsonData := jsonAPIKey{
+		UserName:       d.Get("user_name").(string),
+		Description:    d.Get("description").(string),
+		ExpirationDate: d.Get("expiration_date").(string),
+	}
+	body, code, err := c.newRequest(ctx, "/apikeys/", http.MethodPost, jsonData)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK && code != http.StatusCreated {
+		return result, newAPIError("api doesn't return OK or Created", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if result.ID == "" || result.Key == "" {
+		return result, errors.New("api doesn't return the id and the key of the new api key")
+	}
+
+	return result, nil
+}
+
+// deleteAPIKey revokes the key, a key already revoked is ignored.
+func deleteAPIKey(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/apikeys/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent && code != http.StatusNotFound {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func readAPIKeyOptions(
+	ctx context.Context, keyID string, m interface{},
+) (
+	jsonAPIKey, error,
+) {
+	c := m.(*Client)
+	var result jsonAPIKey
+	body, code, err := c.newRequest(ctx, "/apikeys/"+keyID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+// fillAPIKey doesn't set key, the value can't be retrieved after the creation.
+func fillAPIKey(d *schema.ResourceData, jsonData jsonAPIKey) {
+	if tfErr := d.Set("user_name", jsonData.UserName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("expiration_date", jsonData.ExpirationDate); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,55 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceAPIKeyReadKeepsKey(t *testing.T) {
+	revoked := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v3.12/users/automation":
+			_, _ = w.Write([]byte(`{"user_name":"automation"}`))
+		case r.URL.Path == "/api/v3.12/apikeys/" && r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(jsonAPIKey{ID: "k1", UserName: "automation", Key: "secret"})
+		case r.URL.Path == "/api/v3.12/apikeys/k1" && r.Method == http.MethodGet:
+			if revoked {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+			_ = json.NewEncoder(w).Encode(jsonAPIKey{ID: "k1", UserName: "automation", Description: "ci"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	d := schema.TestResourceDataRaw(t, resourceAPIKey().Schema, map[string]interface{}{
+		"user_name":   "automation",
+		"description": "ci",
+	})
+	if diags := resourceAPIKeyCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "k1" || d.Get("key").(string) != "secret" {
+		t.Fatalf("expected id k1 with the key in state, got id %q", d.Id())
+	}
+	if diags := resourceAPIKeyRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("key").(string) != "secret" {
+		t.Errorf("expected the key to be kept in state after a read")
+	}
+	revoked = true
+	if diags := resourceAPIKeyRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the revoked key to be removed from state, got id %q", d.Id())
+	}
+}
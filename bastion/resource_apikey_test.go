@@ -0,0 +1,51 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceAPIKey_basic(t *testing.T) {
+	resourceName := "wallix-bastion_apikey.testacc_APIKey"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAPIKeyCreate("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "key"),
+				),
+			},
+			{
+				Config: testAccResourceAPIKeyCreate("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rotate_when_changed.rotation", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "key"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceAPIKeyCreate(rotation string) string {
+	return `
+resource "wallix-bastion_user" "testacc_APIKey" {
+  user_name  = "testacc_APIKey"
+  email      = "testacc-apikey@none.none"
+  profile    = "user"
+  user_auths = ["local_password"]
+}
+resource "wallix-bastion_apikey" "testacc_APIKey" {
+  user_name       = wallix-bastion_user.testacc_APIKey.user_name
+  description     = "testacc APIKey"
+  expiration_date = "2099-12-31 23:59"
+  rotate_when_changed = {
+    rotation = "` + rotation + `"
+  }
+}
+`
+}
@@ -155,38 +155,38 @@ func resourceApplication() *schema.Resource {
 	}
 }
 
-func resourceApplicationVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceApplicationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_application not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_application not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceApplicationCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceApplication(ctx, d.Get("application_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("application_name %s already exists", d.Get("application_name").(string)))
+		return diagFromAPIError(fmt.Errorf("application_name %s already exists", d.Get("application_name").(string)))
 	}
 	err = addApplication(ctx, d, m, c.bastionAPIVersion)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceApplication(ctx, d.Get("application_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("application_name %s not found after POST", d.Get("application_name").(string)))
+		return diagFromAPIError(fmt.Errorf("application_name %s not found after POST", d.Get("application_name").(string)))
 	}
 	d.SetId(id)
 
@@ -197,12 +197,12 @@ func resourceApplicationRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readApplicationOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -218,11 +218,11 @@ func resourceApplicationUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceApplicationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateApplication(ctx, d, m, c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -233,11 +233,11 @@ func resourceApplicationDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteApplication(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -250,7 +250,7 @@ func resourceApplicationImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceApplicationVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceApplicationVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceApplication(ctx, d.Id(), m)
@@ -278,12 +278,12 @@ func searchResourceApplication(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/applications/?q=application_name="+applicationName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/applications/?q=application_name="+applicationName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonApplication
 	err = json.Unmarshal([]byte(body), &results)
@@ -310,7 +310,7 @@ func addApplication(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -329,7 +329,7 @@ func updateApplication(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -344,7 +344,7 @@ func deleteApplication(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -453,7 +453,7 @@ func readApplicationOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -79,48 +79,49 @@ func resourceApplicationLocalDomain() *schema.Resource {
 	}
 }
 
-func resourceApplicationLocalDomainVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceApplicationLocalDomainVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_application_localdomain not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_application_localdomain not available with api version %s",
+		c.bastionAPIVersion)
 }
 
 func resourceApplicationLocalDomainCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfgApplication, err := readApplicationOptions(ctx, d.Get("application_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgApplication.ID == "" {
-		return diag.FromErr(fmt.Errorf("application with ID %s doesn't exists", d.Get("application_id").(string)))
+		return diagFromAPIError(fmt.Errorf("application with ID %s doesn't exists", d.Get("application_id").(string)))
 	}
 	_, ex, err := searchResourceApplicationLocalDomain(ctx,
 		d.Get("application_id").(string), d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s on application_id %s already exists",
+		return diagFromAPIError(fmt.Errorf("domain_name %s on application_id %s already exists",
 			d.Get("domain_name").(string), d.Get("application_id").(string)))
 	}
 	err = addApplicationLocalDomain(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceApplicationLocalDomain(ctx,
 		d.Get("application_id").(string), d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s on application_id %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("domain_name %s on application_id %s not found after POST",
 			d.Get("domain_name").(string), d.Get("application_id").(string)))
 	}
 	d.SetId(id)
@@ -132,12 +133,12 @@ func resourceApplicationLocalDomainRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readApplicationLocalDomainOptions(ctx, d.Get("application_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -153,11 +154,11 @@ func resourceApplicationLocalDomainUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateApplicationLocalDomain(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -168,11 +169,11 @@ func resourceApplicationLocalDomainDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteApplicationLocalDomain(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -185,7 +186,7 @@ func resourceApplicationLocalDomainImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceApplicationLocalDomainVersionCheck(c); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -220,13 +221,13 @@ func searchResourceApplicationLocalDomain(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/applications/"+applicationID+
+	body, code, err := c.newRequestPaged(ctx, "/applications/"+applicationID+
 		"/localdomains/?q=domain_name="+domainName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonApplicationLocalDomain
 	err = json.Unmarshal([]byte(body), &results)
@@ -251,7 +252,7 @@ func addApplicationLocalDomain(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -268,7 +269,7 @@ func updateApplicationLocalDomain(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -284,7 +285,7 @@ func deleteApplicationLocalDomain(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -333,7 +334,7 @@ func readApplicationLocalDomainOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
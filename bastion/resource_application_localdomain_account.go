@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type jsonApplicationLocalDomainAccount struct {
@@ -21,6 +22,7 @@ type jsonApplicationLocalDomainAccount struct {
 	DomainPasswordChange *bool            `json:"domain_password_change,omitempty"`
 	AutoChangePassword   bool             `json:"auto_change_password"`
 	CheckoutPolicy       string           `json:"checkout_policy"`
+	PasswordChangePolicy string           `json:"password_change_policy,omitempty"`
 	Credentials          []jsonCredential `json:"credentials"`
 }
 
@@ -74,64 +76,78 @@ func resourceApplicationLocalDomainAccount() *schema.Resource {
 				Optional:  true,
 				Sensitive: true,
 			},
+			"password_change_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"propagate_now": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
 		},
 	}
 }
 
-func resourceApplicationLocalDomainAccountVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceApplicationLocalDomainAccountVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_application_localdomain_account not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_application_localdomain_account not available with api version %s",
+		c.bastionAPIVersion)
 }
 
 func resourceApplicationLocalDomainAccountCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfgApplication, err := readApplicationOptions(ctx, d.Get("application_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgApplication.ID == "" {
-		return diag.FromErr(fmt.Errorf("application with ID %s doesn't exists", d.Get("application_id").(string)))
+		return diagFromAPIError(fmt.Errorf("application with ID %s doesn't exists", d.Get("application_id").(string)))
 	}
 	cfgDomain, err := readApplicationLocalDomainOptions(ctx,
 		d.Get("application_id").(string), d.Get("domain_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDomain.ID == "" {
-		return diag.FromErr(fmt.Errorf("domain_id with ID %s on application_id %s doesn't exists",
+		return diagFromAPIError(fmt.Errorf("domain_id with ID %s on application_id %s doesn't exists",
 			d.Get("domain_id").(string), d.Get("application_id").(string)))
 	}
 	_, ex, err := searchResourceApplicationLocalDomainAccount(ctx,
 		d.Get("application_id").(string), d.Get("domain_id").(string), d.Get("account_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("account_name %s on domain_id %s, application_id %s already exists",
+		return diagFromAPIError(fmt.Errorf("account_name %s on domain_id %s, application_id %s already exists",
 			d.Get("account_name").(string), d.Get("domain_id").(string), d.Get("application_id").(string)))
 	}
 	err = addApplicationLocalDomainAccount(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceApplicationLocalDomainAccount(ctx,
 		d.Get("application_id").(string), d.Get("domain_id").(string), d.Get("account_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("account_name %s on domain_id %s, application_id %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("account_name %s on domain_id %s, application_id %s not found after POST",
 			d.Get("account_name").(string), d.Get("domain_id").(string), d.Get("application_id").(string)))
 	}
 	d.SetId(id)
+	if err := propagateApplicationLocalDomainAccountPassword(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
 
 	return resourceApplicationLocalDomainAccountRead(ctx, d, m)
 }
@@ -140,13 +156,13 @@ func resourceApplicationLocalDomainAccountRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readApplicationLocalDomainAccountOptions(ctx,
 		d.Get("application_id").(string), d.Get("domain_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -162,11 +178,14 @@ func resourceApplicationLocalDomainAccountUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateApplicationLocalDomainAccount(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
+	}
+	if err := propagateApplicationLocalDomainAccountPassword(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -177,11 +196,11 @@ func resourceApplicationLocalDomainAccountDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceApplicationLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteApplicationLocalDomainAccount(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -195,7 +214,7 @@ func resourceApplicationLocalDomainAccountImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceApplicationLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceApplicationLocalDomainAccountVersionCheck(c); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -234,13 +253,13 @@ func searchResourceApplicationLocalDomainAccount(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/applications/"+applicationID+"/localdomains/"+domainID+
+	body, code, err := c.newRequestPaged(ctx, "/applications/"+applicationID+"/localdomains/"+domainID+
 		"/accounts/?q=account_name="+accountName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonApplicationLocalDomainAccount
 	err = json.Unmarshal([]byte(body), &results)
@@ -266,7 +285,7 @@ func addApplicationLocalDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -284,7 +303,7 @@ func updateApplicationLocalDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -301,7 +320,20 @@ func deleteApplicationLocalDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+// propagateApplicationLocalDomainAccountPassword requests an immediate change of the password
+// when propagate_now is set on creation or changed.
+func propagateApplicationLocalDomainAccountPassword(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if !d.HasChange("propagate_now") || d.Get("propagate_now").(string) == "" {
+		return nil
+	}
+	if _, err := rotateAccountCredential(ctx, d.Id(), m); err != nil {
+		return fmt.Errorf("propagate_now: %w", err)
 	}
 
 	return nil
@@ -309,11 +341,12 @@ func deleteApplicationLocalDomainAccount(
 
 func prepareApplicationLocalDomainAccountJSON(d *schema.ResourceData) jsonApplicationLocalDomainAccount {
 	jsonData := jsonApplicationLocalDomainAccount{
-		AccountLogin:       d.Get("account_login").(string),
-		AccountName:        d.Get("account_name").(string),
-		AutoChangePassword: d.Get("auto_change_password").(bool),
-		CheckoutPolicy:     d.Get("checkout_policy").(string),
-		Description:        d.Get("description").(string),
+		AccountLogin:         d.Get("account_login").(string),
+		AccountName:          d.Get("account_name").(string),
+		AutoChangePassword:   d.Get("auto_change_password").(bool),
+		CheckoutPolicy:       d.Get("checkout_policy").(string),
+		Description:          d.Get("description").(string),
+		PasswordChangePolicy: d.Get("password_change_policy").(string),
 	}
 
 	credentials := make([]jsonCredential, 0)
@@ -345,7 +378,7 @@ func readApplicationLocalDomainAccountOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -374,4 +407,7 @@ func fillApplicationLocalDomainAccount(d *schema.ResourceData, jsonData jsonAppl
 	if tfErr := d.Set("domain_password_change", jsonData.DomainPasswordChange); tfErr != nil {
 		panic(tfErr)
 	}
+	if tfErr := d.Set("password_change_policy", jsonData.PasswordChangePolicy); tfErr != nil {
+		panic(tfErr)
+	}
 }
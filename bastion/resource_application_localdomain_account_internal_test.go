@@ -0,0 +1,78 @@
+package bastion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPropagateApplicationLocalDomainAccountPassword(t *testing.T) {
+	tests := map[string]struct {
+		propagateNow string
+		wantRequest  bool
+	}{
+		"set": {
+			propagateNow: "2024-01-01",
+			wantRequest:  true,
+		},
+		"unset": {
+			propagateNow: "",
+			wantRequest:  false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			requested := false
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v3.12/accountchangepassword/a1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				requested = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+			d := schema.TestResourceDataRaw(t, resourceApplicationLocalDomainAccount().Schema, map[string]interface{}{
+				"application_id": "app1",
+				"domain_id":      "dom1",
+				"account_name":   "account",
+				"account_login":  "login",
+				"propagate_now":  tt.propagateNow,
+			})
+			d.SetId("a1")
+			if err := propagateApplicationLocalDomainAccountPassword(context.Background(), d, c); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if requested != tt.wantRequest {
+				t.Errorf("expected password change request %t, got %t", tt.wantRequest, requested)
+			}
+		})
+	}
+}
+
+func TestFillApplicationLocalDomainAccountKeepsPassword(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceApplicationLocalDomainAccount().Schema, map[string]interface{}{
+		"application_id": "app1",
+		"domain_id":      "dom1",
+		"account_name":   "account",
+		"account_login":  "login",
+		"password":       "secret",
+	})
+	// the api never returns the password in the credentials of the account
+	fillApplicationLocalDomainAccount(d, jsonApplicationLocalDomainAccount{
+		AccountName:          "account",
+		AccountLogin:         "login",
+		CheckoutPolicy:       "default",
+		PasswordChangePolicy: "default",
+		Credentials:          []jsonCredential{{Type: "password"}},
+	})
+	if v := d.Get("password").(string); v != "secret" {
+		t.Errorf("expected password to be kept, got %q", v)
+	}
+	if v := d.Get("password_change_policy").(string); v != "default" {
+		t.Errorf("expected password_change_policy default, got %q", v)
+	}
+}
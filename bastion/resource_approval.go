@@ -0,0 +1,334 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonApproval struct {
+	ID             string   `json:"id,omitempty"`
+	ApprovalName   string   `json:"approval_name"`
+	Description    string   `json:"description"`
+	ActiveQuorum   int      `json:"active_quorum"`
+	InactiveQuorum int      `json:"inactive_quorum"`
+	Timeout        int      `json:"timeout"`
+	Approvers      []string `json:"approvers"`
+	Authorizations []string `json:"authorizations"`
+}
+
+func resourceApproval() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceApprovalCreate,
+		ReadContext:   resourceApprovalRead,
+		UpdateContext: resourceApprovalUpdate,
+		DeleteContext: resourceApprovalDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceApprovalImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"approval_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"approvers": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"active_quorum": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			"inactive_quorum": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"authorizations": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceApprovalVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_approval not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceApprovalCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceApprovalVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceApproval(ctx, d.Get("approval_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("approval_name %s already exists", d.Get("approval_name").(string)))
+	}
+	err = addApproval(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceApproval(ctx, d.Get("approval_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("approval_name %s not found after POST", d.Get("approval_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceApprovalRead(ctx, d, m)
+}
+
+func resourceApprovalRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceApprovalVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readApprovalOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillApproval(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceApprovalUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceApprovalVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateApproval(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceApprovalRead(ctx, d, m)
+}
+
+func resourceApprovalDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceApprovalVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteApproval(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceApprovalImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceApprovalVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceApproval(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find approval_name with id %s (id must be <approval_name>)", d.Id())
+	}
+	cfg, err := readApprovalOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillApproval(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceApproval(
+	ctx context.Context, approvalName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/approvals/?q=approval_name="+approvalName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonApproval
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addApproval(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareApprovalJSON(d)
+	body, code, err := c.newRequest(ctx, "/approvals/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateApproval(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareApprovalJSON(d)
+	body, code, err := c.newRequest(ctx, "/approvals/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteApproval(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/approvals/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareApprovalJSON(d *schema.ResourceData) jsonApproval {
+	jsonData := jsonApproval{
+		ApprovalName:   d.Get("approval_name").(string),
+		Description:    d.Get("description").(string),
+		ActiveQuorum:   d.Get("active_quorum").(int),
+		InactiveQuorum: d.Get("inactive_quorum").(int),
+		Timeout:        d.Get("timeout").(int),
+	}
+	listApprovers := d.Get("approvers").([]interface{})
+	jsonData.Approvers = make([]string, len(listApprovers))
+	for i, v := range listApprovers {
+		jsonData.Approvers[i] = v.(string)
+	}
+	listAuthorizations := d.Get("authorizations").(*schema.Set).List()
+	jsonData.Authorizations = make([]string, len(listAuthorizations))
+	for i, v := range listAuthorizations {
+		jsonData.Authorizations[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readApprovalOptions(
+	ctx context.Context, approvalID string, m interface{},
+) (
+	jsonApproval, error,
+) {
+	c := m.(*Client)
+	var result jsonApproval
+	body, code, err := c.newRequest(ctx, "/approvals/"+approvalID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillApproval(d *schema.ResourceData, jsonData jsonApproval) {
+	if tfErr := d.Set("approval_name", jsonData.ApprovalName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("active_quorum", jsonData.ActiveQuorum); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("inactive_quorum", jsonData.InactiveQuorum); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("timeout", jsonData.Timeout); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("approvers", jsonData.Approvers); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("authorizations", jsonData.Authorizations); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,83 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceApproval_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApprovalCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_approval.testacc_Approval",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceApprovalUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_approval.testacc_Approval",
+						"authorizations.#", "1"),
+				),
+			},
+			{
+				ResourceName:  "wallix-bastion_approval.testacc_Approval",
+				ImportState:   true,
+				ImportStateId: "testacc_Approval",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceApprovalCreate() string {
+	return `
+resource "wallix-bastion_usergroup" "testacc_Approval" {
+  group_name = "testacc_Approval"
+  timeframes = ["allthetime"]
+}
+
+resource "wallix-bastion_approval" "testacc_Approval" {
+  approval_name = "testacc_Approval"
+  approvers     = [wallix-bastion_usergroup.testacc_Approval.group_name]
+}
+`
+}
+
+func testAccResourceApprovalUpdate() string {
+	return `
+resource "wallix-bastion_usergroup" "testacc_Approval" {
+  group_name = "testacc_Approval"
+  timeframes = ["allthetime"]
+}
+
+resource "wallix-bastion_targetgroup" "testacc_Approval" {
+  group_name = "testacc_Approval"
+}
+
+resource "wallix-bastion_authorization" "testacc_Approval" {
+  authorization_name = "testacc_Approval"
+  user_group         = wallix-bastion_usergroup.testacc_Approval.group_name
+  target_group       = wallix-bastion_targetgroup.testacc_Approval.group_name
+  authorize_sessions = true
+  subprotocols       = ["SSH_SHELL_SESSION"]
+}
+
+resource "wallix-bastion_approval" "testacc_Approval" {
+  approval_name   = "testacc_Approval"
+  description     = "testacc Approval"
+  approvers       = [wallix-bastion_usergroup.testacc_Approval.group_name]
+  active_quorum   = 1
+  inactive_quorum = 0
+  timeout         = 60
+  authorizations  = [wallix-bastion_authorization.testacc_Approval.authorization_name]
+}
+`
+}
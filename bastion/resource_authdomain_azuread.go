@@ -114,38 +114,38 @@ func resourceAuthDomainAzureAD() *schema.Resource {
 	}
 }
 
-func resourceAuthDomainAzureADVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceAuthDomainAzureADVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authdomain_azuread not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_authdomain_azuread not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceAuthDomainAzureADCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainAzureADVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainAzureADVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceAuthDomainAzureAD(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s already exists", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s already exists", d.Get("domain_name").(string)))
 	}
 	err = addAuthDomainAzureAD(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceAuthDomainAzureAD(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s not found after POST", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s not found after POST", d.Get("domain_name").(string)))
 	}
 	d.SetId(id)
 
@@ -156,12 +156,12 @@ func resourceAuthDomainAzureADRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainAzureADVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainAzureADVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readAuthDomainAzureADOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -177,11 +177,11 @@ func resourceAuthDomainAzureADUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceAuthDomainAzureADVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainAzureADVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateAuthDomainAzureAD(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -192,11 +192,11 @@ func resourceAuthDomainAzureADDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainAzureADVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainAzureADVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteAuthDomainAzureAD(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -209,7 +209,7 @@ func resourceAuthDomainAzureADImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceAuthDomainAzureADVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceAuthDomainAzureADVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceAuthDomainAzureAD(ctx, d.Id(), m)
@@ -237,12 +237,12 @@ func searchResourceAuthDomainAzureAD(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonAuthDomainAzureAD
 	err = json.Unmarshal([]byte(body), &results)
@@ -266,7 +266,7 @@ func addAuthDomainAzureAD(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -282,7 +282,7 @@ func updateAuthDomainAzureAD(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -297,7 +297,7 @@ func deleteAuthDomainAzureAD(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -351,7 +351,7 @@ func readAuthDomainAzureADOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
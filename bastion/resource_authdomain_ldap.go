@@ -119,38 +119,38 @@ func resourceAuthDomainLdap() *schema.Resource {
 	}
 }
 
-func resourceAuthDomainLdapVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceAuthDomainLdapVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authdomain_ldap not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_authdomain_ldap not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceAuthDomainLdapCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceAuthDomainLdap(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s already exists", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s already exists", d.Get("domain_name").(string)))
 	}
 	err = addAuthDomainLdap(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceAuthDomainLdap(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s not found after POST", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s not found after POST", d.Get("domain_name").(string)))
 	}
 	d.SetId(id)
 
@@ -161,12 +161,12 @@ func resourceAuthDomainLdapRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readAuthDomainLdapOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -182,11 +182,11 @@ func resourceAuthDomainLdapUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceAuthDomainLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateAuthDomainLdap(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -197,11 +197,11 @@ func resourceAuthDomainLdapDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteAuthDomainLdap(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -214,7 +214,7 @@ func resourceAuthDomainLdapImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceAuthDomainLdapVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceAuthDomainLdapVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceAuthDomainLdap(ctx, d.Id(), m)
@@ -242,12 +242,12 @@ func searchResourceAuthDomainLdap(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonAuthDomainLdap
 	err = json.Unmarshal([]byte(body), &results)
@@ -271,7 +271,7 @@ func addAuthDomainLdap(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -287,7 +287,7 @@ func updateAuthDomainLdap(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -302,7 +302,7 @@ func deleteAuthDomainLdap(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -358,7 +358,7 @@ func readAuthDomainLdapOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -55,47 +55,54 @@ func resourceAuthDomainMapping() *schema.Resource {
 	}
 }
 
-func resourceAuthDomainMappingVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceAuthDomainMappingVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authdomain_mapping not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_authdomain_mapping not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceAuthDomainMappingCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainMappingVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	domainIDExists, err := checkAuthDomainID(ctx, d.Get("domain_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !domainIDExists {
-		return diag.FromErr(fmt.Errorf("auth domain with ID %s doesn't exists", d.Get("domain_id").(string)))
+		return diagFromAPIError(fmt.Errorf("auth domain with ID %s doesn't exists", d.Get("domain_id").(string)))
 	}
-	_, ex, err := searchResourceAuthDomainMapping(ctx, d.Get("domain_id").(string), d.Get("user_group").(string), m)
+	if err := checkAuthDomainMappingUserGroup(ctx, d.Get("user_group").(string), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceAuthDomainMapping(ctx,
+		d.Get("domain_id").(string), d.Get("user_group").(string), d.Get("external_group").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("auth domain mapping for user_group %s on domain_id %s already exists",
-			d.Get("user_group").(string), d.Get("domain_id").(string)))
+		return diagFromAPIError(fmt.Errorf("auth domain mapping for user_group %s and external_group %s "+
+			"on domain_id %s already exists",
+			d.Get("user_group").(string), d.Get("external_group").(string), d.Get("domain_id").(string)))
 	}
 	err = addAuthDomainMapping(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
-	id, ex, err := searchResourceAuthDomainMapping(ctx, d.Get("domain_id").(string), d.Get("user_group").(string), m)
+	id, ex, err := searchResourceAuthDomainMapping(ctx,
+		d.Get("domain_id").(string), d.Get("user_group").(string), d.Get("external_group").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("auth domain mapping for user_group %s on domain_id %s not found after POST",
-			d.Get("user_group").(string), d.Get("domain_id").(string)))
+		return diagFromAPIError(fmt.Errorf("auth domain mapping for user_group %s and external_group %s "+
+			"on domain_id %s not found after POST",
+			d.Get("user_group").(string), d.Get("external_group").(string), d.Get("domain_id").(string)))
 	}
 	d.SetId(id)
 
@@ -106,12 +113,12 @@ func resourceAuthDomainMappingRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainMappingVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readAuthDomainMappingOptions(ctx, d.Get("domain_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -127,11 +134,16 @@ func resourceAuthDomainMappingUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceAuthDomainMappingVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("user_group") {
+		if err := checkAuthDomainMappingUserGroup(ctx, d.Get("user_group").(string), m); err != nil {
+			return diagFromAPIError(err)
+		}
 	}
 	if err := updateAuthDomainMapping(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -142,11 +154,11 @@ func resourceAuthDomainMappingDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainMappingVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteAuthDomainMapping(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -157,19 +169,25 @@ func resourceAuthDomainMappingImport(
 ) ([]*schema.ResourceData, error) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceAuthDomainMappingVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceAuthDomainMappingVersionCheck(c); err != nil {
 		return nil, err
 	}
-	idSplit := strings.Split(d.Id(), "/")
-	if len(idSplit) != 2 {
-		return nil, errors.New("id must be <domain_id>/<user_group>")
+	// the external group can contain slashes (e.g. a claim value), keep them in the last part
+	idSplit := strings.SplitN(d.Id(), "/", 3)
+	if len(idSplit) < 2 {
+		return nil, errors.New("id must be <domain_id>/<user_group>/<external_group> or <domain_id>/<user_group>")
 	}
-	id, ex, err := searchResourceAuthDomainMapping(ctx, idSplit[0], idSplit[1], m)
+	externalGroup := ""
+	if len(idSplit) == 3 {
+		externalGroup = idSplit[2]
+	}
+	id, ex, err := searchResourceAuthDomainMapping(ctx, idSplit[0], idSplit[1], externalGroup, m)
 	if err != nil {
 		return nil, err
 	}
 	if !ex {
-		return nil, fmt.Errorf("don't find auth domain mapping with id %s (id must be <domain_id>/<user_group>)", d.Id())
+		return nil, fmt.Errorf("don't find auth domain mapping with id %s "+
+			"(id must be <domain_id>/<user_group>/<external_group> or <domain_id>/<user_group>)", d.Id())
 	}
 	cfg, err := readAuthDomainMappingOptions(ctx, idSplit[0], id, m)
 	if err != nil {
@@ -200,7 +218,7 @@ func checkAuthDomainID(
 		return false, nil
 	}
 	if code != http.StatusOK {
-		return false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return false, newAPIError("api doesn't return OK", code, body)
 	}
 	var result jsonAuthDomain
 	err = json.Unmarshal([]byte(body), &result)
@@ -214,13 +232,29 @@ func checkAuthDomainID(
 	return false, nil
 }
 
+// checkAuthDomainMappingUserGroup returns an error if the user group doesn't exist
+// as the api accepts the mapping but it never applies.
+func checkAuthDomainMappingUserGroup(ctx context.Context, userGroup string, m interface{}) error {
+	_, ex, err := searchResourceUserGroup(ctx, userGroup, m)
+	if err != nil {
+		return err
+	}
+	if !ex {
+		return fmt.Errorf("user_group %s doesn't exists", userGroup)
+	}
+
+	return nil
+}
+
+// searchResourceAuthDomainMapping returns the mapping of userGroup with externalGroup on the domain.
+// With an empty externalGroup, the user group need to have only one mapping on the domain.
 func searchResourceAuthDomainMapping(
-	ctx context.Context, domainID, userGroup string, m interface{},
+	ctx context.Context, domainID, userGroup, externalGroup string, m interface{},
 ) (
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(
+	body, code, err := c.newRequestPaged(
 		ctx,
 		"/authdomains/"+domainID+"/mappings/?q=user_group="+userGroup,
 		http.MethodGet,
@@ -230,15 +264,21 @@ func searchResourceAuthDomainMapping(
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonAuthDomainMapping
 	err = json.Unmarshal([]byte(body), &results)
 	if err != nil {
 		return "", false, fmt.Errorf("unmarshaling json: %w", err)
 	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	var found []jsonAuthDomainMapping
+	for _, v := range results {
+		if v.UserGroup == userGroup && (externalGroup == "" || v.ExternalGroup == externalGroup) {
+			found = append(found, v)
+		}
+	}
+	if len(found) == 1 {
+		return found[0].ID, true, nil
 	}
 
 	return "", false, nil
@@ -259,7 +299,7 @@ func addAuthDomainMapping(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -280,7 +320,7 @@ func updateAuthDomainMapping(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -300,7 +340,7 @@ func deleteAuthDomainMapping(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -333,7 +373,7 @@ func readAuthDomainMappingOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
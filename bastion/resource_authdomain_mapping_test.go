@@ -2,6 +2,7 @@ package bastion_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -14,6 +15,10 @@ func TestAccResourceAuthDomainMapping_basic(t *testing.T) {
 		PreCheck:  func() { testAccPreCheck(t) },
 		Providers: testAccProviders,
 		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceAuthDomainMappingMissingUserGroup(),
+				ExpectError: regexp.MustCompile(`user_group testacc_AuthDomainMappingMissing doesn't exists`),
+			},
 			{
 				Config: testAccResourceAuthDomainMappingCreate(),
 				Check: resource.ComposeTestCheckFunc(
@@ -39,7 +44,7 @@ func TestAccResourceAuthDomainMapping_basic(t *testing.T) {
 						return "", fmt.Errorf("Attribute %s not found:\n%+v", "domain_id", rs.Primary.Attributes)
 					}
 
-					return devID + "/testacc_AuthDomainMapping2", nil
+					return devID + "/testacc_AuthDomainMapping2/CN=testacc2,OU=FR,DC=test,DC=com", nil
 				},
 			},
 		},
@@ -47,6 +52,34 @@ func TestAccResourceAuthDomainMapping_basic(t *testing.T) {
 	})
 }
 
+func testAccResourceAuthDomainMappingMissingUserGroup() string {
+	return `
+resource "wallix-bastion_authdomain_ldap" "testacc_AuthDomainMapping" {
+  domain_name          = "testacc.AuthDomainMapping"
+  auth_domain_name     = "test.com"
+  external_auths       = [wallix-bastion_externalauth_ldap.testacc_AuthDomainMapping.authentication_name]
+  default_language     = "fr"
+  default_email_domain = "test.com"
+}
+resource "wallix-bastion_externalauth_ldap" "testacc_AuthDomainMapping" {
+  authentication_name = "testacc_AuthDomainMapping"
+  cn_attribute        = "sAMAccountName"
+  host                = "server1"
+  ldap_base           = "OU=FR,DC=test,DC=com"
+  login_attribute     = "sAMAccountName"
+  port                = 636
+  timeout             = 10
+  is_ssl              = true
+  is_anonymous_access = true
+}
+resource "wallix-bastion_authdomain_mapping" "testacc_AuthDomainMapping" {
+  domain_id      = wallix-bastion_authdomain_ldap.testacc_AuthDomainMapping.id
+  user_group     = "testacc_AuthDomainMappingMissing"
+  external_group = "CN=testacc,OU=FR,DC=test,DC=com"
+}
+`
+}
+
 func testAccResourceAuthDomainMappingCreate() string {
 	return `
 resource "wallix-bastion_authdomain_ldap" "testacc_AuthDomainMapping" {
@@ -26,6 +26,10 @@ type jsonAuthDomainSAML struct {
 	Label              string   `json:"label"`
 	ForceAuthn         bool     `json:"force_authn"`
 
+	DisplayNameAttribute string `json:"display_name_attribute"`
+	EmailAttribute       string `json:"email_attribute"`
+	GroupAttribute       string `json:"group_attribute"`
+
 	IdpInitiatedURL string `json:"idp_initiated_url,omitempty"`
 }
 
@@ -69,6 +73,18 @@ func resourceAuthDomainSAML() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"display_name_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"email_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"force_authn": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -90,38 +106,38 @@ func resourceAuthDomainSAML() *schema.Resource {
 	}
 }
 
-func resourceAuthDomainSAMLVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceAuthDomainSAMLVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authdomain_saml not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_authdomain_saml not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceAuthDomainSAMLCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainSAMLVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainSAMLVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceAuthDomainSAML(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s already exists", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s already exists", d.Get("domain_name").(string)))
 	}
 	err = addAuthDomainSAML(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceAuthDomainSAML(ctx, d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s not found after POST", d.Get("domain_name").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_name %s not found after POST", d.Get("domain_name").(string)))
 	}
 	d.SetId(id)
 
@@ -132,12 +148,12 @@ func resourceAuthDomainSAMLRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainSAMLVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainSAMLVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readAuthDomainSAMLOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -153,11 +169,11 @@ func resourceAuthDomainSAMLUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceAuthDomainSAMLVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainSAMLVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateAuthDomainSAML(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -168,11 +184,11 @@ func resourceAuthDomainSAMLDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthDomainSAMLVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthDomainSAMLVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteAuthDomainSAML(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -185,7 +201,7 @@ func resourceAuthDomainSAMLImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceAuthDomainSAMLVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceAuthDomainSAMLVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceAuthDomainSAML(ctx, d.Id(), m)
@@ -213,12 +229,12 @@ func searchResourceAuthDomainSAML(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonAuthDomainSAML
 	err = json.Unmarshal([]byte(body), &results)
@@ -242,7 +258,7 @@ func addAuthDomainSAML(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -258,7 +274,7 @@ func updateAuthDomainSAML(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -273,7 +289,7 @@ func deleteAuthDomainSAML(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -290,6 +306,10 @@ func prepareAuthDomainSAMLJSON(d *schema.ResourceData) jsonAuthDomainSAML {
 		DefaultEmailDomain: d.Get("default_email_domain").(string),
 		Label:              d.Get("label").(string),
 		ForceAuthn:         d.Get("force_authn").(bool),
+
+		DisplayNameAttribute: d.Get("display_name_attribute").(string),
+		EmailAttribute:       d.Get("email_attribute").(string),
+		GroupAttribute:       d.Get("group_attribute").(string),
 	}
 
 	listExternalAuths := d.Get("external_auths").([]interface{})
@@ -322,7 +342,7 @@ func readAuthDomainSAMLOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -354,6 +374,15 @@ func fillAuthDomainSAML(d *schema.ResourceData, jsonData jsonAuthDomainSAML) {
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
+	if tfErr := d.Set("display_name_attribute", jsonData.DisplayNameAttribute); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("email_attribute", jsonData.EmailAttribute); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("group_attribute", jsonData.GroupAttribute); tfErr != nil {
+		panic(tfErr)
+	}
 	if tfErr := d.Set("force_authn", jsonData.ForceAuthn); tfErr != nil {
 		panic(tfErr)
 	}
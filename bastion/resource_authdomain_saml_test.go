@@ -21,6 +21,11 @@ func TestAccResourceAuthDomainSAML_basic(t *testing.T) {
 			},
 			{
 				Config: testAccResourceAuthDomainSAMLUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_authdomain_saml.testacc_AuthDomainSAML",
+						"group_attribute", "group"),
+				),
 			},
 			{
 				ResourceName:  "wallix-bastion_authdomain_saml.testacc_AuthDomainSAML",
@@ -68,6 +73,10 @@ resource "wallix-bastion_authdomain_saml" "testacc_AuthDomainSAML" {
   description          = "SAML test4.com"
   force_authn          = true
   is_default           = true
+
+  display_name_attribute = "displayname"
+  email_attribute        = "email"
+  group_attribute        = "group"
 }
 resource "wallix-bastion_externalauth_saml" "testacc_AuthDomainSAML" {
   authentication_name = "testacc_AuthDomainSAML"
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type jsonAuthorization struct {
@@ -45,6 +46,7 @@ func resourceAuthorization() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceAuthorizationImport,
 		},
+		CustomizeDiff: resourceAuthorizationCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"authorization_name": {
 				Type:     schema.TypeString,
@@ -81,17 +83,9 @@ func resourceAuthorization() *schema.Resource {
 				RequiredWith: []string{"session_sharing_mode"},
 			},
 			"session_sharing_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ValidateFunc: func(val any, key string) ([]string, []error) {
-					v := val.(string)
-					var errs []error
-					if v != "" && v != "view_only" && v != "view_control" {
-						errs = append(errs, fmt.Errorf("%q must be either 'view_only' or 'view_control', got: %s", key, v))
-					}
-
-					return nil, errs
-				},
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"view_only", "view_control"}, false),
 				RequiredWith: []string{"authorize_session_sharing"},
 			},
 			"subprotocols": {
@@ -134,6 +128,7 @@ func resourceAuthorization() *schema.Resource {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				RequiredWith: []string{"approval_required"},
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"has_comment": {
 				Type:         schema.TypeBool,
@@ -164,38 +159,50 @@ func resourceAuthorization() *schema.Resource {
 	}
 }
 
-func resourceAuthorizationVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceAuthorizationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authorization not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_authorization not available with api version %s", c.bastionAPIVersion)
+}
+
+// resourceAuthorizationCustomizeDiff rejects a session_sharing_mode without authorize_session_sharing,
+// RequiredWith only checks that authorize_session_sharing is set, even to false.
+func resourceAuthorizationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get("session_sharing_mode").(string) != "" && !d.Get("authorize_session_sharing").(bool) {
+		return fmt.Errorf("session_sharing_mode %s can only be set with authorize_session_sharing = true",
+			d.Get("session_sharing_mode").(string))
+	}
+
+	return nil
 }
 
 func resourceAuthorizationCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthorizationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthorizationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceAuthorization(ctx, d.Get("authorization_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("authorization_name %s already exists", d.Get("authorization_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authorization_name %s already exists", d.Get("authorization_name").(string)))
 	}
 	err = addAuthorization(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceAuthorization(ctx, d.Get("authorization_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("authorization_name %s not found after POST", d.Get("authorization_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authorization_name %s not found after POST",
+			d.Get("authorization_name").(string)))
 	}
 	d.SetId(id)
 
@@ -206,12 +213,12 @@ func resourceAuthorizationRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthorizationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthorizationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readAuthorizationOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -227,11 +234,11 @@ func resourceAuthorizationUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceAuthorizationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthorizationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateAuthorization(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -242,11 +249,11 @@ func resourceAuthorizationDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceAuthorizationVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceAuthorizationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteAuthorization(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -259,7 +266,7 @@ func resourceAuthorizationImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceAuthorizationVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceAuthorizationVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceAuthorization(ctx, d.Id(), m)
@@ -287,12 +294,13 @@ func searchResourceAuthorization(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/authorizations/?q=authorization_name="+authorizationName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx,
+		"/authorizations/?q=authorization_name="+authorizationName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonAuthorization
 	err = json.Unmarshal([]byte(body), &results)
@@ -316,7 +324,7 @@ func addAuthorization(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -332,7 +340,7 @@ func updateAuthorization(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -347,7 +355,7 @@ func deleteAuthorization(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -381,8 +389,11 @@ func prepareAuthorizationJSON(d *schema.ResourceData, newResource bool) jsonAuth
 		jsonData.ActiveQuorum = &activeQuorum
 		inactiveQuorum := d.Get("inactive_quorum").(int)
 		jsonData.InactiveQuorum = &inactiveQuorum
-		approvalTimeout := d.Get("approval_timeout").(int)
-		jsonData.ApprovalTimeout = &approvalTimeout
+		// the api rejects a zero timeout, let it apply its default when not set
+		if v, ok := d.GetOk("approval_timeout"); ok {
+			approvalTimeout := v.(int)
+			jsonData.ApprovalTimeout = &approvalTimeout
+		}
 
 		listApprovers := d.Get("approvers").([]interface{})
 		approvers := make([]string, len(listApprovers))
@@ -430,7 +441,7 @@ func readAuthorizationOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -0,0 +1,126 @@
+package bastion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceAuthorizationCustomizeDiff(t *testing.T) {
+	tests := map[string]struct {
+		config   map[string]interface{}
+		errMatch string
+	}{
+		"sharing with mode": {
+			config: map[string]interface{}{
+				"authorize_session_sharing": true,
+				"session_sharing_mode":      "view_only",
+			},
+		},
+		"no sharing": {
+			config: map[string]interface{}{},
+		},
+		"mode without sharing": {
+			config: map[string]interface{}{
+				"authorize_session_sharing": false,
+				"session_sharing_mode":      "view_control",
+			},
+			errMatch: "session_sharing_mode view_control can only be set with authorize_session_sharing = true",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"authorization_name":           "auth",
+				"user_group":                   "users",
+				"target_group":                 "targets",
+				"authorize_password_retrieval": true,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			_, err := resourceAuthorization().Diff(
+				context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.errMatch == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMatch) {
+				t.Fatalf("expected error matching %q, got %v", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestResourceAuthorizationApprovalTimeout(t *testing.T) {
+	tests := map[string]struct {
+		timeout int
+		valid   bool
+	}{
+		"zero":     {timeout: 0},
+		"negative": {timeout: -1},
+		"minimum":  {timeout: 1, valid: true},
+		"one day":  {timeout: 86400, valid: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := resourceAuthorization().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"authorization_name":           "auth",
+				"user_group":                   "users",
+				"target_group":                 "targets",
+				"authorize_password_retrieval": true,
+				"approval_required":            true,
+				"approvers":                    []interface{}{"approvers"},
+				"approval_timeout":             tt.timeout,
+			}))
+			if tt.valid && diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !tt.valid && !diags.HasError() {
+				t.Fatalf("expected an error for approval_timeout = %d", tt.timeout)
+			}
+		})
+	}
+}
+
+func TestResourceAuthorizationTargetGroupRequiresNew(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "a1",
+		Attributes: map[string]string{
+			"id":                           "a1",
+			"authorization_name":           "auth",
+			"user_group":                   "users",
+			"target_group":                 "targets",
+			"authorize_password_retrieval": "true",
+		},
+	}
+	tests := map[string]struct {
+		targetGroup string
+		requiresNew bool
+	}{
+		"same target_group":    {targetGroup: "targets"},
+		"target_group changed": {targetGroup: "other_targets", requiresNew: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff, err := resourceAuthorization().Diff(context.Background(), state,
+				terraform.NewResourceConfigRaw(map[string]interface{}{
+					"authorization_name":           "auth",
+					"user_group":                   "users",
+					"target_group":                 tt.targetGroup,
+					"authorize_password_retrieval": true,
+				}), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff.RequiresNew() != tt.requiresNew {
+				t.Errorf("expected replacement %t, got diff %v", tt.requiresNew, diff)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package bastion_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceAuthorization_basic(t *testing.T) {
@@ -67,6 +69,76 @@ func TestAccResourceAuthorization_sessionSharing(t *testing.T) {
 	})
 }
 
+func TestAccResourceAuthorization_targetGroupReplace(t *testing.T) {
+	resourceName := "wallix-bastion_authorization.testacc_Authorization_replace"
+	var firstID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAuthorizationTargetGroup("testacc_Authorization_replace1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "target_group", "testacc_Authorization_replace1"),
+					func(s *terraform.State) error {
+						rs, ok := s.RootModule().Resources[resourceName]
+						if !ok {
+							return fmt.Errorf("Resource %s not found", resourceName)
+						}
+						firstID = rs.Primary.ID
+
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceAuthorizationTargetGroup("testacc_Authorization_replace2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "target_group", "testacc_Authorization_replace2"),
+					func(s *terraform.State) error {
+						rs, ok := s.RootModule().Resources[resourceName]
+						if !ok {
+							return fmt.Errorf("Resource %s not found", resourceName)
+						}
+						if rs.Primary.ID == firstID {
+							return fmt.Errorf("expected %s to be replaced when target_group changes, got the same id %s",
+								resourceName, firstID)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceAuthorizationTargetGroup(targetGroup string) string {
+	return fmt.Sprintf(`
+resource "wallix-bastion_authorization" "testacc_Authorization_replace" {
+  authorization_name = "testacc_Authorization_replace"
+  user_group         = wallix-bastion_usergroup.testacc_Authorization_replace.group_name
+  target_group       = wallix-bastion_targetgroup.%[1]s.group_name
+  authorize_sessions = true
+  subprotocols       = ["SSH_SHELL_SESSION"]
+}
+
+resource "wallix-bastion_usergroup" "testacc_Authorization_replace" {
+  group_name = "testacc_Authorization_replace"
+  timeframes = ["allthetime"]
+}
+
+resource "wallix-bastion_targetgroup" "testacc_Authorization_replace1" {
+  group_name = "testacc_Authorization_replace1"
+}
+
+resource "wallix-bastion_targetgroup" "testacc_Authorization_replace2" {
+  group_name = "testacc_Authorization_replace2"
+}
+`, targetGroup)
+}
+
 // nolint: lll, nolintlint
 func testAccResourceAuthorizationCreate() string {
 	return `
@@ -9,8 +9,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const checkoutPolicyDefault = "default"
+
 type jsonCheckoutPolicy struct {
 	ChangeCredentialsAtCheckin bool   `json:"change_credentials_at_checkin"`
 	EnableLock                 bool   `json:"enable_lock"`
@@ -54,53 +57,62 @@ func resourceCheckoutPolicy() *schema.Resource {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				RequiredWith: []string{"enable_lock"},
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"extension": {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				RequiredWith: []string{"enable_lock"},
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"max_duration": {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				RequiredWith: []string{"enable_lock"},
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 		},
 	}
 }
 
-func resourceCheckoutPolicyVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceCheckoutPolicyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_checkout_policy not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_checkout_policy not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceCheckoutPolicyCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceCheckoutPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceCheckoutPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.Get("checkout_policy_name").(string) == checkoutPolicyDefault {
+		return diagFromAPIError(fmt.Errorf(
+			"checkout_policy_name %s is the built-in policy of the Bastion and can't be created, "+
+				"import it with `terraform import <resource address> %s` instead",
+			checkoutPolicyDefault, checkoutPolicyDefault))
 	}
 	_, ex, err := searchResourceCheckoutPolicy(ctx, d.Get("checkout_policy_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("checkout_policy_name %s already exists", d.Get("checkout_policy_name").(string)))
+		return diagFromAPIError(fmt.Errorf("checkout_policy_name %s already exists", d.Get("checkout_policy_name").(string)))
 	}
 	err = addCheckoutPolicy(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceCheckoutPolicy(ctx, d.Get("checkout_policy_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("checkout_policy_name %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("checkout_policy_name %s not found after POST",
 			d.Get("checkout_policy_name").(string)))
 	}
 	d.SetId(id)
@@ -112,12 +124,12 @@ func resourceCheckoutPolicyRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceCheckoutPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceCheckoutPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readCheckoutPolicyOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -133,11 +145,11 @@ func resourceCheckoutPolicyUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceCheckoutPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceCheckoutPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateCheckoutPolicy(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -148,11 +160,11 @@ func resourceCheckoutPolicyDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceCheckoutPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceCheckoutPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteCheckoutPolicy(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -165,7 +177,7 @@ func resourceCheckoutPolicyImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceCheckoutPolicyVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceCheckoutPolicyVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceCheckoutPolicy(ctx, d.Id(), m)
@@ -193,13 +205,13 @@ func searchResourceCheckoutPolicy(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
+	body, code, err := c.newRequestPaged(ctx,
 		"/checkoutpolicies/?q=checkout_policy_name="+checkoutPolicyName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonCheckoutPolicy
 	err = json.Unmarshal([]byte(body), &results)
@@ -223,7 +235,7 @@ func addCheckoutPolicy(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -239,7 +251,7 @@ func updateCheckoutPolicy(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -254,7 +266,7 @@ func deleteCheckoutPolicy(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -289,7 +301,7 @@ func readCheckoutPolicyOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
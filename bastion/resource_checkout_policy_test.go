@@ -1,6 +1,7 @@
 package bastion_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -32,6 +33,19 @@ func TestAccResourceCheckoutPolicy_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceCheckoutPolicy_default(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceCheckoutPolicyDefault(),
+				ExpectError: regexp.MustCompile(`built-in policy of the Bastion and can't be created`),
+			},
+		},
+	})
+}
+
 func testAccResourceCheckoutPolicyCreate() string {
 	return `
 resource "wallix-bastion_checkout_policy" "testacc_CheckoutPolicy" {
@@ -53,3 +67,11 @@ resource "wallix-bastion_checkout_policy" "testacc_CheckoutPolicy" {
 }
 `
 }
+
+func testAccResourceCheckoutPolicyDefault() string {
+	return `
+resource "wallix-bastion_checkout_policy" "testacc_CheckoutPolicyDefault" {
+  checkout_policy_name = "default"
+}
+`
+}
@@ -60,38 +60,38 @@ func resourceCluster() *schema.Resource {
 	}
 }
 
-func resourceClusterVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceClusterVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_cluster not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_cluster not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceClusterCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceClusterVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceClusterVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceCluster(ctx, d.Get("cluster_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("cluster_name %s already exists", d.Get("cluster_name").(string)))
+		return diagFromAPIError(fmt.Errorf("cluster_name %s already exists", d.Get("cluster_name").(string)))
 	}
 	err = addCluster(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceCluster(ctx, d.Get("cluster_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("cluster_name %s not found after POST", d.Get("cluster_name").(string)))
+		return diagFromAPIError(fmt.Errorf("cluster_name %s not found after POST", d.Get("cluster_name").(string)))
 	}
 	d.SetId(id)
 
@@ -102,12 +102,12 @@ func resourceClusterRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceClusterVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceClusterVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readClusterOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -123,11 +123,11 @@ func resourceClusterUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceClusterVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceClusterVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateCluster(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -138,11 +138,11 @@ func resourceClusterDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceClusterVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceClusterVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteCluster(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -155,7 +155,7 @@ func resourceClusterImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceClusterVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceClusterVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceCluster(ctx, d.Id(), m)
@@ -183,12 +183,12 @@ func searchResourceCluster(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/clusters/?q=cluster_name="+clusterName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/clusters/?q=cluster_name="+clusterName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonCluster
 	err = json.Unmarshal([]byte(body), &results)
@@ -212,7 +212,7 @@ func addCluster(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -228,7 +228,7 @@ func updateCluster(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -243,7 +243,7 @@ func deleteCluster(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -291,7 +291,7 @@ func readClusterOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
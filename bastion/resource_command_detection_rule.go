@@ -0,0 +1,321 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonCommandDetectionRule struct {
+	ID             string   `json:"id,omitempty"`
+	RuleName       string   `json:"rule_name"`
+	Description    string   `json:"description"`
+	CommandPattern string   `json:"command_pattern"`
+	Action         string   `json:"action"`
+	Severity       string   `json:"severity"`
+	Protocols      []string `json:"protocols"`
+}
+
+func resourceCommandDetectionRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCommandDetectionRuleCreate,
+		ReadContext:   resourceCommandDetectionRuleRead,
+		UpdateContext: resourceCommandDetectionRuleUpdate,
+		DeleteContext: resourceCommandDetectionRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCommandDetectionRuleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"rule_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"command_pattern": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"alert", "block", "end_session"}, false),
+			},
+			"severity": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"low", "medium", "high", "critical"}, false),
+			},
+			"protocols": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"SSH"}, false),
+				},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceCommandDetectionRuleVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_command_detection_rule not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceCommandDetectionRuleCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceCommandDetectionRuleVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceCommandDetectionRule(ctx, d.Get("rule_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("rule_name %s already exists", d.Get("rule_name").(string)))
+	}
+	err = addCommandDetectionRule(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceCommandDetectionRule(ctx, d.Get("rule_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("rule_name %s not found after POST", d.Get("rule_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceCommandDetectionRuleRead(ctx, d, m)
+}
+
+func resourceCommandDetectionRuleRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceCommandDetectionRuleVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readCommandDetectionRuleOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillCommandDetectionRule(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceCommandDetectionRuleUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceCommandDetectionRuleVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateCommandDetectionRule(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceCommandDetectionRuleRead(ctx, d, m)
+}
+
+func resourceCommandDetectionRuleDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceCommandDetectionRuleVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteCommandDetectionRule(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceCommandDetectionRuleImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceCommandDetectionRuleVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceCommandDetectionRule(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find rule_name with id %s (id must be <rule_name>)", d.Id())
+	}
+	cfg, err := readCommandDetectionRuleOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillCommandDetectionRule(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceCommandDetectionRule(
+	ctx context.Context, ruleName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/commanddetectionrules/?q=rule_name="+ruleName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonCommandDetectionRule
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addCommandDetectionRule(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareCommandDetectionRuleJSON(d)
+	body, code, err := c.newRequest(ctx, "/commanddetectionrules/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateCommandDetectionRule(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareCommandDetectionRuleJSON(d)
+	body, code, err := c.newRequest(ctx, "/commanddetectionrules/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteCommandDetectionRule(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/commanddetectionrules/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareCommandDetectionRuleJSON(d *schema.ResourceData) jsonCommandDetectionRule {
+	jsonData := jsonCommandDetectionRule{
+		RuleName:       d.Get("rule_name").(string),
+		Description:    d.Get("description").(string),
+		CommandPattern: d.Get("command_pattern").(string),
+		Action:         d.Get("action").(string),
+		Severity:       d.Get("severity").(string),
+	}
+	listProtocols := d.Get("protocols").(*schema.Set).List()
+	jsonData.Protocols = make([]string, len(listProtocols))
+	for i, v := range listProtocols {
+		jsonData.Protocols[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readCommandDetectionRuleOptions(
+	ctx context.Context, ruleID string, m interface{},
+) (
+	jsonCommandDetectionRule, error,
+) {
+	c := m.(*Client)
+	var result jsonCommandDetectionRule
+	body, code, err := c.newRequest(ctx, "/commanddetectionrules/"+ruleID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillCommandDetectionRule(d *schema.ResourceData, jsonData jsonCommandDetectionRule) {
+	if tfErr := d.Set("rule_name", jsonData.RuleName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("command_pattern", jsonData.CommandPattern); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("action", jsonData.Action); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("severity", jsonData.Severity); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("protocols", jsonData.Protocols); tfErr != nil {
+		panic(tfErr)
+	}
+}
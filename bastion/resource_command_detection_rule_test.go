@@ -0,0 +1,76 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceCommandDetectionRule_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceCommandDetectionRuleInvalid(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`error parsing regexp`),
+			},
+			{
+				Config: testAccResourceCommandDetectionRuleCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_command_detection_rule.testacc_CommandDetectionRule",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceCommandDetectionRuleUpdate(),
+			},
+			{
+				ResourceName:  "wallix-bastion_command_detection_rule.testacc_CommandDetectionRule",
+				ImportState:   true,
+				ImportStateId: "testacc_CommandDetectionRule",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceCommandDetectionRuleInvalid() string {
+	return `
+resource "wallix-bastion_command_detection_rule" "testacc_CommandDetectionRule" {
+  rule_name       = "testacc_CommandDetectionRule"
+  command_pattern = "rm -rf (/"
+  action          = "alert"
+  severity        = "high"
+  protocols       = ["SSH"]
+}
+`
+}
+
+func testAccResourceCommandDetectionRuleCreate() string {
+	return `
+resource "wallix-bastion_command_detection_rule" "testacc_CommandDetectionRule" {
+  rule_name       = "testacc_CommandDetectionRule"
+  command_pattern = "^rm\\s+-rf\\s+/"
+  action          = "alert"
+  severity        = "high"
+  protocols       = ["SSH"]
+}
+`
+}
+
+func testAccResourceCommandDetectionRuleUpdate() string {
+	return `
+resource "wallix-bastion_command_detection_rule" "testacc_CommandDetectionRule" {
+  rule_name       = "testacc_CommandDetectionRule"
+  description     = "testacc CommandDetectionRule"
+  command_pattern = "^rm\\s+-rf\\s+/"
+  action          = "end_session"
+  severity        = "critical"
+  protocols       = ["SSH"]
+}
+`
+}
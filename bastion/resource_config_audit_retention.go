@@ -0,0 +1,291 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigAuditRetention struct {
+	SessionRecordingsDays int                              `json:"session_recordings_days"`
+	SessionMetadataDays   int                              `json:"session_metadata_days"`
+	LogsDays              int                              `json:"logs_days"`
+	ArchiveBeforePurge    bool                             `json:"archive_before_purge"`
+	Archive               *jsonConfigAuditRetentionArchive `json:"archive,omitempty"`
+}
+
+type jsonConfigAuditRetentionArchive struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Path     string `json:"path"`
+	User     string `json:"user"`
+	Password string `json:"password,omitempty"`
+}
+
+func resourceConfigAuditRetention() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigAuditRetentionCreate,
+		ReadContext:   resourceConfigAuditRetentionRead,
+		UpdateContext: resourceConfigAuditRetentionUpdate,
+		DeleteContext: resourceConfigAuditRetentionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigAuditRetentionImport,
+		},
+		CustomizeDiff: resourceConfigAuditRetentionCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"session_recordings_days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"session_metadata_days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"logs_days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"archive_before_purge": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"archive": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateHostnameOrIP,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      22,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"user": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceConfigAuditRetentionCustomizeDiff rejects the metadata kept for less time than the recordings,
+// they're needed to search and replay the recordings, and archive_before_purge without archive.
+func resourceConfigAuditRetentionCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.NewValueKnown("session_recordings_days") && d.NewValueKnown("session_metadata_days") &&
+		d.Get("session_metadata_days").(int) < d.Get("session_recordings_days").(int) {
+		return fmt.Errorf("session_metadata_days (%d) must be greater than or equal to session_recordings_days (%d)",
+			d.Get("session_metadata_days").(int), d.Get("session_recordings_days").(int))
+	}
+	if d.Get("archive_before_purge").(bool) && len(d.Get("archive").([]interface{})) == 0 {
+		return errors.New("archive_before_purge requires an archive block")
+	}
+
+	return nil
+}
+
+func resourceConfigAuditRetentionVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_audit_retention not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceConfigAuditRetentionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigAuditRetentionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigAuditRetention(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("auditRetentionConfig")
+
+	return resourceConfigAuditRetentionRead(ctx, d, m)
+}
+
+func resourceConfigAuditRetentionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigAuditRetentionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigAuditRetentionOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigAuditRetention(d, cfg)
+
+	return nil
+}
+
+func resourceConfigAuditRetentionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigAuditRetentionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigAuditRetention(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigAuditRetentionRead(ctx, d, m)
+}
+
+func resourceConfigAuditRetentionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigAuditRetentionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// A retention of zero day would purge all the audit data, so restore the defaults of the appliance
+	if err := deleteConfigAuditRetention(ctx, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Audit retention restored to the appliance defaults",
+		Detail: "The audit retention of the Bastion can't be removed, " +
+			"destroying wallix-bastion_config_audit_retention restored the default retention periods.",
+	}}
+}
+
+func resourceConfigAuditRetentionImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "auditRetentionConfig" ID
+	d.SetId("auditRetentionConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigAuditRetentionOptions(ctx context.Context, m interface{}) (jsonConfigAuditRetention, error) {
+	c := m.(*Client)
+	var result jsonConfigAuditRetention
+	body, code, err := c.newRequest(ctx, "/config/auditretention", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigAuditRetention(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareConfigAuditRetentionJSON(d)
+	body, code, err := c.newRequest(ctx, "/config/auditretention", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func deleteConfigAuditRetention(ctx context.Context, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/auditretention", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigAuditRetentionJSON(d *schema.ResourceData) jsonConfigAuditRetention {
+	jsonData := jsonConfigAuditRetention{
+		SessionRecordingsDays: d.Get("session_recordings_days").(int),
+		SessionMetadataDays:   d.Get("session_metadata_days").(int),
+		LogsDays:              d.Get("logs_days").(int),
+		ArchiveBeforePurge:    d.Get("archive_before_purge").(bool),
+	}
+	if listArchive := d.Get("archive").([]interface{}); len(listArchive) > 0 && listArchive[0] != nil {
+		archive := listArchive[0].(map[string]interface{})
+		jsonData.Archive = &jsonConfigAuditRetentionArchive{
+			Host:     archive["host"].(string),
+			Port:     archive["port"].(int),
+			Path:     archive["path"].(string),
+			User:     archive["user"].(string),
+			Password: archive["password"].(string),
+		}
+	}
+
+	return jsonData
+}
+
+// fillConfigAuditRetention sets all the attributes, the password of the archive is never returned by the API
+// so the value in state is kept.
+func fillConfigAuditRetention(d *schema.ResourceData, jsonData jsonConfigAuditRetention) {
+	if tfErr := d.Set("session_recordings_days", jsonData.SessionRecordingsDays); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("session_metadata_days", jsonData.SessionMetadataDays); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("logs_days", jsonData.LogsDays); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("archive_before_purge", jsonData.ArchiveBeforePurge); tfErr != nil {
+		panic(tfErr)
+	}
+	archive := make([]map[string]interface{}, 0, 1)
+	if jsonData.Archive != nil && jsonData.Archive.Host != "" {
+		password := ""
+		if listArchive := d.Get("archive").([]interface{}); len(listArchive) > 0 && listArchive[0] != nil {
+			password = listArchive[0].(map[string]interface{})["password"].(string)
+		}
+		archive = append(archive, map[string]interface{}{
+			"host":     jsonData.Archive.Host,
+			"port":     jsonData.Archive.Port,
+			"path":     jsonData.Archive.Path,
+			"user":     jsonData.Archive.User,
+			"password": password,
+		})
+	}
+	if tfErr := d.Set("archive", archive); tfErr != nil {
+		panic(tfErr)
+	}
+}
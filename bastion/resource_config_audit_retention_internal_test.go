@@ -0,0 +1,136 @@
+package bastion
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceConfigAuditRetentionCustomizeDiff(t *testing.T) {
+	archive := []interface{}{map[string]interface{}{
+		"host":     "archive.example.com",
+		"path":     "/srv/archive",
+		"user":     "archiver",
+		"password": "secret",
+	}}
+	tests := map[string]struct {
+		config   map[string]interface{}
+		errMatch string
+	}{
+		"metadata kept longer": {
+			config: map[string]interface{}{},
+		},
+		"metadata kept as long": {
+			config: map[string]interface{}{
+				"session_metadata_days": 90,
+			},
+		},
+		"metadata kept shorter": {
+			config: map[string]interface{}{
+				"session_metadata_days": 30,
+			},
+			errMatch: "session_metadata_days (30) must be greater than or equal to session_recordings_days (90)",
+		},
+		"archive before purge": {
+			config: map[string]interface{}{
+				"archive_before_purge": true,
+				"archive":              archive,
+			},
+		},
+		"archive before purge without archive": {
+			config: map[string]interface{}{
+				"archive_before_purge": true,
+			},
+			errMatch: "archive_before_purge requires an archive block",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"session_recordings_days": 90,
+				"session_metadata_days":   365,
+				"logs_days":               180,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			_, err := resourceConfigAuditRetention().Diff(
+				context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.errMatch == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMatch) {
+				t.Fatalf("expected error matching %q, got %v", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestResourceConfigAuditRetentionDeleteRestoresDefaults(t *testing.T) {
+	var method string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3.12/config/auditretention" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		method = r.Method
+		w.WriteHeader(http.StatusNoContent)
+	})
+	d := resourceConfigAuditRetention().TestResourceData()
+	d.SetId("auditRetentionConfig")
+	diags := resourceConfigAuditRetentionDelete(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if method != http.MethodDelete {
+		t.Errorf("expected a DELETE to restore the defaults, got %s", method)
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Summary, "restored to the appliance defaults") {
+		t.Errorf("expected a warning about the restored defaults, got %v", diags)
+	}
+}
+
+func TestFillConfigAuditRetentionKeepsArchivePassword(t *testing.T) {
+	d := resourceConfigAuditRetention().TestResourceData()
+	if err := d.Set("archive", []interface{}{map[string]interface{}{
+		"host":     "archive.example.com",
+		"port":     22,
+		"path":     "/srv/archive",
+		"user":     "archiver",
+		"password": "secret",
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	fillConfigAuditRetention(d, jsonConfigAuditRetention{
+		SessionRecordingsDays: 90,
+		SessionMetadataDays:   365,
+		LogsDays:              180,
+		ArchiveBeforePurge:    true,
+		Archive: &jsonConfigAuditRetentionArchive{
+			Host: "archive2.example.com",
+			Port: 2222,
+			Path: "/srv/archive",
+			User: "archiver",
+		},
+	})
+	if v := d.Get("archive.0.host").(string); v != "archive2.example.com" {
+		t.Errorf("expected archive host from the api, got %q", v)
+	}
+	if v := d.Get("archive.0.password").(string); v != "secret" {
+		t.Errorf("expected archive password to be kept from state, got %q", v)
+	}
+	fillConfigAuditRetention(d, jsonConfigAuditRetention{
+		SessionRecordingsDays: 90,
+		SessionMetadataDays:   365,
+		LogsDays:              180,
+	})
+	if v := d.Get("archive").([]interface{}); len(v) != 0 {
+		t.Errorf("expected no archive without destination from the api, got %v", v)
+	}
+}
@@ -0,0 +1,84 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigAuditRetention_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_audit_retention.testacc_ConfigAuditRetention"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigAuditRetentionInvalid(),
+				ExpectError: regexp.MustCompile(`must be greater than or equal to session_recordings_days`),
+			},
+			{
+				Config: testAccResourceConfigAuditRetentionCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "session_recordings_days", "90"),
+					resource.TestCheckResourceAttr(resourceName, "archive.#", "0"),
+				),
+			},
+			{
+				Config: testAccResourceConfigAuditRetentionUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "archive_before_purge", "true"),
+					resource.TestCheckResourceAttr(resourceName, "archive.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "archive.0.host", "192.0.2.50"),
+					resource.TestCheckResourceAttr(resourceName, "archive.0.port", "22"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "auditretention",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"archive.0.password"},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigAuditRetentionInvalid() string {
+	return `
+resource "wallix-bastion_config_audit_retention" "testacc_ConfigAuditRetention" {
+  session_recordings_days = 90
+  session_metadata_days   = 30
+  logs_days               = 180
+}
+`
+}
+
+func testAccResourceConfigAuditRetentionCreate() string {
+	return `
+resource "wallix-bastion_config_audit_retention" "testacc_ConfigAuditRetention" {
+  session_recordings_days = 90
+  session_metadata_days   = 365
+  logs_days               = 180
+}
+`
+}
+
+func testAccResourceConfigAuditRetentionUpdate() string {
+	return `
+resource "wallix-bastion_config_audit_retention" "testacc_ConfigAuditRetention" {
+  session_recordings_days = 90
+  session_metadata_days   = 365
+  logs_days               = 180
+  archive_before_purge    = true
+  archive {
+    host     = "192.0.2.50"
+    path     = "/srv/bastion-archive"
+    user     = "archiver"
+    password = "testacc_ConfigAuditRetentionPassword"
+  }
+}
+`
+}
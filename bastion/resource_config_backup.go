@@ -0,0 +1,265 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigBackup struct {
+	Enable               bool     `json:"enable"`
+	Host                 string   `json:"host"`
+	Port                 int      `json:"port"`
+	Path                 string   `json:"path"`
+	User                 string   `json:"user"`
+	Password             string   `json:"password,omitempty"`
+	SSHPrivateKey        string   `json:"ssh_private_key,omitempty"`
+	ScheduleDays         []string `json:"schedule_days"`
+	ScheduleTime         string   `json:"schedule_time"`
+	RetentionCount       int      `json:"retention_count"`
+	EncryptionPassphrase string   `json:"encryption_passphrase,omitempty"`
+}
+
+func resourceConfigBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigBackupCreate,
+		ReadContext:   resourceConfigBackupRead,
+		UpdateContext: resourceConfigBackupUpdate,
+		DeleteContext: resourceConfigBackupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigBackupImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      22,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "ssh_private_key"},
+			},
+			"ssh_private_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "ssh_private_key"},
+			},
+			"schedule_days": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+					}, false),
+				},
+			},
+			"schedule_time": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`),
+					"must be a time in the HH:MM format"),
+			},
+			"retention_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      7,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"encryption_passphrase": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(8, 256),
+			},
+			"enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceConfigBackupVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_backup not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigBackupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigBackupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigBackup(ctx, prepareConfigBackupJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("backupConfig")
+
+	return resourceConfigBackupRead(ctx, d, m)
+}
+
+func resourceConfigBackupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigBackupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigBackupOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigBackup(d, cfg)
+
+	return nil
+}
+
+func resourceConfigBackupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigBackupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigBackup(ctx, prepareConfigBackupJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigBackupRead(ctx, d, m)
+}
+
+func resourceConfigBackupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigBackupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The backup configuration can't be removed, so disable the scheduled backups
+	cfg, err := readConfigBackupOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg.Enable = false
+	if err := updateConfigBackup(ctx, cfg, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigBackupImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "backupConfig" ID
+	d.SetId("backupConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigBackupOptions(ctx context.Context, m interface{}) (jsonConfigBackup, error) {
+	c := m.(*Client)
+	var result jsonConfigBackup
+	body, code, err := c.newRequest(ctx, "/config/backup", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigBackup(ctx context.Context, jsonData jsonConfigBackup, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/backup", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigBackupJSON(d *schema.ResourceData) jsonConfigBackup {
+	jsonData := jsonConfigBackup{
+		Enable:               d.Get("enable").(bool),
+		Host:                 d.Get("host").(string),
+		Port:                 d.Get("port").(int),
+		Path:                 d.Get("path").(string),
+		User:                 d.Get("user").(string),
+		Password:             d.Get("password").(string),
+		SSHPrivateKey:        d.Get("ssh_private_key").(string),
+		ScheduleTime:         d.Get("schedule_time").(string),
+		RetentionCount:       d.Get("retention_count").(int),
+		EncryptionPassphrase: d.Get("encryption_passphrase").(string),
+	}
+	listScheduleDays := d.Get("schedule_days").(*schema.Set).List()
+	jsonData.ScheduleDays = make([]string, len(listScheduleDays))
+	for i, v := range listScheduleDays {
+		jsonData.ScheduleDays[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+// fillConfigBackup sets all the attributes except password, ssh_private_key and encryption_passphrase
+// which are never returned by the API, so the values in state are kept.
+func fillConfigBackup(d *schema.ResourceData, jsonData jsonConfigBackup) {
+	if tfErr := d.Set("enable", jsonData.Enable); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("host", jsonData.Host); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("path", jsonData.Path); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("user", jsonData.User); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("schedule_days", jsonData.ScheduleDays); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("schedule_time", jsonData.ScheduleTime); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("retention_count", jsonData.RetentionCount); tfErr != nil {
+		panic(tfErr)
+	}
+}
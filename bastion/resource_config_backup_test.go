@@ -0,0 +1,67 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigBackup_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_backup.testacc_ConfigBackup"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigBackupCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "schedule_days.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceConfigBackupUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule_days.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "retention_count", "14"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "backup_config",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigBackupCreate() string {
+	return `
+resource "wallix-bastion_config_backup" "testacc_ConfigBackup" {
+  host                  = "192.0.2.20"
+  path                  = "/backups/bastion"
+  user                  = "testacc"
+  password              = "testacc_password"
+  schedule_days         = ["sunday"]
+  schedule_time         = "02:30"
+  encryption_passphrase = "testacc_passphrase"
+}
+`
+}
+
+func testAccResourceConfigBackupUpdate() string {
+	return `
+resource "wallix-bastion_config_backup" "testacc_ConfigBackup" {
+  host                  = "192.0.2.20"
+  port                  = 2222
+  path                  = "/backups/bastion"
+  user                  = "testacc"
+  password              = "testacc_password"
+  schedule_days         = ["wednesday", "sunday"]
+  schedule_time         = "03:00"
+  retention_count       = 14
+  encryption_passphrase = "testacc_passphrase"
+}
+`
+}
@@ -0,0 +1,416 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// localPasswordPolicyDefault is the name of the policy which always exists on the Bastion.
+const localPasswordPolicyDefault = "default"
+
+func resourceConfigLocalPasswordPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigLocalPasswordPolicyCreate,
+		ReadContext:   resourceConfigLocalPasswordPolicyRead,
+		UpdateContext: resourceConfigLocalPasswordPolicyUpdate,
+		DeleteContext: resourceConfigLocalPasswordPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigLocalPasswordPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"allow_same_user_and_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"forbidden_passwords": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"last_passwords_to_reject": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 50),
+			},
+			"max_auth_failures": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_expiration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_min_digit_chars": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_min_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      12,
+				ValidateFunc: validation.IntBetween(8, 64),
+			},
+			"password_min_lower_chars": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_min_special_chars": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_min_upper_chars": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_warning_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"ssh_key_algos_allowed": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"ssh-rsa", "ssh-ed25519", "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384", "ecdsa-sha2-nistp521",
+					}, false),
+				},
+			},
+			"ssh_rsa_min_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntInSlice([]int{1024, 2048, 3072, 4096, 8192}),
+			},
+		},
+	}
+}
+
+func resourceConfigLocalPasswordPolicyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_local_password_policy not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceConfigLocalPasswordPolicyCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigLocalPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	existingID, ex, err := searchResourceConfigLocalPasswordPolicy(ctx, d.Get("policy_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		if d.Get("policy_name").(string) != localPasswordPolicyDefault {
+			return diagFromAPIError(fmt.Errorf("policy_name %s already exists", d.Get("policy_name").(string)))
+		}
+		// The default policy always exists, so adopt it and update it with the configuration
+		d.SetId(existingID)
+		if err := updateConfigLocalPasswordPolicy(ctx, d, m); err != nil {
+			return diagFromAPIError(err)
+		}
+
+		return resourceConfigLocalPasswordPolicyRead(ctx, d, m)
+	}
+	err = addConfigLocalPasswordPolicy(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceConfigLocalPasswordPolicy(ctx, d.Get("policy_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("policy_name %s not found after POST", d.Get("policy_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceConfigLocalPasswordPolicyRead(ctx, d, m)
+}
+
+func resourceConfigLocalPasswordPolicyRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigLocalPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigLocalPasswordPolicyOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillConfigLocalPasswordPolicy(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceConfigLocalPasswordPolicyUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigLocalPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigLocalPasswordPolicy(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigLocalPasswordPolicyRead(ctx, d, m)
+}
+
+func resourceConfigLocalPasswordPolicyDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigLocalPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.Get("policy_name").(string) == localPasswordPolicyDefault {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Default local password policy kept on the Bastion",
+			Detail: "The default local password policy can't be deleted, " +
+				"destroying wallix-bastion_config_local_password_policy only removed it from the state.",
+		}}
+	}
+	if err := deleteConfigLocalPasswordPolicy(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigLocalPasswordPolicyImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceConfigLocalPasswordPolicyVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceConfigLocalPasswordPolicy(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find policy_name with id %s (id must be <policy_name>)", d.Id())
+	}
+	cfg, err := readConfigLocalPasswordPolicyOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillConfigLocalPasswordPolicy(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceConfigLocalPasswordPolicy(
+	ctx context.Context, policyName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx,
+		"/localpasswordpolicies/?q=password_policy_name="+policyName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonLocalPasswordPolicy
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	for _, v := range results {
+		if v.PasswordPolicyName == policyName {
+			return v.ID, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func addConfigLocalPasswordPolicy(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareConfigLocalPasswordPolicyJSON(d)
+	body, code, err := c.newRequest(ctx, "/localpasswordpolicies/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateConfigLocalPasswordPolicy(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareConfigLocalPasswordPolicyJSON(d)
+	body, code, err := c.newRequest(ctx, "/localpasswordpolicies/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteConfigLocalPasswordPolicy(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/localpasswordpolicies/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigLocalPasswordPolicyJSON(d *schema.ResourceData) jsonLocalPasswordPolicy {
+	jsonData := jsonLocalPasswordPolicy{
+		PasswordPolicyName:       d.Get("policy_name").(string),
+		AllowSameUserAndPassword: d.Get("allow_same_user_and_password").(bool),
+		PasswordExpiration:       d.Get("password_expiration").(int),
+		PasswordWarningDays:      d.Get("password_warning_days").(int),
+		PasswordMinLength:        d.Get("password_min_length").(int),
+		PasswordMinLowerChars:    d.Get("password_min_lower_chars").(int),
+		PasswordMinUpperChars:    d.Get("password_min_upper_chars").(int),
+		PasswordMinDigitChars:    d.Get("password_min_digit_chars").(int),
+		PasswordMinSpecialChars:  d.Get("password_min_special_chars").(int),
+		LastPasswordsToReject:    d.Get("last_passwords_to_reject").(int),
+		MaxAuthFailures:          d.Get("max_auth_failures").(int),
+		SSHRsaMinLength:          d.Get("ssh_rsa_min_length").(int),
+	}
+	listForbiddenPasswords := d.Get("forbidden_passwords").(*schema.Set).List()
+	jsonData.ForbiddenPasswords = make([]string, len(listForbiddenPasswords))
+	for i, v := range listForbiddenPasswords {
+		jsonData.ForbiddenPasswords[i] = v.(string)
+	}
+	listSSHKeyAlgosAllowed := d.Get("ssh_key_algos_allowed").(*schema.Set).List()
+	jsonData.SSHKeyAlgosAllowed = make([]string, len(listSSHKeyAlgosAllowed))
+	for i, v := range listSSHKeyAlgosAllowed {
+		jsonData.SSHKeyAlgosAllowed[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readConfigLocalPasswordPolicyOptions(
+	ctx context.Context, policyID string, m interface{},
+) (
+	jsonLocalPasswordPolicy, error,
+) {
+	c := m.(*Client)
+	var result jsonLocalPasswordPolicy
+	body, code, err := c.newRequest(ctx, "/localpasswordpolicies/"+policyID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillConfigLocalPasswordPolicy(d *schema.ResourceData, jsonData jsonLocalPasswordPolicy) {
+	if tfErr := d.Set("policy_name", jsonData.PasswordPolicyName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("allow_same_user_and_password", jsonData.AllowSameUserAndPassword); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("password_expiration", jsonData.PasswordExpiration); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("password_warning_days", jsonData.PasswordWarningDays); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("password_min_length", jsonData.PasswordMinLength); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("password_min_lower_chars", jsonData.PasswordMinLowerChars); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("password_min_upper_chars", jsonData.PasswordMinUpperChars); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("password_min_digit_chars", jsonData.PasswordMinDigitChars); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("password_min_special_chars", jsonData.PasswordMinSpecialChars); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("last_passwords_to_reject", jsonData.LastPasswordsToReject); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("max_auth_failures", jsonData.MaxAuthFailures); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ssh_rsa_min_length", jsonData.SSHRsaMinLength); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("forbidden_passwords", jsonData.ForbiddenPasswords); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ssh_key_algos_allowed", jsonData.SSHKeyAlgosAllowed); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,86 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigLocalPasswordPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigLocalPasswordPolicyInvalid(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected password_min_length to be in the range \(8 - 64\)`),
+			},
+			{
+				Config: testAccResourceConfigLocalPasswordPolicyCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_config_local_password_policy.testacc_ConfigLocalPasswordPolicy",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceConfigLocalPasswordPolicyUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_config_local_password_policy.testacc_ConfigLocalPasswordPolicy",
+						"password_min_length", "16"),
+				),
+			},
+			{
+				ResourceName:  "wallix-bastion_config_local_password_policy.testacc_ConfigLocalPasswordPolicy",
+				ImportState:   true,
+				ImportStateId: "default",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigLocalPasswordPolicyInvalid() string {
+	return `
+resource "wallix-bastion_config_local_password_policy" "testacc_ConfigLocalPasswordPolicy" {
+  policy_name         = "default"
+  password_min_length = 6
+}
+`
+}
+
+func testAccResourceConfigLocalPasswordPolicyCreate() string {
+	return `
+resource "wallix-bastion_config_local_password_policy" "testacc_ConfigLocalPasswordPolicy" {
+  policy_name              = "default"
+  password_min_length      = 12
+  password_min_digit_chars = 1
+  max_auth_failures        = 5
+  ssh_key_algos_allowed    = ["ssh-ed25519", "ssh-rsa"]
+  ssh_rsa_min_length       = 2048
+}
+`
+}
+
+func testAccResourceConfigLocalPasswordPolicyUpdate() string {
+	return `
+resource "wallix-bastion_config_local_password_policy" "testacc_ConfigLocalPasswordPolicy" {
+  policy_name                = "default"
+  password_min_length        = 16
+  password_min_lower_chars   = 1
+  password_min_upper_chars   = 1
+  password_min_digit_chars   = 1
+  password_min_special_chars = 1
+  password_expiration        = 90
+  password_warning_days      = 7
+  last_passwords_to_reject   = 5
+  max_auth_failures          = 3
+  forbidden_passwords        = ["password", "wallix"]
+  ssh_key_algos_allowed      = ["ssh-ed25519", "ecdsa-sha2-nistp256"]
+  ssh_rsa_min_length         = 4096
+}
+`
+}
@@ -0,0 +1,224 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigLoginBanner struct {
+	Title        string                             `json:"title"`
+	Body         string                             `json:"body"`
+	Translations []jsonConfigLoginBannerTranslation `json:"translations"`
+}
+
+type jsonConfigLoginBannerTranslation struct {
+	Language string `json:"language"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+}
+
+func resourceConfigLoginBanner() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigLoginBannerCreate,
+		ReadContext:   resourceConfigLoginBannerRead,
+		UpdateContext: resourceConfigLoginBannerUpdate,
+		DeleteContext: resourceConfigLoginBannerDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigLoginBannerImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"translation": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"language": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"de", "en", "es", "fr", "ru"}, false),
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"body": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceConfigLoginBannerVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_login_banner not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigLoginBannerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigLoginBannerVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigLoginBanner(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("loginBannerConfig")
+
+	return resourceConfigLoginBannerRead(ctx, d, m)
+}
+
+func resourceConfigLoginBannerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigLoginBannerVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigLoginBannerOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigLoginBanner(d, cfg)
+
+	return nil
+}
+
+func resourceConfigLoginBannerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigLoginBannerVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigLoginBanner(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigLoginBannerRead(ctx, d, m)
+}
+
+func resourceConfigLoginBannerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigLoginBannerVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Restore the default banner of the product
+	if err := deleteConfigLoginBanner(ctx, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigLoginBannerImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "loginBannerConfig" ID
+	d.SetId("loginBannerConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigLoginBannerOptions(ctx context.Context, m interface{}) (jsonConfigLoginBanner, error) {
+	c := m.(*Client)
+	var result jsonConfigLoginBanner
+	body, code, err := c.newRequest(ctx, "/config/loginbanner", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigLoginBanner(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareConfigLoginBannerJSON(d)
+	body, code, err := c.newRequest(ctx, "/config/loginbanner", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func deleteConfigLoginBanner(ctx context.Context, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/loginbanner", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigLoginBannerJSON(d *schema.ResourceData) jsonConfigLoginBanner {
+	jsonData := jsonConfigLoginBanner{
+		Title: d.Get("title").(string),
+		Body:  d.Get("body").(string),
+	}
+	listTranslation := d.Get("translation").(*schema.Set).List()
+	jsonData.Translations = make([]jsonConfigLoginBannerTranslation, len(listTranslation))
+	for i, v := range listTranslation {
+		translation := v.(map[string]interface{})
+		jsonData.Translations[i] = jsonConfigLoginBannerTranslation{
+			Language: translation["language"].(string),
+			Title:    translation["title"].(string),
+			Body:     translation["body"].(string),
+		}
+	}
+
+	return jsonData
+}
+
+func fillConfigLoginBanner(d *schema.ResourceData, jsonData jsonConfigLoginBanner) {
+	if tfErr := d.Set("title", jsonData.Title); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("body", jsonData.Body); tfErr != nil {
+		panic(tfErr)
+	}
+	translation := make([]map[string]interface{}, len(jsonData.Translations))
+	for i, v := range jsonData.Translations {
+		translation[i] = map[string]interface{}{
+			"language": v.Language,
+			"title":    v.Title,
+			"body":     v.Body,
+		}
+	}
+	if tfErr := d.Set("translation", translation); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,59 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigLoginBanner_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_login_banner.testacc_ConfigLoginBanner"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigLoginBannerCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "title", "testacc title"),
+				),
+			},
+			{
+				Config: testAccResourceConfigLoginBannerUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "translation.#", "1"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "login_banner_config",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigLoginBannerCreate() string {
+	return `
+resource "wallix-bastion_config_login_banner" "testacc_ConfigLoginBanner" {
+  title = "testacc title"
+  body  = "testacc body"
+}
+`
+}
+
+func testAccResourceConfigLoginBannerUpdate() string {
+	return `
+resource "wallix-bastion_config_login_banner" "testacc_ConfigLoginBanner" {
+  title = "testacc title"
+  body  = "testacc body updated"
+  translation {
+    language = "fr"
+    title    = "testacc titre"
+    body     = "testacc texte"
+  }
+}
+`
+}
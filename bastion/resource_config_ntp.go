@@ -0,0 +1,217 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigNTP struct {
+	Enable     bool     `json:"enable"`
+	NTPServers []string `json:"ntp_servers"`
+	Timezone   string   `json:"timezone,omitempty"`
+}
+
+func resourceConfigNTP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigNTPCreate,
+		ReadContext:   resourceConfigNTPRead,
+		UpdateContext: resourceConfigNTPUpdate,
+		DeleteContext: resourceConfigNTPDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigNTPImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"ntp_servers": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateHostnameOrIP,
+				},
+			},
+			"timezone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+var hostnameRegexp = regexp.MustCompile( //nolint:gochecknoglobals
+	`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// validateHostnameOrIP checks that the value is an IP address or a valid (RFC 1123) hostname.
+func validateHostnameOrIP(val interface{}, key string) ([]string, []error) {
+	v := val.(string)
+	if net.ParseIP(v) != nil {
+		return nil, nil
+	}
+	if len(v) > 253 || !hostnameRegexp.MatchString(v) {
+		return nil, []error{fmt.Errorf("%q must be a valid hostname or IP address, got: %s", key, v)}
+	}
+
+	return nil, nil
+}
+
+func resourceConfigNTPVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_ntp not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigNTPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigNTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigNTP(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("ntpConfig")
+
+	return resourceConfigNTPRead(ctx, d, m)
+}
+
+func resourceConfigNTPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigNTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigNTPOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigNTP(d, cfg)
+
+	return nil
+}
+
+func resourceConfigNTPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigNTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigNTP(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigNTPRead(ctx, d, m)
+}
+
+func resourceConfigNTPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigNTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The time service can't be removed, so restore the defaults of the appliance
+	if err := deleteConfigNTP(ctx, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "NTP configuration restored to the appliance defaults",
+		Detail: "The time service of the Bastion can't be removed, " +
+			"destroying wallix-bastion_config_ntp restored the default NTP servers and timezone.",
+	}}
+}
+
+func resourceConfigNTPImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "ntpConfig" ID
+	d.SetId("ntpConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigNTPOptions(ctx context.Context, m interface{}) (jsonConfigNTP, error) {
+	c := m.(*Client)
+	var result jsonConfigNTP
+	body, code, err := c.newRequest(ctx, "/config/timeservice", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigNTP(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareConfigNTPJSON(d)
+	body, code, err := c.newRequest(ctx, "/config/timeservice", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func deleteConfigNTP(ctx context.Context, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/timeservice", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigNTPJSON(d *schema.ResourceData) jsonConfigNTP {
+	jsonData := jsonConfigNTP{
+		Enable:   d.Get("enable").(bool),
+		Timezone: d.Get("timezone").(string),
+	}
+	listNTPServers := d.Get("ntp_servers").([]interface{})
+	jsonData.NTPServers = make([]string, len(listNTPServers))
+	for i, v := range listNTPServers {
+		jsonData.NTPServers[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func fillConfigNTP(d *schema.ResourceData, jsonData jsonConfigNTP) {
+	if tfErr := d.Set("ntp_servers", jsonData.NTPServers); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("timezone", jsonData.Timezone); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("enable", jsonData.Enable); tfErr != nil {
+		panic(tfErr)
+	}
+}
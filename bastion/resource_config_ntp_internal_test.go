@@ -0,0 +1,29 @@
+package bastion
+
+import "testing"
+
+func TestValidateHostnameOrIP(t *testing.T) {
+	tests := map[string]bool{
+		"pool.ntp.org":           true,
+		"ntp1":                   true,
+		"192.0.2.10":             true,
+		"2001:db8::123":          true,
+		"":                       false,
+		"ntp_server.example.com": false,
+		"-ntp.example.com":       false,
+		"ntp.example.com.":       false,
+		"ntp .example.com":       false,
+		"http://pool.ntp.org":    false,
+	}
+	for value, valid := range tests {
+		t.Run(value, func(t *testing.T) {
+			_, errs := validateHostnameOrIP(value, "ntp_servers.0")
+			if valid && len(errs) > 0 {
+				t.Errorf("expected %q to be valid, got: %v", value, errs)
+			}
+			if !valid && len(errs) == 0 {
+				t.Errorf("expected %q to be invalid", value)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigNTP_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_ntp.testacc_ConfigNTP"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigNTPInvalid(),
+				ExpectError: regexp.MustCompile(`must be a valid hostname or IP address`),
+			},
+			{
+				Config: testAccResourceConfigNTPCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "ntp_servers.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceConfigNTPUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ntp_servers.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ntp_servers.0", "192.0.2.123"),
+					resource.TestCheckResourceAttr(resourceName, "ntp_servers.1", "pool.ntp.org"),
+					resource.TestCheckResourceAttr(resourceName, "timezone", "Europe/Paris"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "ntp_config",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigNTPInvalid() string {
+	return `
+resource "wallix-bastion_config_ntp" "testacc_ConfigNTP" {
+  ntp_servers = ["pool ntp org"]
+}
+`
+}
+
+func testAccResourceConfigNTPCreate() string {
+	return `
+resource "wallix-bastion_config_ntp" "testacc_ConfigNTP" {
+  ntp_servers = ["pool.ntp.org"]
+}
+`
+}
+
+func testAccResourceConfigNTPUpdate() string {
+	return `
+resource "wallix-bastion_config_ntp" "testacc_ConfigNTP" {
+  ntp_servers = ["192.0.2.123", "pool.ntp.org"]
+  timezone    = "Europe/Paris"
+}
+`
+}
@@ -0,0 +1,294 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// configPasswordPolicyDefaultMinLength is the minimum length restored on delete.
+const configPasswordPolicyDefaultMinLength = 12
+
+type jsonConfigPasswordPolicy struct {
+	MinLength    int                                 `json:"min_length"`
+	HistoryCount int                                 `json:"history_count"`
+	MaxAge       int                                 `json:"max_age"`
+	Complexity   *jsonConfigPasswordPolicyComplexity `json:"complexity"`
+	Lockout      *jsonConfigPasswordPolicyLockout    `json:"lockout"`
+}
+
+type jsonConfigPasswordPolicyComplexity struct {
+	MinLowercase int `json:"min_lowercase"`
+	MinUppercase int `json:"min_uppercase"`
+	MinDigits    int `json:"min_digits"`
+	MinSpecial   int `json:"min_special"`
+}
+
+type jsonConfigPasswordPolicyLockout struct {
+	MaxFailures int `json:"max_failures"`
+	Duration    int `json:"duration"`
+}
+
+func resourceConfigPasswordPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigPasswordPolicyCreate,
+		ReadContext:   resourceConfigPasswordPolicyRead,
+		UpdateContext: resourceConfigPasswordPolicyUpdate,
+		DeleteContext: resourceConfigPasswordPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigPasswordPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"min_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      configPasswordPolicyDefaultMinLength,
+				ValidateFunc: validation.IntBetween(8, 128),
+			},
+			"complexity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_lowercase": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 32),
+						},
+						"min_uppercase": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 32),
+						},
+						"min_digits": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 32),
+						},
+						"min_special": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 32),
+						},
+					},
+				},
+			},
+			"history_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 50),
+			},
+			"max_age": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 3650),
+			},
+			"lockout": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_failures": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 100),
+						},
+						"duration": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntBetween(1, 1440),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceConfigPasswordPolicyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_password_policy not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceConfigPasswordPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	jsonData, err := prepareConfigPasswordPolicyJSON(d)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigPasswordPolicy(ctx, jsonData, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("passwordPolicyConfig")
+
+	return resourceConfigPasswordPolicyRead(ctx, d, m)
+}
+
+func resourceConfigPasswordPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigPasswordPolicyOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigPasswordPolicy(d, cfg)
+
+	return nil
+}
+
+func resourceConfigPasswordPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	jsonData, err := prepareConfigPasswordPolicyJSON(d)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigPasswordPolicy(ctx, jsonData, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigPasswordPolicyRead(ctx, d, m)
+}
+
+func resourceConfigPasswordPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigPasswordPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The password policy can't be removed, so restore the default policy
+	if err := updateConfigPasswordPolicy(ctx, jsonConfigPasswordPolicy{
+		MinLength: configPasswordPolicyDefaultMinLength,
+	}, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigPasswordPolicyImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "passwordPolicyConfig" ID
+	d.SetId("passwordPolicyConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigPasswordPolicyOptions(ctx context.Context, m interface{}) (jsonConfigPasswordPolicy, error) {
+	c := m.(*Client)
+	var result jsonConfigPasswordPolicy
+	body, code, err := c.newRequest(ctx, "/config/password_policy", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigPasswordPolicy(ctx context.Context, jsonData jsonConfigPasswordPolicy, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/password_policy", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigPasswordPolicyJSON(d *schema.ResourceData) (jsonConfigPasswordPolicy, error) {
+	jsonData := jsonConfigPasswordPolicy{
+		MinLength:    d.Get("min_length").(int),
+		HistoryCount: d.Get("history_count").(int),
+		MaxAge:       d.Get("max_age").(int),
+	}
+	if listComplexity := d.Get("complexity").([]interface{}); len(listComplexity) > 0 && listComplexity[0] != nil {
+		complexity := listComplexity[0].(map[string]interface{})
+		jsonData.Complexity = &jsonConfigPasswordPolicyComplexity{
+			MinLowercase: complexity["min_lowercase"].(int),
+			MinUppercase: complexity["min_uppercase"].(int),
+			MinDigits:    complexity["min_digits"].(int),
+			MinSpecial:   complexity["min_special"].(int),
+		}
+		// a password can't satisfy more required characters than its minimum length
+		required := jsonData.Complexity.MinLowercase + jsonData.Complexity.MinUppercase +
+			jsonData.Complexity.MinDigits + jsonData.Complexity.MinSpecial
+		if required > jsonData.MinLength {
+			return jsonData, fmt.Errorf("complexity requires %d characters, more than min_length (%d)",
+				required, jsonData.MinLength)
+		}
+	}
+	if listLockout := d.Get("lockout").([]interface{}); len(listLockout) > 0 && listLockout[0] != nil {
+		lockout := listLockout[0].(map[string]interface{})
+		jsonData.Lockout = &jsonConfigPasswordPolicyLockout{
+			MaxFailures: lockout["max_failures"].(int),
+			Duration:    lockout["duration"].(int),
+		}
+	}
+
+	return jsonData, nil
+}
+
+func fillConfigPasswordPolicy(d *schema.ResourceData, jsonData jsonConfigPasswordPolicy) {
+	if tfErr := d.Set("min_length", jsonData.MinLength); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("history_count", jsonData.HistoryCount); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("max_age", jsonData.MaxAge); tfErr != nil {
+		panic(tfErr)
+	}
+	complexity := make([]map[string]interface{}, 0, 1)
+	if jsonData.Complexity != nil {
+		complexity = append(complexity, map[string]interface{}{
+			"min_lowercase": jsonData.Complexity.MinLowercase,
+			"min_uppercase": jsonData.Complexity.MinUppercase,
+			"min_digits":    jsonData.Complexity.MinDigits,
+			"min_special":   jsonData.Complexity.MinSpecial,
+		})
+	}
+	if tfErr := d.Set("complexity", complexity); tfErr != nil {
+		panic(tfErr)
+	}
+	lockout := make([]map[string]interface{}, 0, 1)
+	if jsonData.Lockout != nil && jsonData.Lockout.MaxFailures > 0 {
+		lockout = append(lockout, map[string]interface{}{
+			"max_failures": jsonData.Lockout.MaxFailures,
+			"duration":     jsonData.Lockout.Duration,
+		})
+	}
+	if tfErr := d.Set("lockout", lockout); tfErr != nil {
+		panic(tfErr)
+	}
+}
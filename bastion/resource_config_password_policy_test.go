@@ -0,0 +1,84 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigPasswordPolicy_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_password_policy.testacc_ConfigPasswordPolicy"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigPasswordPolicyTooComplex(),
+				ExpectError: regexp.MustCompile(`more than min_length`),
+			},
+			{
+				Config: testAccResourceConfigPasswordPolicyCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "min_length", "12"),
+					resource.TestCheckResourceAttr(resourceName, "complexity.#", "0"),
+				),
+			},
+			{
+				Config: testAccResourceConfigPasswordPolicyUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "min_length", "16"),
+					resource.TestCheckResourceAttr(resourceName, "complexity.0.min_special", "2"),
+					resource.TestCheckResourceAttr(resourceName, "history_count", "5"),
+					resource.TestCheckResourceAttr(resourceName, "lockout.0.duration", "30"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "password_policy",
+				ImportStateVerify: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigPasswordPolicyTooComplex() string {
+	return `
+resource "wallix-bastion_config_password_policy" "testacc_ConfigPasswordPolicy" {
+  min_length = 8
+  complexity {
+    min_lowercase = 3
+    min_uppercase = 3
+    min_digits    = 3
+  }
+}
+`
+}
+
+func testAccResourceConfigPasswordPolicyCreate() string {
+	return `
+resource "wallix-bastion_config_password_policy" "testacc_ConfigPasswordPolicy" {}
+`
+}
+
+func testAccResourceConfigPasswordPolicyUpdate() string {
+	return `
+resource "wallix-bastion_config_password_policy" "testacc_ConfigPasswordPolicy" {
+  min_length    = 16
+  history_count = 5
+  max_age       = 90
+  complexity {
+    min_lowercase = 1
+    min_uppercase = 1
+    min_digits    = 1
+    min_special   = 2
+  }
+  lockout {
+    max_failures = 3
+  }
+}
+`
+}
@@ -0,0 +1,232 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// configRDPProxySection is the configoptions section of the RDP proxy.
+const configRDPProxySection = "rdp_proxy"
+
+func resourceConfigRDPProxy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigRDPProxyCreate,
+		ReadContext:   resourceConfigRDPProxyRead,
+		UpdateContext: resourceConfigRDPProxyUpdate,
+		DeleteContext: resourceConfigRDPProxyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigRDPProxyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"tls_security_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"low", "medium", "high"}, false),
+			},
+			"security_protocols": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"rdp", "tls", "nla"}, false),
+				},
+			},
+			"cipher_suites": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"private_key"},
+				ValidateFunc: validatePEM("CERTIFICATE"),
+			},
+			"private_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"certificate"},
+				ValidateFunc: validatePEM("RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY"),
+			},
+			"keepalive_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 3600),
+			},
+			"keepalive_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 3600),
+			},
+		},
+	}
+}
+
+func resourceConfigRDPProxyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_rdp_proxy not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigRDPProxyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigRDPProxyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigRDPProxy(ctx, prepareConfigRDPProxyJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("rdpProxyConfig")
+
+	return resourceConfigRDPProxyRead(ctx, d, m)
+}
+
+func resourceConfigRDPProxyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigRDPProxyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSessionOptions(ctx, configRDPProxySection, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigRDPProxy(d, cfg)
+
+	return nil
+}
+
+func resourceConfigRDPProxyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigRDPProxyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigRDPProxy(ctx, prepareConfigRDPProxyJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigRDPProxyRead(ctx, d, m)
+}
+
+func resourceConfigRDPProxyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigRDPProxyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The options can't be removed, so restore the default value of each typed option
+	options := make(map[string]interface{})
+	for _, v := range configRDPProxyOptionNames() {
+		options[v] = ""
+	}
+	if err := updateConfigSessionOptions(ctx, configRDPProxySection, options, true, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigRDPProxyImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "rdpProxyConfig" ID
+	d.SetId("rdpProxyConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// configRDPProxyOptionNames returns the name of the options in the section for each attribute.
+func configRDPProxyOptionNames() map[string]string {
+	return map[string]string{
+		"tls_security_level": "tls_security_level",
+		"security_protocols": "security_protocols",
+		"cipher_suites":      "ssl_cipher_list",
+		"certificate":        "listener_certificate",
+		"private_key":        "listener_private_key",
+		"keepalive_interval": "keepalive_interval",
+		"keepalive_timeout":  "keepalive_timeout",
+	}
+}
+
+func updateConfigRDPProxy(ctx context.Context, jsonData jsonConfigSessionOptions, m interface{}) error {
+	if len(jsonData.Options) == 0 {
+		return nil
+	}
+
+	return putConfigSessionOptions(ctx, configRDPProxySection, jsonData, m)
+}
+
+// prepareConfigRDPProxyJSON sends only the options set in the configuration,
+// the others keep their current value on the bastion.
+func prepareConfigRDPProxyJSON(d *schema.ResourceData) jsonConfigSessionOptions {
+	optionNames := configRDPProxyOptionNames()
+	jsonData := jsonConfigSessionOptions{
+		Options: make([]jsonConfigSessionOption, 0, len(optionNames)),
+	}
+	for _, attr := range []string{
+		"tls_security_level", "cipher_suites", "certificate", "private_key", "keepalive_interval", "keepalive_timeout",
+	} {
+		if v, ok := d.GetOk(attr); ok {
+			jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{Name: optionNames[attr], Value: v})
+		}
+	}
+	if v, ok := d.GetOk("security_protocols"); ok {
+		listSecurityProtocols := v.(*schema.Set).List()
+		securityProtocols := make([]string, len(listSecurityProtocols))
+		for i, p := range listSecurityProtocols {
+			securityProtocols[i] = p.(string)
+		}
+		slices.Sort(securityProtocols)
+		jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{
+			Name:  optionNames["security_protocols"],
+			Value: securityProtocols,
+		})
+	}
+
+	return jsonData
+}
+
+// fillConfigRDPProxy maps the options of the section to the typed attributes,
+// private_key is never returned by the API.
+func fillConfigRDPProxy(d *schema.ResourceData, jsonData jsonConfigSessionOptions) {
+	values := make(map[string]interface{}, len(jsonData.Options))
+	for _, v := range jsonData.Options {
+		values[v.Name] = v.Value
+	}
+	optionNames := configRDPProxyOptionNames()
+	for _, attr := range []string{"tls_security_level", "cipher_suites", "certificate"} {
+		value, _ := values[optionNames[attr]].(string)
+		if tfErr := d.Set(attr, value); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	for _, attr := range []string{"keepalive_interval", "keepalive_timeout"} {
+		value, _ := values[optionNames[attr]].(float64)
+		if tfErr := d.Set(attr, int(value)); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	listSecurityProtocols, _ := values[optionNames["security_protocols"]].([]interface{})
+	securityProtocols := make([]string, 0, len(listSecurityProtocols))
+	for _, v := range listSecurityProtocols {
+		if p, ok := v.(string); ok {
+			securityProtocols = append(securityProtocols, p)
+		}
+	}
+	if tfErr := d.Set("security_protocols", securityProtocols); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,85 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceConfigRDPProxyOptions(t *testing.T) {
+	options := map[string]jsonConfigSessionOption{
+		"tls_security_level": {Name: "tls_security_level", Value: "medium", Default: "medium"},
+		"security_protocols": {
+			Name: "security_protocols", Value: []interface{}{"tls", "nla"}, Default: []interface{}{"nla"},
+		},
+		"ssl_cipher_list":      {Name: "ssl_cipher_list", Value: "HIGH:!aNULL", Default: "HIGH:!aNULL"},
+		"listener_certificate": {Name: "listener_certificate", Value: "", Default: ""},
+		"listener_private_key": {Name: "listener_private_key", Value: "", Default: ""},
+		"keepalive_interval":   {Name: "keepalive_interval", Value: float64(60), Default: float64(60)},
+		"keepalive_timeout":    {Name: "keepalive_timeout", Value: float64(300), Default: float64(300)},
+		"rdp_clipboard":        {Name: "rdp_clipboard", Value: true, Default: true},
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3.12/configoptions/rdp_proxy" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			var result jsonConfigSessionOptions
+			for _, v := range options {
+				result.Options = append(result.Options, v)
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case http.MethodPut:
+			var jsonData jsonConfigSessionOptions
+			if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+				t.Errorf("decoding request: %s", err)
+			}
+			for _, v := range jsonData.Options {
+				if v.Name == "rdp_clipboard" {
+					t.Errorf("option rdp_clipboard isn't managed by the resource")
+				}
+				option := options[v.Name]
+				option.Value = v.Value
+				options[v.Name] = option
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	d := schema.TestResourceDataRaw(t, resourceConfigRDPProxy().Schema, map[string]interface{}{
+		"tls_security_level": "high",
+		"keepalive_interval": 30,
+	})
+	if diags := resourceConfigRDPProxyCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("tls_security_level").(string); got != "high" {
+		t.Errorf("expected tls_security_level high, got %q", got)
+	}
+	if got := d.Get("keepalive_interval").(int); got != 30 {
+		t.Errorf("expected keepalive_interval 30, got %d", got)
+	}
+	if got := d.Get("keepalive_timeout").(int); got != 300 {
+		t.Errorf("expected keepalive_timeout from the api, got %d", got)
+	}
+	if got := d.Get("cipher_suites").(string); got != "HIGH:!aNULL" {
+		t.Errorf("expected cipher_suites from the api, got %q", got)
+	}
+	listSecurityProtocols := d.Get("security_protocols").(*schema.Set).List()
+	if len(listSecurityProtocols) != 2 || !slices.Contains(listSecurityProtocols, interface{}("nla")) {
+		t.Errorf("expected security_protocols tls and nla, got %v", listSecurityProtocols)
+	}
+	if diags := resourceConfigRDPProxyDelete(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if options["tls_security_level"].Value != "medium" || options["keepalive_interval"].Value != float64(60) {
+		t.Errorf("expected the default values to be restored, got %v", options)
+	}
+}
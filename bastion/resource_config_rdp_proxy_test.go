@@ -0,0 +1,59 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigRDPProxy_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_rdp_proxy.testacc_ConfigRDPProxy"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigRDPProxyCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "tls_security_level", "high"),
+					resource.TestCheckResourceAttrSet(resourceName, "keepalive_timeout"),
+				),
+			},
+			{
+				Config: testAccResourceConfigRDPProxyUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "security_protocols.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "keepalive_interval", "30"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "rdp_proxy",
+				ImportStateVerify: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigRDPProxyCreate() string {
+	return `
+resource "wallix-bastion_config_rdp_proxy" "testacc_ConfigRDPProxy" {
+  tls_security_level = "high"
+}
+`
+}
+
+func testAccResourceConfigRDPProxyUpdate() string {
+	return `
+resource "wallix-bastion_config_rdp_proxy" "testacc_ConfigRDPProxy" {
+  tls_security_level = "high"
+  security_protocols = ["tls", "nla"]
+  cipher_suites      = "HIGH:!aNULL:!MD5"
+  keepalive_interval = 30
+  keepalive_timeout  = 120
+}
+`
+}
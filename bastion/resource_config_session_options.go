@@ -0,0 +1,308 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigSessionOptions struct {
+	Options []jsonConfigSessionOption `json:"options"`
+}
+
+type jsonConfigSessionOption struct {
+	Name          string        `json:"name"`
+	Value         interface{}   `json:"value"`
+	Default       interface{}   `json:"default,omitempty"`
+	AllowedValues []interface{} `json:"allowed_values,omitempty"`
+}
+
+func resourceConfigSessionOptions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigSessionOptionsCreate,
+		ReadContext:   resourceConfigSessionOptionsRead,
+		UpdateContext: resourceConfigSessionOptionsUpdate,
+		DeleteContext: resourceConfigSessionOptionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigSessionOptionsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"section": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"options": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceConfigSessionOptionsVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_session_options not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceConfigSessionOptionsCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSessionOptionsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	section := d.Get("section").(string)
+	if err := updateConfigSessionOptions(ctx, section, d.Get("options").(map[string]interface{}), false, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(section)
+
+	return resourceConfigSessionOptionsRead(ctx, d, m)
+}
+
+func resourceConfigSessionOptionsRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSessionOptionsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSessionOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigSessionOptions(d, cfg)
+
+	return nil
+}
+
+func resourceConfigSessionOptionsUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigSessionOptionsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("options") {
+		oldOptions, newOptions := d.GetChange("options")
+		// Restore the default value of the options which aren't managed anymore
+		removedOptions := make(map[string]interface{})
+		for k, v := range oldOptions.(map[string]interface{}) {
+			if _, ok := newOptions.(map[string]interface{})[k]; !ok {
+				removedOptions[k] = v
+			}
+		}
+		if len(removedOptions) > 0 {
+			if err := updateConfigSessionOptions(ctx, d.Id(), removedOptions, true, m); err != nil {
+				return diagFromAPIError(err)
+			}
+		}
+		if err := updateConfigSessionOptions(ctx, d.Id(), newOptions.(map[string]interface{}), false, m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	d.Partial(false)
+
+	return resourceConfigSessionOptionsRead(ctx, d, m)
+}
+
+func resourceConfigSessionOptionsDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSessionOptionsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The options can't be removed, so restore the default value of each managed option
+	if err := updateConfigSessionOptions(ctx, d.Id(), d.Get("options").(map[string]interface{}), true, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigSessionOptionsImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceConfigSessionOptionsVersionCheck(c); err != nil {
+		return nil, err
+	}
+	if _, err := readConfigSessionOptions(ctx, d.Id(), m); err != nil {
+		return nil, fmt.Errorf("don't find section with id %s (id must be <section>): %w", d.Id(), err)
+	}
+	if tfErr := d.Set("section", d.Id()); tfErr != nil {
+		panic(tfErr)
+	}
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+func readConfigSessionOptions(
+	ctx context.Context, section string, m interface{},
+) (
+	jsonConfigSessionOptions, error,
+) {
+	c := m.(*Client)
+	var result jsonConfigSessionOptions
+	body, code, err := c.newRequest(ctx, "/configoptions/"+section, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+// updateConfigSessionOptions sends only the given options to the section,
+// with their default value reported by the API if restoreDefault is true.
+func updateConfigSessionOptions(
+	ctx context.Context, section string, options map[string]interface{}, restoreDefault bool, m interface{},
+) error {
+	current, err := readConfigSessionOptions(ctx, section, m)
+	if err != nil {
+		return err
+	}
+	jsonData, err := prepareConfigSessionOptionsJSON(current, options, restoreDefault)
+	if err != nil {
+		return err
+	}
+
+	return putConfigSessionOptions(ctx, section, jsonData, m)
+}
+
+func putConfigSessionOptions(
+	ctx context.Context, section string, jsonData jsonConfigSessionOptions, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/configoptions/"+section, http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+// prepareConfigSessionOptionsJSON converts the string values of the options
+// to the type of the current value of each option on the bastion.
+func prepareConfigSessionOptionsJSON(
+	current jsonConfigSessionOptions, options map[string]interface{}, restoreDefault bool,
+) (
+	jsonConfigSessionOptions, error,
+) {
+	jsonData := jsonConfigSessionOptions{
+		Options: make([]jsonConfigSessionOption, 0, len(options)),
+	}
+	for _, v := range current.Options {
+		value, ok := options[v.Name]
+		if !ok {
+			continue
+		}
+		if restoreDefault {
+			jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{Name: v.Name, Value: v.Default})
+
+			continue
+		}
+		typedValue, err := convertConfigSessionOptionValue(value.(string), v.Value)
+		if err != nil {
+			return jsonData, fmt.Errorf("options.%s: %w", v.Name, err)
+		}
+		jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{Name: v.Name, Value: typedValue})
+	}
+	if len(jsonData.Options) != len(options) {
+		for k := range options {
+			if !slices.ContainsFunc(current.Options, func(v jsonConfigSessionOption) bool {
+				return v.Name == k
+			}) {
+				return jsonData, fmt.Errorf("option %s doesn't exist in the section", k)
+			}
+		}
+	}
+
+	return jsonData, nil
+}
+
+func convertConfigSessionOptionValue(value string, currentValue interface{}) (interface{}, error) {
+	switch currentValue.(type) {
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("must be a boolean, got: %s", value)
+		}
+
+		return b, nil
+	case float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a number, got: %s", value)
+		}
+
+		return f, nil
+	default:
+		return value, nil
+	}
+}
+
+// configSessionOptionValueString returns the value of an option as set in the options attribute.
+func configSessionOptionValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+
+		return string(b)
+	}
+}
+
+// fillConfigSessionOptions sets only the options already managed by the resource,
+// so the other options of the section don't appear in the plan.
+func fillConfigSessionOptions(d *schema.ResourceData, jsonData jsonConfigSessionOptions) {
+	managed := d.Get("options").(map[string]interface{})
+	options := make(map[string]interface{}, len(managed))
+	for _, v := range jsonData.Options {
+		if _, ok := managed[v.Name]; ok {
+			options[v.Name] = configSessionOptionValueString(v.Value)
+		}
+	}
+	if tfErr := d.Set("section", d.Id()); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("options", options); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,58 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigSessionOptions_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigSessionOptionsCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_config_session_options.testacc_ConfigSessionOptions",
+						"options.%", "1"),
+				),
+			},
+			{
+				Config: testAccResourceConfigSessionOptionsUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_config_session_options.testacc_ConfigSessionOptions",
+						"options.%", "1"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_config_session_options.testacc_ConfigSessionOptions",
+						"options.one_time_password_ttl", "120"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigSessionOptionsCreate() string {
+	return `
+resource "wallix-bastion_config_session_options" "testacc_ConfigSessionOptions" {
+  section = "wabengine"
+  options = {
+    one_time_password_ttl = "60"
+  }
+}
+`
+}
+
+func testAccResourceConfigSessionOptionsUpdate() string {
+	return `
+resource "wallix-bastion_config_session_options" "testacc_ConfigSessionOptions" {
+  section = "wabengine"
+  options = {
+    one_time_password_ttl = "120"
+  }
+}
+`
+}
@@ -0,0 +1,230 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigSMTP struct {
+	SMTPServer   string `json:"smtp_server"`
+	SMTPPort     int    `json:"smtp_port"`
+	SenderEmail  string `json:"sender_email"`
+	UseTLS       bool   `json:"use_tls"`
+	StartTLS     bool   `json:"starttls"`
+	AuthUser     string `json:"auth_user"`
+	AuthPassword string `json:"auth_password,omitempty"`
+}
+
+func resourceConfigSMTP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigSMTPCreate,
+		ReadContext:   resourceConfigSMTPRead,
+		UpdateContext: resourceConfigSMTPUpdate,
+		DeleteContext: resourceConfigSMTPDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigSMTPImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"smtp_server": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"sender_email": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"smtp_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      25,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"use_tls": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"starttls"},
+			},
+			"starttls": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"use_tls"},
+			},
+			"auth_user": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"auth_password"},
+			},
+			"auth_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"auth_user"},
+			},
+		},
+	}
+}
+
+func resourceConfigSMTPVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_smtp not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigSMTPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSMTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSMTP(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("smtpConfig")
+
+	return resourceConfigSMTPRead(ctx, d, m)
+}
+
+func resourceConfigSMTPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSMTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSMTPOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	// If no server configured, mark the resource as deleted
+	if cfg.SMTPServer == "" {
+		d.SetId("")
+
+		return nil
+	}
+	fillConfigSMTP(d, cfg)
+
+	return nil
+}
+
+func resourceConfigSMTPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigSMTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSMTP(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigSMTPRead(ctx, d, m)
+}
+
+func resourceConfigSMTPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSMTPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Reset the configuration to the defaults
+	if err := deleteConfigSMTP(ctx, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigSMTPImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "smtpConfig" ID
+	d.SetId("smtpConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigSMTPOptions(ctx context.Context, m interface{}) (jsonConfigSMTP, error) {
+	c := m.(*Client)
+	var result jsonConfigSMTP
+	body, code, err := c.newRequest(ctx, "/config/smtp", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigSMTP(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareConfigSMTPJSON(d)
+	body, code, err := c.newRequest(ctx, "/config/smtp", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func deleteConfigSMTP(ctx context.Context, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/smtp", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigSMTPJSON(d *schema.ResourceData) jsonConfigSMTP {
+	return jsonConfigSMTP{
+		SMTPServer:   d.Get("smtp_server").(string),
+		SMTPPort:     d.Get("smtp_port").(int),
+		SenderEmail:  d.Get("sender_email").(string),
+		UseTLS:       d.Get("use_tls").(bool),
+		StartTLS:     d.Get("starttls").(bool),
+		AuthUser:     d.Get("auth_user").(string),
+		AuthPassword: d.Get("auth_password").(string),
+	}
+}
+
+// fillConfigSMTP sets all the attributes except auth_password which is never returned by the API.
+func fillConfigSMTP(d *schema.ResourceData, jsonData jsonConfigSMTP) {
+	if tfErr := d.Set("smtp_server", jsonData.SMTPServer); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("smtp_port", jsonData.SMTPPort); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("sender_email", jsonData.SenderEmail); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("use_tls", jsonData.UseTLS); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("starttls", jsonData.StartTLS); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("auth_user", jsonData.AuthUser); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,59 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigSMTP_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_smtp.testacc_ConfigSMTP"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigSMTPCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "smtp_port", "25"),
+				),
+			},
+			{
+				Config: testAccResourceConfigSMTPUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "smtp_port", "587"),
+					resource.TestCheckResourceAttr(resourceName, "starttls", "true"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "smtp_config",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigSMTPCreate() string {
+	return `
+resource "wallix-bastion_config_smtp" "testacc_ConfigSMTP" {
+  smtp_server  = "smtp.testacc.local"
+  sender_email = "bastion@testacc.local"
+}
+`
+}
+
+func testAccResourceConfigSMTPUpdate() string {
+	return `
+resource "wallix-bastion_config_smtp" "testacc_ConfigSMTP" {
+  smtp_server   = "smtp.testacc.local"
+  smtp_port     = 587
+  sender_email  = "bastion@testacc.local"
+  starttls      = true
+  auth_user     = "testacc"
+  auth_password = "testacc_P@ssw0rd"
+}
+`
+}
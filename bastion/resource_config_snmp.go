@@ -0,0 +1,251 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigSNMP struct {
+	Enable          bool     `json:"enable"`
+	Community       string   `json:"community,omitempty"`
+	User            string   `json:"user"`
+	AuthProtocol    string   `json:"auth_protocol"`
+	AuthPassphrase  string   `json:"auth_passphrase,omitempty"`
+	PrivProtocol    string   `json:"priv_protocol"`
+	PrivPassphrase  string   `json:"priv_passphrase,omitempty"`
+	SystemLocation  string   `json:"system_location"`
+	SystemContact   string   `json:"system_contact"`
+	AllowedManagers []string `json:"allowed_managers"`
+}
+
+func resourceConfigSNMP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigSNMPCreate,
+		ReadContext:   resourceConfigSNMPRead,
+		UpdateContext: resourceConfigSNMPUpdate,
+		DeleteContext: resourceConfigSNMPDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigSNMPImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"community": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"user"},
+			},
+			"user": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"community"},
+				RequiredWith:  []string{"auth_protocol", "auth_passphrase"},
+			},
+			"auth_protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"user"},
+				ValidateFunc: validation.StringInSlice([]string{"MD5", "SHA", "SHA-224", "SHA-256", "SHA-384", "SHA-512"}, false),
+			},
+			"auth_passphrase": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"user"},
+				ValidateFunc: validation.StringLenBetween(8, 256),
+			},
+			"priv_protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"user", "priv_passphrase"},
+				ValidateFunc: validation.StringInSlice([]string{"DES", "AES", "AES-192", "AES-256"}, false),
+			},
+			"priv_passphrase": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"priv_protocol"},
+				ValidateFunc: validation.StringLenBetween(8, 256),
+			},
+			"allowed_managers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				},
+			},
+			"system_location": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"system_contact": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceConfigSNMPVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_snmp not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigSNMPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSNMPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSNMP(ctx, prepareConfigSNMPJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("snmpConfig")
+
+	return resourceConfigSNMPRead(ctx, d, m)
+}
+
+func resourceConfigSNMPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSNMPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSNMPOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigSNMP(d, cfg)
+
+	return nil
+}
+
+func resourceConfigSNMPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigSNMPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSNMP(ctx, prepareConfigSNMPJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigSNMPRead(ctx, d, m)
+}
+
+func resourceConfigSNMPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSNMPVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The SNMP configuration can't be removed, so disable the agent
+	cfg, err := readConfigSNMPOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg.Enable = false
+	if err := updateConfigSNMP(ctx, cfg, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigSNMPImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "snmpConfig" ID
+	d.SetId("snmpConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigSNMPOptions(ctx context.Context, m interface{}) (jsonConfigSNMP, error) {
+	c := m.(*Client)
+	var result jsonConfigSNMP
+	body, code, err := c.newRequest(ctx, "/config/snmp", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigSNMP(ctx context.Context, jsonData jsonConfigSNMP, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/snmp", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigSNMPJSON(d *schema.ResourceData) jsonConfigSNMP {
+	jsonData := jsonConfigSNMP{
+		Enable:         d.Get("enable").(bool),
+		Community:      d.Get("community").(string),
+		User:           d.Get("user").(string),
+		AuthProtocol:   d.Get("auth_protocol").(string),
+		AuthPassphrase: d.Get("auth_passphrase").(string),
+		PrivProtocol:   d.Get("priv_protocol").(string),
+		PrivPassphrase: d.Get("priv_passphrase").(string),
+		SystemLocation: d.Get("system_location").(string),
+		SystemContact:  d.Get("system_contact").(string),
+	}
+	listAllowedManagers := d.Get("allowed_managers").(*schema.Set).List()
+	jsonData.AllowedManagers = make([]string, len(listAllowedManagers))
+	for i, v := range listAllowedManagers {
+		jsonData.AllowedManagers[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+// fillConfigSNMP sets all the attributes except the community and passphrases which are never returned by the API.
+func fillConfigSNMP(d *schema.ResourceData, jsonData jsonConfigSNMP) {
+	if tfErr := d.Set("enable", jsonData.Enable); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("user", jsonData.User); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("auth_protocol", jsonData.AuthProtocol); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("priv_protocol", jsonData.PrivProtocol); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("allowed_managers", jsonData.AllowedManagers); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("system_location", jsonData.SystemLocation); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("system_contact", jsonData.SystemContact); tfErr != nil {
+		panic(tfErr)
+	}
+}
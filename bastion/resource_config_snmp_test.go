@@ -0,0 +1,63 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigSNMP_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_snmp.testacc_ConfigSNMP"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigSNMPCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "allowed_managers.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceConfigSNMPUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "allowed_managers.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "priv_protocol", "AES"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "snmp_config",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigSNMPCreate() string {
+	return `
+resource "wallix-bastion_config_snmp" "testacc_ConfigSNMP" {
+  user             = "testacc"
+  auth_protocol    = "SHA"
+  auth_passphrase  = "testacc_auth_pass"
+  allowed_managers = ["192.0.2.10"]
+}
+`
+}
+
+func testAccResourceConfigSNMPUpdate() string {
+	return `
+resource "wallix-bastion_config_snmp" "testacc_ConfigSNMP" {
+  user             = "testacc"
+  auth_protocol    = "SHA"
+  auth_passphrase  = "testacc_auth_pass"
+  priv_protocol    = "AES"
+  priv_passphrase  = "testacc_priv_pass"
+  allowed_managers = ["192.0.2.10", "198.51.100.0/24"]
+  system_location  = "testacc datacenter"
+  system_contact   = "testacc@testacc.local"
+}
+`
+}
@@ -0,0 +1,281 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/mod/semver"
+)
+
+type jsonConfigSSH struct {
+	Ciphers              []string `json:"ciphers,omitempty"`
+	Macs                 []string `json:"macs,omitempty"`
+	KexAlgorithms        []string `json:"kex_algorithms,omitempty"`
+	AllowedClientCiphers []string `json:"allowed_client_ciphers,omitempty"`
+}
+
+func resourceConfigSSH() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigSSHCreate,
+		ReadContext:   resourceConfigSSHRead,
+		UpdateContext: resourceConfigSSHUpdate,
+		DeleteContext: resourceConfigSSHDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigSSHImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"ciphers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"macs": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"kex_algorithms": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"allowed_client_ciphers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceConfigSSHVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_ssh not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigSSHCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSSHVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSSH(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("sshConfig")
+
+	return resourceConfigSSHRead(ctx, d, m)
+}
+
+func resourceConfigSSHRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSSHVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSSHOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigSSH(d, cfg)
+
+	return nil
+}
+
+func resourceConfigSSHUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigSSHVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSSH(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigSSHRead(ctx, d, m)
+}
+
+func resourceConfigSSHDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSSHVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Reset the configuration to the defaults
+	if err := deleteConfigSSH(ctx, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigSSHImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "sshConfig" ID
+	d.SetId("sshConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigSSHOptions(ctx context.Context, m interface{}) (jsonConfigSSH, error) {
+	c := m.(*Client)
+	var result jsonConfigSSH
+	body, code, err := c.newRequest(ctx, "/config/ssh", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigSSH(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData, err := prepareConfigSSHJSON(d, c.bastionAPIVersion)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/config/ssh", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func deleteConfigSSH(ctx context.Context, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/ssh", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigSSHJSON(d *schema.ResourceData, apiVersion string) (jsonConfigSSH, error) {
+	var jsonData jsonConfigSSH
+	var err error
+	if jsonData.Ciphers, err = prepareConfigSSHAlgorithms(
+		d, "ciphers", validConfigSSHCiphers(apiVersion)); err != nil {
+		return jsonData, err
+	}
+	if jsonData.Macs, err = prepareConfigSSHAlgorithms(
+		d, "macs", validConfigSSHMacs(apiVersion)); err != nil {
+		return jsonData, err
+	}
+	if jsonData.KexAlgorithms, err = prepareConfigSSHAlgorithms(
+		d, "kex_algorithms", validConfigSSHKexAlgorithms(apiVersion)); err != nil {
+		return jsonData, err
+	}
+	if jsonData.AllowedClientCiphers, err = prepareConfigSSHAlgorithms(
+		d, "allowed_client_ciphers", validConfigSSHCiphers(apiVersion)); err != nil {
+		return jsonData, err
+	}
+
+	return jsonData, nil
+}
+
+// prepareConfigSSHAlgorithms returns the ordered list of an attribute
+// after checking each element is a known algorithm for the api version.
+// A nil list is returned when the attribute is unset to keep the current value on the bastion.
+func prepareConfigSSHAlgorithms(d *schema.ResourceData, key string, valid []string) ([]string, error) {
+	list := d.Get(key).([]interface{})
+	if len(list) == 0 {
+		return nil, nil
+	}
+	result := make([]string, len(list))
+	for i, v := range list {
+		if !slices.Contains(valid, v.(string)) {
+			return nil, fmt.Errorf("%s must be in %v", key, valid)
+		}
+		result[i] = v.(string)
+	}
+
+	return result, nil
+}
+
+func validConfigSSHCiphers(apiVersion string) []string {
+	ciphers := []string{
+		"aes128-ctr",
+		"aes192-ctr",
+		"aes256-ctr",
+		"aes128-gcm@openssh.com",
+		"aes256-gcm@openssh.com",
+	}
+	if semver.Compare(apiVersion, VersionWallixAPI312) >= 0 {
+		ciphers = append(ciphers, "chacha20-poly1305@openssh.com")
+	}
+
+	return ciphers
+}
+
+func validConfigSSHMacs(apiVersion string) []string {
+	macs := []string{
+		"hmac-sha2-256",
+		"hmac-sha2-512",
+		"hmac-sha2-256-etm@openssh.com",
+		"hmac-sha2-512-etm@openssh.com",
+	}
+	if semver.Compare(apiVersion, VersionWallixAPI312) >= 0 {
+		macs = append(macs, "umac-128-etm@openssh.com")
+	}
+
+	return macs
+}
+
+func validConfigSSHKexAlgorithms(apiVersion string) []string {
+	kexAlgorithms := []string{
+		"curve25519-sha256",
+		"curve25519-sha256@libssh.org",
+		"ecdh-sha2-nistp256",
+		"ecdh-sha2-nistp384",
+		"ecdh-sha2-nistp521",
+		"diffie-hellman-group14-sha256",
+		"diffie-hellman-group16-sha512",
+		"diffie-hellman-group18-sha512",
+		"diffie-hellman-group-exchange-sha256",
+	}
+	if semver.Compare(apiVersion, VersionWallixAPI312) >= 0 {
+		kexAlgorithms = append(kexAlgorithms, "sntrup761x25519-sha512@openssh.com")
+	}
+
+	return kexAlgorithms
+}
+
+func fillConfigSSH(d *schema.ResourceData, jsonData jsonConfigSSH) {
+	if tfErr := d.Set("ciphers", jsonData.Ciphers); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("macs", jsonData.Macs); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("kex_algorithms", jsonData.KexAlgorithms); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("allowed_client_ciphers", jsonData.AllowedClientCiphers); tfErr != nil {
+		panic(tfErr)
+	}
+}
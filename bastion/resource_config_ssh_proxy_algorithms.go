@@ -0,0 +1,242 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// configSSHProxySection is the configoptions section of the SSH proxy.
+const configSSHProxySection = "ssh_proxy"
+
+func resourceConfigSSHProxyAlgorithms() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigSSHProxyAlgorithmsCreate,
+		ReadContext:   resourceConfigSSHProxyAlgorithmsRead,
+		UpdateContext: resourceConfigSSHProxyAlgorithmsUpdate,
+		DeleteContext: resourceConfigSSHProxyAlgorithmsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigSSHProxyAlgorithmsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"kex_algorithms": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"ciphers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"macs": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"hostkey_algorithms": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+		},
+	}
+}
+
+func resourceConfigSSHProxyAlgorithmsVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_ssh_proxy_algorithms not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceConfigSSHProxyAlgorithmsCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSSHProxyAlgorithmsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSSHProxyAlgorithms(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("sshProxyAlgorithmsConfig")
+
+	return resourceConfigSSHProxyAlgorithmsRead(ctx, d, m)
+}
+
+func resourceConfigSSHProxyAlgorithmsRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSSHProxyAlgorithmsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSessionOptions(ctx, configSSHProxySection, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigSSHProxyAlgorithms(d, cfg)
+
+	return nil
+}
+
+func resourceConfigSSHProxyAlgorithmsUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigSSHProxyAlgorithmsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigSSHProxyAlgorithms(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigSSHProxyAlgorithmsRead(ctx, d, m)
+}
+
+func resourceConfigSSHProxyAlgorithmsDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSSHProxyAlgorithmsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The options can't be removed, so restore the default value of each list
+	options := make(map[string]interface{})
+	for _, v := range configSSHProxyAlgorithmsOptionNames() {
+		options[v] = ""
+	}
+	if err := updateConfigSessionOptions(ctx, configSSHProxySection, options, true, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigSSHProxyAlgorithmsImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "sshProxyAlgorithmsConfig" ID
+	d.SetId("sshProxyAlgorithmsConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// configSSHProxyAlgorithmsOptionNames returns the name of the options in the section for each attribute.
+func configSSHProxyAlgorithmsOptionNames() map[string]string {
+	return map[string]string{
+		"kex_algorithms":     "kex_algorithms",
+		"ciphers":            "ciphers",
+		"macs":               "macs",
+		"hostkey_algorithms": "host_key_algorithms",
+	}
+}
+
+// updateConfigSSHProxyAlgorithms checks the lists against the algorithms supported by the bastion,
+// so a new algorithm of the appliance doesn't need a new release of the provider.
+func updateConfigSSHProxyAlgorithms(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	current, err := readConfigSessionOptions(ctx, configSSHProxySection, m)
+	if err != nil {
+		return err
+	}
+	jsonData, err := prepareConfigSSHProxyAlgorithmsJSON(d, current)
+	if err != nil {
+		return err
+	}
+	if len(jsonData.Options) == 0 {
+		return nil
+	}
+
+	return putConfigSessionOptions(ctx, configSSHProxySection, jsonData, m)
+}
+
+// prepareConfigSSHProxyAlgorithmsJSON sends only the lists set in the configuration, in the same order.
+func prepareConfigSSHProxyAlgorithmsJSON(
+	d *schema.ResourceData, current jsonConfigSessionOptions,
+) (
+	jsonConfigSessionOptions, error,
+) {
+	optionNames := configSSHProxyAlgorithmsOptionNames()
+	jsonData := jsonConfigSessionOptions{
+		Options: make([]jsonConfigSessionOption, 0, len(optionNames)),
+	}
+	for _, attr := range []string{"kex_algorithms", "ciphers", "macs", "hostkey_algorithms"} {
+		list := d.Get(attr).([]interface{})
+		if len(list) == 0 {
+			continue
+		}
+		idx := slices.IndexFunc(current.Options, func(v jsonConfigSessionOption) bool {
+			return v.Name == optionNames[attr]
+		})
+		if idx < 0 {
+			return jsonData, fmt.Errorf("option %s doesn't exist in the section %s", optionNames[attr], configSSHProxySection)
+		}
+		supported := make([]string, 0, len(current.Options[idx].AllowedValues))
+		for _, v := range current.Options[idx].AllowedValues {
+			if s, ok := v.(string); ok {
+				supported = append(supported, s)
+			}
+		}
+		algorithms := make([]string, len(list))
+		for i, v := range list {
+			if len(supported) > 0 && !slices.Contains(supported, v.(string)) {
+				return jsonData, fmt.Errorf("%s: %s isn't supported by the bastion, must be in %s",
+					attr, v, strings.Join(supported, ", "))
+			}
+			if slices.Contains(algorithms[:i], v.(string)) {
+				return jsonData, fmt.Errorf("%s: %s is set more than once", attr, v)
+			}
+			algorithms[i] = v.(string)
+		}
+		jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{
+			Name:  optionNames[attr],
+			Value: algorithms,
+		})
+	}
+
+	return jsonData, nil
+}
+
+func fillConfigSSHProxyAlgorithms(d *schema.ResourceData, jsonData jsonConfigSessionOptions) {
+	values := make(map[string]interface{}, len(jsonData.Options))
+	for _, v := range jsonData.Options {
+		values[v.Name] = v.Value
+	}
+	for attr, optionName := range configSSHProxyAlgorithmsOptionNames() {
+		list, _ := values[optionName].([]interface{})
+		algorithms := make([]string, 0, len(list))
+		for _, v := range list {
+			if s, ok := v.(string); ok {
+				algorithms = append(algorithms, s)
+			}
+		}
+		if tfErr := d.Set(attr, algorithms); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package bastion
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPrepareConfigSSHProxyAlgorithmsJSON(t *testing.T) {
+	current := jsonConfigSessionOptions{
+		Options: []jsonConfigSessionOption{
+			{
+				Name:          "kex_algorithms",
+				Value:         []interface{}{"curve25519-sha256"},
+				AllowedValues: []interface{}{"curve25519-sha256", "diffie-hellman-group16-sha512"},
+			},
+			{
+				Name:          "ciphers",
+				Value:         []interface{}{"aes256-ctr"},
+				AllowedValues: []interface{}{"aes256-ctr", "aes256-gcm@openssh.com", "chacha20-poly1305@openssh.com"},
+			},
+			{Name: "macs", Value: []interface{}{"hmac-sha2-512"}},
+			{Name: "host_key_algorithms", Value: []interface{}{"ssh-ed25519"}},
+		},
+	}
+	tests := map[string]struct {
+		config   map[string]interface{}
+		expected []jsonConfigSessionOption
+		errMatch string
+	}{
+		"order is kept": {
+			config: map[string]interface{}{
+				"ciphers": []interface{}{"chacha20-poly1305@openssh.com", "aes256-gcm@openssh.com"},
+			},
+			expected: []jsonConfigSessionOption{
+				{Name: "ciphers", Value: []string{"chacha20-poly1305@openssh.com", "aes256-gcm@openssh.com"}},
+			},
+		},
+		"without allowed values": {
+			config: map[string]interface{}{
+				"hostkey_algorithms": []interface{}{"rsa-sha2-512", "ssh-ed25519"},
+			},
+			expected: []jsonConfigSessionOption{
+				{Name: "host_key_algorithms", Value: []string{"rsa-sha2-512", "ssh-ed25519"}},
+			},
+		},
+		"not supported": {
+			config: map[string]interface{}{
+				"kex_algorithms": []interface{}{"diffie-hellman-group1-sha1"},
+			},
+			errMatch: "kex_algorithms: diffie-hellman-group1-sha1 isn't supported by the bastion, " +
+				"must be in curve25519-sha256, diffie-hellman-group16-sha512",
+		},
+		"duplicate": {
+			config: map[string]interface{}{
+				"ciphers": []interface{}{"aes256-ctr", "aes256-ctr"},
+			},
+			errMatch: "ciphers: aes256-ctr is set more than once",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceConfigSSHProxyAlgorithms().Schema, tt.config)
+			jsonData, err := prepareConfigSSHProxyAlgorithmsJSON(d, current)
+			if tt.errMatch != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", tt.errMatch, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(jsonData.Options, tt.expected) {
+				t.Errorf("expected options %v, got %v", tt.expected, jsonData.Options)
+			}
+		})
+	}
+}
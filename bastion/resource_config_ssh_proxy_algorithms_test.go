@@ -0,0 +1,69 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigSSHProxyAlgorithms_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_ssh_proxy_algorithms.testacc_ConfigSSHProxyAlgorithms"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigSSHProxyAlgorithmsNotSupported(),
+				ExpectError: regexp.MustCompile(`isn't supported by the bastion`),
+			},
+			{
+				Config: testAccResourceConfigSSHProxyAlgorithmsCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "ciphers.0", "aes256-gcm@openssh.com"),
+				),
+			},
+			{
+				Config: testAccResourceConfigSSHProxyAlgorithmsUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ciphers.0", "aes256-ctr"),
+					resource.TestCheckResourceAttr(resourceName, "ciphers.1", "aes256-gcm@openssh.com"),
+					resource.TestCheckResourceAttr(resourceName, "kex_algorithms.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "ssh_proxy_algorithms",
+				ImportStateVerify: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigSSHProxyAlgorithmsNotSupported() string {
+	return `
+resource "wallix-bastion_config_ssh_proxy_algorithms" "testacc_ConfigSSHProxyAlgorithms" {
+  kex_algorithms = ["diffie-hellman-group1-sha1"]
+}
+`
+}
+
+func testAccResourceConfigSSHProxyAlgorithmsCreate() string {
+	return `
+resource "wallix-bastion_config_ssh_proxy_algorithms" "testacc_ConfigSSHProxyAlgorithms" {
+  ciphers = ["aes256-gcm@openssh.com", "aes256-ctr"]
+}
+`
+}
+
+func testAccResourceConfigSSHProxyAlgorithmsUpdate() string {
+	return `
+resource "wallix-bastion_config_ssh_proxy_algorithms" "testacc_ConfigSSHProxyAlgorithms" {
+  ciphers        = ["aes256-ctr", "aes256-gcm@openssh.com"]
+  kex_algorithms = ["curve25519-sha256"]
+}
+`
+}
@@ -0,0 +1,70 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigSSH_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_ssh.testacc_ConfigSSH"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigSSHInvalid(),
+				ExpectError: regexp.MustCompile(`ciphers must be in`),
+			},
+			{
+				Config: testAccResourceConfigSSHCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "ciphers.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ciphers.0", "aes256-gcm@openssh.com"),
+				),
+			},
+			{
+				Config: testAccResourceConfigSSHUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "macs.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "kex_algorithms.#", "1"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "ssh_config",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigSSHInvalid() string {
+	return `
+resource "wallix-bastion_config_ssh" "testacc_ConfigSSH" {
+  ciphers = ["3des-cbc"]
+}
+`
+}
+
+func testAccResourceConfigSSHCreate() string {
+	return `
+resource "wallix-bastion_config_ssh" "testacc_ConfigSSH" {
+  ciphers = ["aes256-gcm@openssh.com", "aes256-ctr"]
+}
+`
+}
+
+func testAccResourceConfigSSHUpdate() string {
+	return `
+resource "wallix-bastion_config_ssh" "testacc_ConfigSSH" {
+  ciphers                = ["aes256-gcm@openssh.com", "aes256-ctr"]
+  macs                   = ["hmac-sha2-512-etm@openssh.com", "hmac-sha2-256-etm@openssh.com"]
+  kex_algorithms         = ["curve25519-sha256"]
+  allowed_client_ciphers = ["aes256-gcm@openssh.com", "aes128-gcm@openssh.com"]
+}
+`
+}
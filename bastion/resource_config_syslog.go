@@ -0,0 +1,376 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// configSyslogMutex serializes the read-modify-write of the syslog configuration
+// by wallix-bastion_config_syslog and wallix-bastion_config_syslog_destination in the same apply.
+var configSyslogMutex sync.Mutex //nolint:gochecknoglobals
+
+type jsonConfigSyslog struct {
+	Enable  bool                     `json:"enable"`
+	Format  string                   `json:"format"`
+	Servers []jsonConfigSyslogServer `json:"servers"`
+}
+
+type jsonConfigSyslogServer struct {
+	Host          string `json:"host"`
+	Port          int    `json:"port"`
+	Protocol      string `json:"protocol"`
+	Facility      string `json:"facility"`
+	TLS           bool   `json:"tls"`
+	CACertificate string `json:"ca_certificate,omitempty"`
+}
+
+func resourceConfigSyslog() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigSyslogCreate,
+		ReadContext:   resourceConfigSyslogRead,
+		UpdateContext: resourceConfigSyslogUpdate,
+		DeleteContext: resourceConfigSyslogDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigSyslogImport,
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateConfigSyslogServersTLS,
+		},
+		Schema: map[string]*schema.Schema{
+			"server": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: configSyslogServerSchema(),
+				},
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "rfc5424",
+				ValidateFunc: validation.StringInSlice([]string{"rfc3164", "rfc5424"}, false),
+			},
+			"enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+// configSyslogServerSchema returns the arguments of a syslog server,
+// shared by the server blocks of wallix-bastion_config_syslog and wallix-bastion_config_syslog_destination.
+func configSyslogServerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateHostnameOrIP,
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      514,
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"protocol": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "udp",
+			ValidateFunc: validation.StringInSlice([]string{"udp", "tcp"}, false),
+		},
+		"facility": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "local0",
+			ValidateFunc: validation.StringInSlice([]string{
+				"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+				"uucp", "cron", "authpriv", "ftp",
+				"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+			}, false),
+		},
+		"tls": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"ca_certificate": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+// validateConfigSyslogServersTLS rejects at plan time a server with tls without protocol tcp or ca_certificate,
+// the values unknown until the apply are checked by the Bastion.
+func validateConfigSyslogServersTLS(
+	_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+	servers := req.RawConfig.GetAttr("server")
+	if !servers.IsKnown() || servers.IsNull() {
+		return
+	}
+	for i, server := range servers.AsValueSlice() {
+		resp.Diagnostics = append(resp.Diagnostics,
+			configSyslogServerTLSDiagnostics(server, cty.GetAttrPath("server").IndexInt(i))...)
+	}
+}
+
+// configSyslogServerTLSDiagnostics returns the errors of a server with tls without protocol tcp or ca_certificate,
+// path is the path of the server in the configuration.
+func configSyslogServerTLSDiagnostics(server cty.Value, path cty.Path) diag.Diagnostics {
+	if !server.IsKnown() || server.IsNull() {
+		return nil
+	}
+	tls := server.GetAttr("tls")
+	if !tls.IsKnown() || tls.IsNull() || tls.False() {
+		return nil
+	}
+	var diags diag.Diagnostics
+	// protocol defaults to udp
+	if protocol := server.GetAttr("protocol"); protocol.IsNull() ||
+		(protocol.IsKnown() && protocol.AsString() != "tcp") {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       `tls requires protocol "tcp"`,
+			AttributePath: path.Copy().GetAttr("protocol"),
+		})
+	}
+	if caCertificate := server.GetAttr("ca_certificate"); caCertificate.IsKnown() && caCertificate.IsNull() {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "ca_certificate must be set with tls",
+			AttributePath: path.Copy().GetAttr("ca_certificate"),
+		})
+	}
+
+	return diags
+}
+
+func resourceConfigSyslogVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_syslog not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigSyslogCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSyslogVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := applyConfigSyslog(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("syslogConfig")
+
+	return resourceConfigSyslogRead(ctx, d, m)
+}
+
+func resourceConfigSyslogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSyslogVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSyslogOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	// only the servers of the resource are compared, the others are managed
+	// by wallix-bastion_config_syslog_destination or outside of Terraform
+	cfg.Servers = configSyslogManagedServers(cfg.Servers, configSyslogServerHosts(d.Get("server").([]interface{})))
+	fillConfigSyslog(d, cfg)
+
+	return nil
+}
+
+func resourceConfigSyslogUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigSyslogVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := applyConfigSyslog(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigSyslogRead(ctx, d, m)
+}
+
+func resourceConfigSyslogDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSyslogVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The syslog configuration can't be removed, so remove the servers of the resource
+	// and stop the forwarding if no server is left
+	configSyslogMutex.Lock()
+	defer configSyslogMutex.Unlock()
+	cfg, err := readConfigSyslogOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg.Servers = configSyslogOtherServers(cfg.Servers, configSyslogServerHosts(d.Get("server").([]interface{})))
+	if len(cfg.Servers) == 0 {
+		cfg.Enable = false
+	}
+	if err := updateConfigSyslog(ctx, cfg, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+// resourceConfigSyslogImport adopts all the servers of the Bastion, the ones managed
+// by wallix-bastion_config_syslog_destination need to be removed from the server blocks.
+func resourceConfigSyslogImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*Client)
+	if err := resourceConfigSyslogVersionCheck(c); err != nil {
+		return nil, err
+	}
+	cfg, err := readConfigSyslogOptions(context.Background(), m)
+	if err != nil {
+		return nil, err
+	}
+	fillConfigSyslog(d, cfg)
+	// Since the resource does not have a unique ID, use the static "syslogConfig" ID
+	d.SetId("syslogConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigSyslogOptions(ctx context.Context, m interface{}) (jsonConfigSyslog, error) {
+	c := m.(*Client)
+	var result jsonConfigSyslog
+	body, code, err := c.newRequest(ctx, "/config/syslog", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigSyslog(ctx context.Context, jsonData jsonConfigSyslog, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/syslog", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+// applyConfigSyslog sends the configuration of the resource, keeping the servers of the Bastion
+// not managed by the resource and removing the ones removed from the configuration.
+func applyConfigSyslog(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	configSyslogMutex.Lock()
+	defer configSyslogMutex.Unlock()
+	cfg, err := readConfigSyslogOptions(ctx, m)
+	if err != nil {
+		return err
+	}
+	oldServers, _ := d.GetChange("server")
+	jsonData := prepareConfigSyslogJSON(d)
+	managed := configSyslogServerHosts(oldServers.([]interface{}))
+	for _, server := range jsonData.Servers {
+		managed = append(managed, server.Host)
+	}
+	jsonData.Servers = append(configSyslogOtherServers(cfg.Servers, managed), jsonData.Servers...)
+
+	return updateConfigSyslog(ctx, jsonData, m)
+}
+
+// configSyslogServerHosts returns the hosts of the servers of a list of server blocks.
+func configSyslogServerHosts(servers []interface{}) []string {
+	hosts := make([]string, 0, len(servers))
+	for _, v := range servers {
+		hosts = append(hosts, v.(map[string]interface{})["host"].(string))
+	}
+
+	return hosts
+}
+
+// configSyslogManagedServers returns the servers with one of the hosts.
+func configSyslogManagedServers(servers []jsonConfigSyslogServer, hosts []string) []jsonConfigSyslogServer {
+	return slices.DeleteFunc(slices.Clone(servers), func(server jsonConfigSyslogServer) bool {
+		return !slices.Contains(hosts, server.Host)
+	})
+}
+
+// configSyslogOtherServers returns the servers without one of the hosts.
+func configSyslogOtherServers(servers []jsonConfigSyslogServer, hosts []string) []jsonConfigSyslogServer {
+	return slices.DeleteFunc(slices.Clone(servers), func(server jsonConfigSyslogServer) bool {
+		return slices.Contains(hosts, server.Host)
+	})
+}
+
+func prepareConfigSyslogJSON(d *schema.ResourceData) jsonConfigSyslog {
+	jsonData := jsonConfigSyslog{
+		Enable: d.Get("enable").(bool),
+		Format: d.Get("format").(string),
+	}
+	listServer := d.Get("server").([]interface{})
+	jsonData.Servers = make([]jsonConfigSyslogServer, len(listServer))
+	for i, v := range listServer {
+		server := v.(map[string]interface{})
+		jsonData.Servers[i] = jsonConfigSyslogServer{
+			Host:          server["host"].(string),
+			Port:          server["port"].(int),
+			Protocol:      server["protocol"].(string),
+			Facility:      server["facility"].(string),
+			TLS:           server["tls"].(bool),
+			CACertificate: server["ca_certificate"].(string),
+		}
+	}
+
+	return jsonData
+}
+
+func fillConfigSyslog(d *schema.ResourceData, jsonData jsonConfigSyslog) {
+	if tfErr := d.Set("enable", jsonData.Enable); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("format", jsonData.Format); tfErr != nil {
+		panic(tfErr)
+	}
+	server := make([]map[string]interface{}, len(jsonData.Servers))
+	for i, v := range jsonData.Servers {
+		server[i] = map[string]interface{}{
+			"host":           v.Host,
+			"port":           v.Port,
+			"protocol":       v.Protocol,
+			"facility":       v.Facility,
+			"tls":            v.TLS,
+			"ca_certificate": v.CACertificate,
+		}
+	}
+	if tfErr := d.Set("server", server); tfErr != nil {
+		panic(tfErr)
+	}
+}
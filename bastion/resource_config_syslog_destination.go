@@ -0,0 +1,220 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceConfigSyslogDestination() *schema.Resource {
+	serverSchema := configSyslogServerSchema()
+	serverSchema["host"].ForceNew = true
+
+	return &schema.Resource{
+		CreateContext: resourceConfigSyslogDestinationCreate,
+		ReadContext:   resourceConfigSyslogDestinationRead,
+		UpdateContext: resourceConfigSyslogDestinationUpdate,
+		DeleteContext: resourceConfigSyslogDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigSyslogDestinationImport,
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateConfigSyslogDestinationTLS,
+		},
+		Schema: serverSchema,
+	}
+}
+
+// validateConfigSyslogDestinationTLS rejects at plan time tls without protocol tcp or ca_certificate,
+// the values unknown until the apply are checked by the Bastion.
+func validateConfigSyslogDestinationTLS(
+	_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	resp.Diagnostics = append(resp.Diagnostics, configSyslogServerTLSDiagnostics(req.RawConfig, cty.Path{})...)
+}
+
+func resourceConfigSyslogDestinationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_syslog_destination not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceConfigSyslogDestinationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSyslogDestinationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	configSyslogMutex.Lock()
+	defer configSyslogMutex.Unlock()
+	cfg, err := readConfigSyslogOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if _, ex := searchConfigSyslogServer(cfg.Servers, d.Get("host").(string)); ex {
+		return diagFromAPIError(fmt.Errorf("server %s already exists", d.Get("host").(string)))
+	}
+	cfg.Servers = append(cfg.Servers, prepareConfigSyslogDestinationJSON(d))
+	if err := updateConfigSyslog(ctx, cfg, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(d.Get("host").(string))
+
+	return readConfigSyslogDestination(ctx, d, m)
+}
+
+func resourceConfigSyslogDestinationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSyslogDestinationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return readConfigSyslogDestination(ctx, d, m)
+}
+
+func resourceConfigSyslogDestinationUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigSyslogDestinationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	configSyslogMutex.Lock()
+	defer configSyslogMutex.Unlock()
+	cfg, err := readConfigSyslogOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	i, ex := searchConfigSyslogServer(cfg.Servers, d.Id())
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("server %s not found", d.Id()))
+	}
+	cfg.Servers[i] = prepareConfigSyslogDestinationJSON(d)
+	if err := updateConfigSyslog(ctx, cfg, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return readConfigSyslogDestination(ctx, d, m)
+}
+
+func resourceConfigSyslogDestinationDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigSyslogDestinationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	configSyslogMutex.Lock()
+	defer configSyslogMutex.Unlock()
+	cfg, err := readConfigSyslogOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	i, ex := searchConfigSyslogServer(cfg.Servers, d.Id())
+	if !ex {
+		return nil
+	}
+	cfg.Servers = slices.Delete(cfg.Servers, i, i+1)
+	if err := updateConfigSyslog(ctx, cfg, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigSyslogDestinationImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	c := m.(*Client)
+	if err := resourceConfigSyslogDestinationVersionCheck(c); err != nil {
+		return nil, err
+	}
+	cfg, err := readConfigSyslogOptions(context.Background(), m)
+	if err != nil {
+		return nil, err
+	}
+	i, ex := searchConfigSyslogServer(cfg.Servers, d.Id())
+	if !ex {
+		return nil, fmt.Errorf("don't find server with id %s (id must be <host>)", d.Id())
+	}
+	fillConfigSyslogDestination(d, cfg.Servers[i])
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+// readConfigSyslogDestination fills the resource with its server in the syslog configuration
+// or removes it from the state when the server no longer exists.
+func readConfigSyslogDestination(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	cfg, err := readConfigSyslogOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	i, ex := searchConfigSyslogServer(cfg.Servers, d.Id())
+	if !ex {
+		d.SetId("")
+
+		return nil
+	}
+	fillConfigSyslogDestination(d, cfg.Servers[i])
+
+	return nil
+}
+
+// searchConfigSyslogServer returns the index of the server with the host in the syslog configuration.
+func searchConfigSyslogServer(servers []jsonConfigSyslogServer, host string) (int, bool) {
+	i := slices.IndexFunc(servers, func(server jsonConfigSyslogServer) bool {
+		return server.Host == host
+	})
+
+	return i, i >= 0
+}
+
+func prepareConfigSyslogDestinationJSON(d *schema.ResourceData) jsonConfigSyslogServer {
+	return jsonConfigSyslogServer{
+		Host:          d.Get("host").(string),
+		Port:          d.Get("port").(int),
+		Protocol:      d.Get("protocol").(string),
+		Facility:      d.Get("facility").(string),
+		TLS:           d.Get("tls").(bool),
+		CACertificate: d.Get("ca_certificate").(string),
+	}
+}
+
+func fillConfigSyslogDestination(d *schema.ResourceData, jsonData jsonConfigSyslogServer) {
+	if tfErr := d.Set("host", jsonData.Host); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("protocol", jsonData.Protocol); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("facility", jsonData.Facility); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("tls", jsonData.TLS); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ca_certificate", jsonData.CACertificate); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,108 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigSyslogDestination_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigSyslogDestinationTLSWithoutCA(),
+				ExpectError: regexp.MustCompile(`ca_certificate must be set with tls`),
+			},
+			{
+				Config: testAccResourceConfigSyslogDestinationCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_config_syslog_destination.testacc_ConfigSyslogDestination",
+						"id", "192.0.2.10"),
+				),
+			},
+			{
+				Config: testAccResourceConfigSyslogDestinationUpdate(),
+			},
+			{
+				ResourceName:  "wallix-bastion_config_syslog_destination.testacc_ConfigSyslogDestination",
+				ImportState:   true,
+				ImportStateId: "192.0.2.10",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func TestAccResourceConfigSyslogDestination_withConfigSyslog(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigSyslogDestinationWithConfigSyslog(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_config_syslog.testacc_ConfigSyslog",
+						"server.#", "1"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_config_syslog_destination.testacc_ConfigSyslogDestination",
+						"tls", "false"),
+				),
+			},
+			{
+				// the two resources keep the server of the other
+				Config:   testAccResourceConfigSyslogDestinationWithConfigSyslog(),
+				PlanOnly: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigSyslogDestinationTLSWithoutCA() string {
+	return `
+resource "wallix-bastion_config_syslog_destination" "testacc_ConfigSyslogDestination" {
+  host     = "192.0.2.10"
+  port     = 6514
+  protocol = "tcp"
+  tls      = true
+}
+`
+}
+
+func testAccResourceConfigSyslogDestinationCreate() string {
+	return `
+resource "wallix-bastion_config_syslog_destination" "testacc_ConfigSyslogDestination" {
+  host = "192.0.2.10"
+}
+`
+}
+
+func testAccResourceConfigSyslogDestinationUpdate() string {
+	return `
+resource "wallix-bastion_config_syslog_destination" "testacc_ConfigSyslogDestination" {
+  host     = "192.0.2.10"
+  port     = 601
+  protocol = "tcp"
+  facility = "local3"
+}
+`
+}
+
+func testAccResourceConfigSyslogDestinationWithConfigSyslog() string {
+	return `
+resource "wallix-bastion_config_syslog" "testacc_ConfigSyslog" {
+  server {
+    host = "192.0.2.11"
+  }
+}
+resource "wallix-bastion_config_syslog_destination" "testacc_ConfigSyslogDestination" {
+  host     = "192.0.2.12"
+  protocol = "tcp"
+}
+`
+}
@@ -0,0 +1,235 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateConfigSyslogServersTLS(t *testing.T) {
+	server := func(protocol, caCertificate cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"host":           cty.StringVal("siem.example.com"),
+			"port":           cty.NumberIntVal(6514),
+			"protocol":       protocol,
+			"facility":       cty.NullVal(cty.String),
+			"tls":            cty.True,
+			"ca_certificate": caCertificate,
+		})
+	}
+	tests := map[string]struct {
+		server cty.Value
+		errors []string
+	}{
+		"tcp with CA": {
+			server: server(cty.StringVal("tcp"), cty.StringVal("-----BEGIN CERTIFICATE-----")),
+		},
+		"unknown CA": {
+			server: server(cty.StringVal("tcp"), cty.UnknownVal(cty.String)),
+		},
+		"default protocol": {
+			server: server(cty.NullVal(cty.String), cty.StringVal("-----BEGIN CERTIFICATE-----")),
+			errors: []string{`tls requires protocol "tcp"`},
+		},
+		"udp without CA": {
+			server: server(cty.StringVal("udp"), cty.NullVal(cty.String)),
+			errors: []string{`tls requires protocol "tcp"`, "ca_certificate must be set with tls"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateConfigSyslogServersTLS(context.Background(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"server": cty.ListVal([]cty.Value{tt.server}),
+				}),
+			}, resp)
+			if len(resp.Diagnostics) != len(tt.errors) {
+				t.Fatalf("expected %d errors, got %v", len(tt.errors), resp.Diagnostics)
+			}
+			for i, v := range tt.errors {
+				if resp.Diagnostics[i].Summary != v {
+					t.Errorf("expected error %q, got %q", v, resp.Diagnostics[i].Summary)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfigSyslogDestinationTLS(t *testing.T) {
+	tests := map[string]struct {
+		protocol      cty.Value
+		caCertificate cty.Value
+		errors        []string
+	}{
+		"tcp with CA": {
+			protocol:      cty.StringVal("tcp"),
+			caCertificate: cty.StringVal("-----BEGIN CERTIFICATE-----"),
+		},
+		"tcp without CA": {
+			protocol:      cty.StringVal("tcp"),
+			caCertificate: cty.NullVal(cty.String),
+			errors:        []string{"ca_certificate must be set with tls"},
+		},
+		"tcp with unknown CA": {
+			protocol:      cty.StringVal("tcp"),
+			caCertificate: cty.UnknownVal(cty.String),
+		},
+		"default protocol": {
+			protocol:      cty.NullVal(cty.String),
+			caCertificate: cty.StringVal("-----BEGIN CERTIFICATE-----"),
+			errors:        []string{`tls requires protocol "tcp"`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateConfigSyslogDestinationTLS(context.Background(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"host":           cty.StringVal("192.0.2.10"),
+					"port":           cty.NumberIntVal(6514),
+					"protocol":       tt.protocol,
+					"facility":       cty.NullVal(cty.String),
+					"tls":            cty.True,
+					"ca_certificate": tt.caCertificate,
+				}),
+			}, resp)
+			if len(resp.Diagnostics) != len(tt.errors) {
+				t.Fatalf("expected %d errors, got %v", len(tt.errors), resp.Diagnostics)
+			}
+			for i, v := range tt.errors {
+				if resp.Diagnostics[i].Summary != v {
+					t.Errorf("expected error %q, got %q", v, resp.Diagnostics[i].Summary)
+				}
+			}
+		})
+	}
+}
+
+// TestResourceConfigSyslogWithDestination applies wallix-bastion_config_syslog and
+// wallix-bastion_config_syslog_destination on the same Bastion, with a server not managed by Terraform.
+func TestResourceConfigSyslogWithDestination(t *testing.T) {
+	ctx := context.Background()
+	bastionConfig := jsonConfigSyslog{
+		Enable: true,
+		Format: "rfc5424",
+		Servers: []jsonConfigSyslogServer{
+			{Host: "198.51.100.1", Port: 514, Protocol: "udp", Facility: "local0"},
+		},
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3.12/config/syslog" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if err := json.NewEncoder(w).Encode(bastionConfig); err != nil {
+				t.Errorf("encoding syslog configuration: %s", err)
+			}
+		case http.MethodPut:
+			bastionConfig = jsonConfigSyslog{}
+			if err := json.NewDecoder(r.Body).Decode(&bastionConfig); err != nil {
+				t.Errorf("decoding syslog configuration: %s", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	hosts := func() []string {
+		result := make([]string, len(bastionConfig.Servers))
+		for i, v := range bastionConfig.Servers {
+			result[i] = v.Host
+		}
+
+		return result
+	}
+	apply := func(r *schema.Resource, state *terraform.InstanceState, config map[string]interface{},
+	) *terraform.InstanceState {
+		t.Helper()
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("computing the diff: %s", err)
+		}
+		newState, diags := r.Apply(ctx, state, diff, c)
+		if diags.HasError() {
+			t.Fatalf("applying: %v", diags)
+		}
+
+		return newState
+	}
+	destroy := func(r *schema.Resource, state *terraform.InstanceState) {
+		t.Helper()
+		if _, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, c); diags.HasError() {
+			t.Fatalf("destroying: %v", diags)
+		}
+	}
+	expectNoDrift := func(r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) {
+		t.Helper()
+		state, diags := r.RefreshWithoutUpgrade(ctx, state, c)
+		if diags.HasError() {
+			t.Fatalf("refreshing: %v", diags)
+		}
+		if state.ID == "" {
+			t.Fatal("expected the resource to still exist after refresh")
+		}
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("computing the diff: %s", err)
+		}
+		if !diff.Empty() {
+			t.Errorf("expected no diff after refresh, got %v", diff.Attributes)
+		}
+	}
+
+	syslog := resourceConfigSyslog()
+	syslogConfig := map[string]interface{}{
+		"server": []interface{}{
+			map[string]interface{}{"host": "192.0.2.10"},
+		},
+	}
+	syslogState := apply(syslog, nil, syslogConfig)
+	destination := resourceConfigSyslogDestination()
+	destinationConfig := map[string]interface{}{
+		"host":           "192.0.2.20",
+		"port":           6514,
+		"protocol":       "tcp",
+		"tls":            true,
+		"ca_certificate": "-----BEGIN CERTIFICATE-----",
+	}
+	destinationState := apply(destination, nil, destinationConfig)
+	if got, want := hosts(), []string{"198.51.100.1", "192.0.2.10", "192.0.2.20"}; !slices.Equal(got, want) {
+		t.Fatalf("expected servers %v, got %v", want, got)
+	}
+	expectNoDrift(syslog, syslogState, syslogConfig)
+	expectNoDrift(destination, destinationState, destinationConfig)
+
+	syslogConfig["server"] = []interface{}{
+		map[string]interface{}{"host": "192.0.2.11", "protocol": "tcp"},
+	}
+	syslogState = apply(syslog, syslogState, syslogConfig)
+	if got, want := hosts(), []string{"198.51.100.1", "192.0.2.20", "192.0.2.11"}; !slices.Equal(got, want) {
+		t.Fatalf("expected servers %v after update, got %v", want, got)
+	}
+	expectNoDrift(destination, destinationState, destinationConfig)
+
+	destroy(syslog, syslogState)
+	if got, want := hosts(), []string{"198.51.100.1", "192.0.2.20"}; !slices.Equal(got, want) {
+		t.Fatalf("expected servers %v after destroy, got %v", want, got)
+	}
+	if !bastionConfig.Enable {
+		t.Error("expected the forwarding to stay enabled for the remaining servers")
+	}
+	destroy(destination, destinationState)
+	if got, want := hosts(), []string{"198.51.100.1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected servers %v after destroy, got %v", want, got)
+	}
+}
@@ -0,0 +1,84 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigSyslog_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_syslog.testacc_ConfigSyslog"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigSyslogTLSWithoutCA(),
+				ExpectError: regexp.MustCompile(`ca_certificate must be set with tls`),
+			},
+			{
+				Config: testAccResourceConfigSyslogCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "server.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "format", "rfc5424"),
+				),
+			},
+			{
+				Config: testAccResourceConfigSyslogUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "server.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "server.1.facility", "local3"),
+					resource.TestCheckResourceAttr(resourceName, "format", "rfc3164"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "syslog_config",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigSyslogTLSWithoutCA() string {
+	return `
+resource "wallix-bastion_config_syslog" "testacc_ConfigSyslog" {
+  server {
+    host     = "192.0.2.10"
+    port     = 6514
+    protocol = "tcp"
+    tls      = true
+  }
+}
+`
+}
+
+func testAccResourceConfigSyslogCreate() string {
+	return `
+resource "wallix-bastion_config_syslog" "testacc_ConfigSyslog" {
+  server {
+    host = "192.0.2.10"
+  }
+}
+`
+}
+
+func testAccResourceConfigSyslogUpdate() string {
+	return `
+resource "wallix-bastion_config_syslog" "testacc_ConfigSyslog" {
+  format = "rfc3164"
+  server {
+    host = "192.0.2.10"
+  }
+  server {
+    host     = "192.0.2.11"
+    port     = 601
+    protocol = "tcp"
+    facility = "local3"
+  }
+}
+`
+}
@@ -0,0 +1,271 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// configUserAuthenticationPolicySection is the configoptions section of the user authentication.
+const configUserAuthenticationPolicySection = "authentication"
+
+func resourceConfigUserAuthenticationPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigUserAuthenticationPolicyCreate,
+		ReadContext:   resourceConfigUserAuthenticationPolicyRead,
+		UpdateContext: resourceConfigUserAuthenticationPolicyUpdate,
+		DeleteContext: resourceConfigUserAuthenticationPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigUserAuthenticationPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"max_failed_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"lockout_duration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 1440),
+			},
+			"password_expiration_warning_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 90),
+			},
+			"authentication_methods": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"local_authentication_with_external": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allowed", "denied"}, false),
+			},
+		},
+	}
+}
+
+func resourceConfigUserAuthenticationPolicyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_user_authentication_policy not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceConfigUserAuthenticationPolicyCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigUserAuthenticationPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigUserAuthenticationPolicy(ctx, prepareConfigUserAuthenticationPolicyJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("userAuthenticationPolicyConfig")
+
+	return resourceConfigUserAuthenticationPolicyRead(ctx, d, m)
+}
+
+func resourceConfigUserAuthenticationPolicyRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigUserAuthenticationPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigSessionOptions(ctx, configUserAuthenticationPolicySection, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigUserAuthenticationPolicy(d, cfg)
+
+	return nil
+}
+
+func resourceConfigUserAuthenticationPolicyUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigUserAuthenticationPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Restore the default value of the options which aren't managed anymore
+	optionNames := configUserAuthenticationPolicyOptionNames()
+	removedOptions := make(map[string]interface{})
+	for attr, name := range optionNames {
+		oldValue, _ := d.GetChange(attr)
+		if configUserAuthenticationPolicyValueSet(oldValue) && !configUserAuthenticationPolicyValueSet(d.Get(attr)) {
+			removedOptions[name] = ""
+		}
+	}
+	if len(removedOptions) > 0 {
+		if err := updateConfigSessionOptions(
+			ctx, configUserAuthenticationPolicySection, removedOptions, true, m,
+		); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	if err := updateConfigUserAuthenticationPolicy(ctx, prepareConfigUserAuthenticationPolicyJSON(d), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigUserAuthenticationPolicyRead(ctx, d, m)
+}
+
+func resourceConfigUserAuthenticationPolicyDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigUserAuthenticationPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The options can't be removed, so restore the default value of each managed option
+	options := make(map[string]interface{})
+	for attr, name := range configUserAuthenticationPolicyOptionNames() {
+		if configUserAuthenticationPolicyValueSet(d.Get(attr)) {
+			options[name] = ""
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	if err := updateConfigSessionOptions(ctx, configUserAuthenticationPolicySection, options, true, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigUserAuthenticationPolicyImport(
+	d *schema.ResourceData, _ interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	// Since the resource does not have a unique ID, use the static "userAuthenticationPolicyConfig" ID
+	d.SetId("userAuthenticationPolicyConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// configUserAuthenticationPolicyOptionNames returns the name of the options in the section for each attribute.
+func configUserAuthenticationPolicyOptionNames() map[string]string {
+	return map[string]string{
+		"max_failed_attempts":                "max_failed_attempts",
+		"lockout_duration":                   "lockout_duration",
+		"password_expiration_warning_days":   "password_expiration_warning",
+		"authentication_methods":             "authentication_methods_order",
+		"local_authentication_with_external": "allow_local_authentication_with_external",
+	}
+}
+
+// configUserAuthenticationPolicyValueSet returns true if the value of an attribute isn't empty,
+// i.e. the option is managed by the resource.
+func configUserAuthenticationPolicyValueSet(value interface{}) bool {
+	switch v := value.(type) {
+	case int:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	default:
+		return false
+	}
+}
+
+func updateConfigUserAuthenticationPolicy(ctx context.Context, jsonData jsonConfigSessionOptions, m interface{}) error {
+	if len(jsonData.Options) == 0 {
+		return nil
+	}
+
+	return putConfigSessionOptions(ctx, configUserAuthenticationPolicySection, jsonData, m)
+}
+
+// prepareConfigUserAuthenticationPolicyJSON sends only the options set in the configuration,
+// the others keep their current value on the bastion.
+func prepareConfigUserAuthenticationPolicyJSON(d *schema.ResourceData) jsonConfigSessionOptions {
+	optionNames := configUserAuthenticationPolicyOptionNames()
+	jsonData := jsonConfigSessionOptions{
+		Options: make([]jsonConfigSessionOption, 0, len(optionNames)),
+	}
+	for _, attr := range []string{"max_failed_attempts", "lockout_duration", "password_expiration_warning_days"} {
+		if v, ok := d.GetOk(attr); ok {
+			jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{Name: optionNames[attr], Value: v})
+		}
+	}
+	if v, ok := d.GetOk("authentication_methods"); ok {
+		listAuthenticationMethods := v.([]interface{})
+		authenticationMethods := make([]string, len(listAuthenticationMethods))
+		for i, method := range listAuthenticationMethods {
+			authenticationMethods[i] = method.(string)
+		}
+		jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{
+			Name:  optionNames["authentication_methods"],
+			Value: authenticationMethods,
+		})
+	}
+	if v, ok := d.GetOk("local_authentication_with_external"); ok {
+		jsonData.Options = append(jsonData.Options, jsonConfigSessionOption{
+			Name:  optionNames["local_authentication_with_external"],
+			Value: v.(string) == "allowed",
+		})
+	}
+
+	return jsonData
+}
+
+// fillConfigUserAuthenticationPolicy maps the options of the section to the typed attributes
+// already managed by the resource, so the other options don't appear in the plan.
+func fillConfigUserAuthenticationPolicy(d *schema.ResourceData, jsonData jsonConfigSessionOptions) {
+	values := make(map[string]interface{}, len(jsonData.Options))
+	for _, v := range jsonData.Options {
+		values[v.Name] = v.Value
+	}
+	optionNames := configUserAuthenticationPolicyOptionNames()
+	for _, attr := range []string{"max_failed_attempts", "lockout_duration", "password_expiration_warning_days"} {
+		if !configUserAuthenticationPolicyValueSet(d.Get(attr)) {
+			continue
+		}
+		value, _ := values[optionNames[attr]].(float64)
+		if tfErr := d.Set(attr, int(value)); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	if configUserAuthenticationPolicyValueSet(d.Get("authentication_methods")) {
+		listAuthenticationMethods, _ := values[optionNames["authentication_methods"]].([]interface{})
+		authenticationMethods := make([]string, 0, len(listAuthenticationMethods))
+		for _, v := range listAuthenticationMethods {
+			if method, ok := v.(string); ok {
+				authenticationMethods = append(authenticationMethods, method)
+			}
+		}
+		if tfErr := d.Set("authentication_methods", authenticationMethods); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	if configUserAuthenticationPolicyValueSet(d.Get("local_authentication_with_external")) {
+		localAuthentication := "denied"
+		if allowed, _ := values[optionNames["local_authentication_with_external"]].(bool); allowed {
+			localAuthentication = "allowed"
+		}
+		if tfErr := d.Set("local_authentication_with_external", localAuthentication); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceConfigUserAuthenticationPolicyOptions(t *testing.T) {
+	options := map[string]jsonConfigSessionOption{
+		"max_failed_attempts":         {Name: "max_failed_attempts", Value: float64(3), Default: float64(3)},
+		"lockout_duration":            {Name: "lockout_duration", Value: float64(15), Default: float64(15)},
+		"password_expiration_warning": {Name: "password_expiration_warning", Value: float64(7), Default: float64(7)},
+		"authentication_methods_order": {
+			Name: "authentication_methods_order", Value: []interface{}{"local"}, Default: []interface{}{"local"},
+		},
+		"allow_local_authentication_with_external": {
+			Name: "allow_local_authentication_with_external", Value: true, Default: true,
+		},
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3.12/configoptions/authentication" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			var result jsonConfigSessionOptions
+			for _, v := range options {
+				result.Options = append(result.Options, v)
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case http.MethodPut:
+			var jsonData jsonConfigSessionOptions
+			if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+				t.Errorf("decoding request: %s", err)
+			}
+			for _, v := range jsonData.Options {
+				if v.Value == "" {
+					t.Errorf("option %s set to an empty string", v.Name)
+				}
+				option := options[v.Name]
+				option.Value = v.Value
+				options[v.Name] = option
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	r := resourceConfigUserAuthenticationPolicy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"max_failed_attempts":                5,
+		"lockout_duration":                   60,
+		"authentication_methods":             []interface{}{"ldap", "local"},
+		"local_authentication_with_external": "denied",
+	})
+	if diags := resourceConfigUserAuthenticationPolicyCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("max_failed_attempts").(int); got != 5 {
+		t.Errorf("expected max_failed_attempts 5, got %d", got)
+	}
+	if got := d.Get("password_expiration_warning_days").(int); got != 0 {
+		t.Errorf("expected password_expiration_warning_days not managed, got %d", got)
+	}
+	if got := d.Get("local_authentication_with_external").(string); got != "denied" {
+		t.Errorf("expected local_authentication_with_external denied, got %q", got)
+	}
+	if got := options["authentication_methods_order"].Value; !reflect.DeepEqual(got, []interface{}{"ldap", "local"}) {
+		t.Errorf("expected authentication_methods_order to keep the order, got %v", got)
+	}
+
+	// lockout_duration removed from the configuration
+	d = r.Data(d.State())
+	if err := d.Set("lockout_duration", 0); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceConfigUserAuthenticationPolicyUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := options["lockout_duration"].Value; got != float64(15) {
+		t.Errorf("expected lockout_duration default restored, got %v", got)
+	}
+	if got := d.Get("lockout_duration").(int); got != 0 {
+		t.Errorf("expected lockout_duration not managed anymore, got %d", got)
+	}
+
+	if diags := resourceConfigUserAuthenticationPolicyDelete(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if options["max_failed_attempts"].Value != float64(3) ||
+		options["allow_local_authentication_with_external"].Value != true {
+		t.Errorf("expected the default values to be restored, got %v", options)
+	}
+}
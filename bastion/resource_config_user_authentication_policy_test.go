@@ -0,0 +1,67 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigUserAuthenticationPolicy_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_user_authentication_policy.testacc_ConfigUserAuthenticationPolicy"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigUserAuthenticationPolicyInvalid(),
+				ExpectError: regexp.MustCompile(`expected max_failed_attempts to be in the range \(1 - 100\)`),
+			},
+			{
+				Config: testAccResourceConfigUserAuthenticationPolicyCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "max_failed_attempts", "5"),
+					resource.TestCheckResourceAttr(resourceName, "lockout_duration", "30"),
+					resource.TestCheckResourceAttr(resourceName, "password_expiration_warning_days", "0"),
+				),
+			},
+			{
+				Config: testAccResourceConfigUserAuthenticationPolicyUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "lockout_duration", "0"),
+					resource.TestCheckResourceAttr(resourceName, "password_expiration_warning_days", "14"),
+					resource.TestCheckResourceAttr(resourceName, "local_authentication_with_external", "denied"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigUserAuthenticationPolicyInvalid() string {
+	return `
+resource "wallix-bastion_config_user_authentication_policy" "testacc_ConfigUserAuthenticationPolicy" {
+  max_failed_attempts = 101
+}
+`
+}
+
+func testAccResourceConfigUserAuthenticationPolicyCreate() string {
+	return `
+resource "wallix-bastion_config_user_authentication_policy" "testacc_ConfigUserAuthenticationPolicy" {
+  max_failed_attempts = 5
+  lockout_duration    = 30
+}
+`
+}
+
+func testAccResourceConfigUserAuthenticationPolicyUpdate() string {
+	return `
+resource "wallix-bastion_config_user_authentication_policy" "testacc_ConfigUserAuthenticationPolicy" {
+  max_failed_attempts                = 5
+  password_expiration_warning_days   = 14
+  local_authentication_with_external = "denied"
+}
+`
+}
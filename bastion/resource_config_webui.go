@@ -0,0 +1,253 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonConfigWebUI struct {
+	SessionTimeout  int      `json:"session_timeout"`
+	AllowedNetworks []string `json:"allowed_networks"`
+	SecurityHeaders bool     `json:"security_headers"`
+	Port            int      `json:"port"`
+}
+
+func resourceConfigWebUI() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigWebUICreate,
+		ReadContext:   resourceConfigWebUIRead,
+		UpdateContext: resourceConfigWebUIUpdate,
+		DeleteContext: resourceConfigWebUIDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigWebUIImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"session_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntBetween(1, 1440),
+			},
+			"allowed_networks": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"security_headers": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      443,
+				ValidateFunc: validation.IsPortNumber,
+			},
+		},
+	}
+}
+
+func resourceConfigWebUIVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_config_webui not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceConfigWebUICreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigWebUIVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigWebUI(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("webuiConfig")
+
+	return resourceConfigWebUIRead(ctx, d, m)
+}
+
+func resourceConfigWebUIRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigWebUIVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readConfigWebUIOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillConfigWebUI(d, cfg)
+
+	return nil
+}
+
+func resourceConfigWebUIUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceConfigWebUIVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigWebUI(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceConfigWebUIRead(ctx, d, m)
+}
+
+func resourceConfigWebUIDelete(ctx context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceConfigWebUIVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// The web UI can't be removed, so restore the defaults of the appliance
+	if err := deleteConfigWebUI(ctx, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Web UI configuration restored to the appliance defaults",
+		Detail: "The web UI of the Bastion can't be removed, " +
+			"destroying wallix-bastion_config_webui restored the default session timeout, " +
+			"allowed networks, security headers and port.",
+	}}
+}
+
+func resourceConfigWebUIImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "webuiConfig" ID
+	d.SetId("webuiConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// configWebUISourceAddress returns the local address used by the provider to connect to the Bastion,
+// the UDP socket only selects the route and doesn't send any packet.
+func configWebUISourceAddress(c *Client) (net.IP, error) {
+	conn, err := net.Dial("udp", net.JoinHostPort(c.bastionIP, strconv.Itoa(c.bastionPort)))
+	if err != nil {
+		return nil, fmt.Errorf("finding the source address of the provider: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// checkConfigWebUIAllowedNetworks refuses allowed_networks without the source address of the provider,
+// the next requests of the provider would be rejected by the Bastion.
+func checkConfigWebUIAllowedNetworks(allowedNetworks []string, source net.IP) error {
+	if len(allowedNetworks) == 0 {
+		return nil
+	}
+	for _, v := range allowedNetworks {
+		_, network, err := net.ParseCIDR(v)
+		if err != nil {
+			return fmt.Errorf("parsing allowed_networks: %w", err)
+		}
+		if network.Contains(source) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("allowed_networks doesn't contain %s, the address used by the provider to connect "+
+		"to the Bastion, applying it would lock out the provider", source)
+}
+
+func readConfigWebUIOptions(ctx context.Context, m interface{}) (jsonConfigWebUI, error) {
+	c := m.(*Client)
+	var result jsonConfigWebUI
+	body, code, err := c.newRequest(ctx, "/config/webui", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateConfigWebUI(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareConfigWebUIJSON(d)
+	if d.HasChange("allowed_networks") {
+		source, err := configWebUISourceAddress(c)
+		if err != nil {
+			return err
+		}
+		if err := checkConfigWebUIAllowedNetworks(jsonData.AllowedNetworks, source); err != nil {
+			return err
+		}
+	}
+	body, code, err := c.newRequest(ctx, "/config/webui", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteConfigWebUI(ctx context.Context, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/webui", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareConfigWebUIJSON(d *schema.ResourceData) jsonConfigWebUI {
+	jsonData := jsonConfigWebUI{
+		SessionTimeout:  d.Get("session_timeout").(int),
+		SecurityHeaders: d.Get("security_headers").(bool),
+		Port:            d.Get("port").(int),
+	}
+	listAllowedNetworks := d.Get("allowed_networks").(*schema.Set).List()
+	jsonData.AllowedNetworks = make([]string, len(listAllowedNetworks))
+	for i, v := range listAllowedNetworks {
+		jsonData.AllowedNetworks[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func fillConfigWebUI(d *schema.ResourceData, jsonData jsonConfigWebUI) {
+	if tfErr := d.Set("session_timeout", jsonData.SessionTimeout); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("allowed_networks", jsonData.AllowedNetworks); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("security_headers", jsonData.SecurityHeaders); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
+		panic(tfErr)
+	}
+}
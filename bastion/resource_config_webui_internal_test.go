@@ -0,0 +1,111 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCheckConfigWebUIAllowedNetworks(t *testing.T) {
+	tests := map[string]struct {
+		allowedNetworks []string
+		source          string
+		errMatch        string
+	}{
+		"all allowed": {
+			source: "192.0.2.10",
+		},
+		"source allowed": {
+			allowedNetworks: []string{"198.51.100.0/24", "192.0.2.0/24"},
+			source:          "192.0.2.10",
+		},
+		"ipv6 source allowed": {
+			allowedNetworks: []string{"2001:db8::/32"},
+			source:          "2001:db8::10",
+		},
+		"source locked out": {
+			allowedNetworks: []string{"198.51.100.0/24"},
+			source:          "192.0.2.10",
+			errMatch:        "allowed_networks doesn't contain 192.0.2.10",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkConfigWebUIAllowedNetworks(tt.allowedNetworks, net.ParseIP(tt.source))
+			if tt.errMatch == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMatch) {
+				t.Fatalf("expected error matching %q, got %v", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestResourceConfigWebUICreate(t *testing.T) {
+	tests := map[string]struct {
+		allowedNetworks []interface{}
+		errMatch        string
+	}{
+		// the test server listens on the loopback address
+		"provider allowed": {
+			allowedNetworks: []interface{}{"127.0.0.0/8"},
+		},
+		"provider locked out": {
+			allowedNetworks: []interface{}{"192.0.2.0/24"},
+			errMatch:        "applying it would lock out the provider",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var config jsonConfigWebUI
+			puts := 0
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/api/v3.12/config/webui":
+					if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+						t.Errorf("decoding request: %s", err)
+					}
+					puts++
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v3.12/config/webui":
+					_ = json.NewEncoder(w).Encode(config)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			d := schema.TestResourceDataRaw(t, resourceConfigWebUI().Schema, map[string]interface{}{
+				"session_timeout":  10,
+				"allowed_networks": tt.allowedNetworks,
+			})
+			diags := resourceConfigWebUICreate(context.Background(), d, c)
+			if tt.errMatch != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", tt.errMatch, diags)
+				}
+				if puts != 0 {
+					t.Errorf("expected the configuration not to be sent, got %d updates", puts)
+				}
+
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != "webuiConfig" || d.Get("session_timeout").(int) != 10 || d.Get("port").(int) != 443 ||
+				!d.Get("security_headers").(bool) || d.Get("allowed_networks").(*schema.Set).Len() != 1 {
+				t.Errorf("unexpected state after creation: %v", d.State())
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package bastion_test
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigWebUI_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_webui.testacc_ConfigWebUI"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigWebUI(30, `["192.0.2"]`),
+				ExpectError: regexp.MustCompile(`to be a valid CIDR Value`),
+			},
+			{
+				// TEST-NET-1 doesn't contain the address of the provider
+				Config:      testAccResourceConfigWebUI(30, `["192.0.2.0/24"]`),
+				ExpectError: regexp.MustCompile(`would lock out the provider`),
+			},
+			{
+				Config: testAccResourceConfigWebUI(30, `["0.0.0.0/0", "::/0"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "allowed_networks.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceConfigWebUI(15, `["0.0.0.0/0", "::/0"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "session_timeout", "15"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "webui_config",
+				ImportStateVerify: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigWebUI(sessionTimeout int, allowedNetworks string) string {
+	return `
+resource "wallix-bastion_config_webui" "testacc_ConfigWebUI" {
+  session_timeout  = ` + strconv.Itoa(sessionTimeout) + `
+  allowed_networks = ` + allowedNetworks + `
+}
+`
+}
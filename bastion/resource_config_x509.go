@@ -8,11 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -25,6 +27,9 @@ type jsonConfigX509 struct {
 	ServerPrivateKey string `json:"server_private_key"`
 	Enable           bool   `json:"enable"`
 	Default          bool   `json:"default,omitempty"`
+	// only returned by the API
+	CaCertificateFingerprint   string `json:"ca_certificate_fingerprint,omitempty"`
+	ServerPublicKeyFingerprint string `json:"server_public_key_fingerprint,omitempty"`
 }
 
 func resourceConfigX509() *schema.Resource {
@@ -38,29 +43,62 @@ func resourceConfigX509() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"ca_certificate": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.All(validatePEM("CERTIFICATE"), validateX509CACertificate),
+				DiffSuppressFunc: suppressConfigX509CertificateDiffAfterImport("ca_certificate_fingerprint"),
 			},
 			"server_public_key": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validatePEM("CERTIFICATE"),
+				DiffSuppressFunc: suppressConfigX509CertificateDiffAfterImport("server_public_key_fingerprint"),
 			},
 			"server_private_key": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePEM("RSA PRIVATE KEY", "EC PRIVATE KEY"),
 			},
 			"enable": {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			// The API returns the distinguished name of the CA certificate instead of the PEM.
+			"ca_certificate_dn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"server_public_key_dn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ca_certificate_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"server_public_key_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// The API has a single X509 configuration and doesn't allow to toggle
+			// the default flag (the default configuration is restored on delete),
+			// so it's only exposed for visibility.
+			"default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func resourceConfigX509Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the certificate can be unknown during the validation of the configuration
+	if err := checkConfigX509CACertificate(d.Get("ca_certificate").(string)); err != nil {
+		return diagFromAPIError(err)
+	}
 	// Add the configuration
 	if err := addConfigX509(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	// Use a static ID since the API does not provide one
 	d.SetId("x509Config")
@@ -71,7 +109,7 @@ func resourceConfigX509Create(ctx context.Context, d *schema.ResourceData, m int
 func resourceConfigX509Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	cfg, err := readConfigX509Options(ctx, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	// If default config, mark the resource as deleted
@@ -84,14 +122,14 @@ func resourceConfigX509Read(ctx context.Context, d *schema.ResourceData, m inter
 		// check diff between api response and common name of ca_certificate
 		caCertificatePEM, _ := pem.Decode([]byte(d.Get("ca_certificate").(string)))
 		if caCertificatePEM == nil {
-			return diag.FromErr(errors.New("failed to decode PEM block from ca_certificate"))
+			return diagFromAPIError(errors.New("failed to decode PEM block from ca_certificate"))
 		}
 		caCertificate, err := x509.ParseCertificate(caCertificatePEM.Bytes)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromAPIError(err)
 		}
 		// If ca_certificate common name not match, mark the resource as deleted
-		if !strings.Contains(cfg.CaCertificate, "/CN="+caCertificate.Subject.CommonName) {
+		if !x509DNHasCommonName(cfg.CaCertificate, caCertificate.Subject.CommonName) {
 			d.SetId("")
 
 			return nil
@@ -101,14 +139,14 @@ func resourceConfigX509Read(ctx context.Context, d *schema.ResourceData, m inter
 		// check diff between api response and common name of server_public_key
 		serverPublicKeyPEM, _ := pem.Decode([]byte(d.Get("server_public_key").(string)))
 		if serverPublicKeyPEM == nil {
-			return diag.FromErr(errors.New("failed to decode PEM block from server_public_key"))
+			return diagFromAPIError(errors.New("failed to decode PEM block from server_public_key"))
 		}
 		serverPublicKey, err := x509.ParseCertificate(serverPublicKeyPEM.Bytes)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromAPIError(err)
 		}
 		// If server_public_key common name not match, mark the resource as deleted
-		if !strings.Contains(cfg.ServerPublicKey, "/CN="+serverPublicKey.Subject.CommonName) {
+		if !x509DNHasCommonName(cfg.ServerPublicKey, serverPublicKey.Subject.CommonName) {
 			d.SetId("")
 
 			return nil
@@ -116,15 +154,18 @@ func resourceConfigX509Read(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if err := fillConfigX509(d, cfg); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
 }
 
 func resourceConfigX509Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkConfigX509CACertificate(d.Get("ca_certificate").(string)); err != nil {
+		return diagFromAPIError(err)
+	}
 	if err := updateConfigX509(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return resourceConfigX509Read(ctx, d, m)
@@ -132,7 +173,7 @@ func resourceConfigX509Update(ctx context.Context, d *schema.ResourceData, m int
 
 func resourceConfigX509Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if err := deleteConfigX509(ctx, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	// Remove the resource from state
@@ -141,13 +182,86 @@ func resourceConfigX509Delete(ctx context.Context, d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceConfigX509Import(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+// resourceConfigX509Import populates the attributes returned by the API,
+// the PEM values can't be read so their diff is suppressed while the state is empty.
+func resourceConfigX509Import(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx := context.Background()
+	cfg, err := readConfigX509Options(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Default {
+		return nil, errors.New("the bastion uses the default x509 configuration, there is nothing to import")
+	}
+	if err := fillConfigX509(d, cfg); err != nil {
+		return nil, err
+	}
+	if err := d.Set("enable", cfg.Enable); err != nil {
+		return nil, fmt.Errorf("setting enable: %w", err)
+	}
 	// Since the resource does not have a unique ID, use the static "x509Config" ID
 	d.SetId("x509Config")
 
 	return []*schema.ResourceData{d}, nil
 }
 
+// x509DNHasCommonName returns true if the distinguished name, in the /C=FR/CN=name form returned by the API,
+// has the common name commonName. The whole attribute is compared, a longer common name doesn't match.
+func x509DNHasCommonName(dn, commonName string) bool {
+	return slices.Contains(strings.Split(dn, "/"), "CN="+commonName)
+}
+
+// suppressConfigX509CertificateDiffAfterImport suppresses the diff on a certificate which isn't in the state
+// (i.e. just after an import) when its fingerprint matches the fingerprint returned by the API,
+// a renewed certificate with the same distinguished name is still applied.
+func suppressConfigX509CertificateDiffAfterImport(fingerprintKey string) schema.SchemaDiffSuppressFunc {
+	return func(_, oldValue, newValue string, d *schema.ResourceData) bool {
+		if d.Id() == "" || oldValue != "" || newValue == "" {
+			return false
+		}
+		fingerprint := d.Get(fingerprintKey).(string)
+		if fingerprint == "" {
+			return false
+		}
+		expected, err := x509CertificateFingerprint(newValue)
+		if err != nil {
+			return false
+		}
+
+		return expected == fingerprint
+	}
+}
+
+// checkConfigX509CACertificate rejects a ca_certificate which is not a CA
+// (a leaf certificate copied by mistake would cause trust failures).
+func checkConfigX509CACertificate(caCertificatePEM string) error {
+	if caCertificatePEM == "" {
+		return nil
+	}
+	caCertificateBlock, _ := pem.Decode([]byte(caCertificatePEM))
+	if caCertificateBlock == nil {
+		return errors.New("failed to decode PEM block from ca_certificate")
+	}
+	caCertificate, err := x509.ParseCertificate(caCertificateBlock.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing ca_certificate: %w", err)
+	}
+	if !caCertificate.BasicConstraintsValid || !caCertificate.IsCA {
+		return fmt.Errorf("ca_certificate (CN=%s) is not a CA certificate", caCertificate.Subject.CommonName)
+	}
+
+	return nil
+}
+
+// validateX509CACertificate checks that the value is a PEM encoded CA certificate parsed by crypto/x509.
+func validateX509CACertificate(val interface{}, _ string) ([]string, []error) {
+	if err := checkConfigX509CACertificate(val.(string)); err != nil {
+		return nil, []error{err}
+	}
+
+	return nil, nil
+}
+
 func addConfigX509(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 	jsonData := prepareConfigX509JSON(d)
@@ -156,7 +270,7 @@ func addConfigX509(ctx context.Context, d *schema.ResourceData, m interface{}) e
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("API returned error: %d with body:\n%s", code, body)
+		return newAPIError("API returned error", code, body)
 	}
 
 	// sleep after modifying the x509 configuration
@@ -177,7 +291,7 @@ func readConfigX509Options(ctx context.Context, m interface{}) (jsonConfigX509,
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("API returned error: %d with body:\n%s", code, body)
+		return result, newAPIError("API returned error", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -195,7 +309,7 @@ func updateConfigX509(ctx context.Context, d *schema.ResourceData, m interface{}
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("API returned error: %d with body:\n%s", code, body)
+		return newAPIError("API returned error", code, body)
 	}
 
 	// sleep after modifying the x509 configuration
@@ -212,7 +326,7 @@ func deleteConfigX509(ctx context.Context, m interface{}) error {
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("API returned error: %d with body:\n%s", code, body)
+		return newAPIError("API returned error", code, body)
 	}
 
 	// sleep after modifying the x509 configuration
@@ -224,18 +338,52 @@ func deleteConfigX509(ctx context.Context, m interface{}) error {
 
 func prepareConfigX509JSON(d *schema.ResourceData) jsonConfigX509 {
 	return jsonConfigX509{
-		CaCertificate:    d.Get("ca_certificate").(string),
-		ServerPublicKey:  d.Get("server_public_key").(string),
-		ServerPrivateKey: d.Get("server_private_key").(string),
+		CaCertificate:    configX509PEMValue(d, "ca_certificate"),
+		ServerPublicKey:  configX509PEMValue(d, "server_public_key"),
+		ServerPrivateKey: configX509PEMValue(d, "server_private_key"),
 		Enable:           d.Get("enable").(bool),
 	}
 }
 
-//nolint:wrapcheck
+// configX509PEMValue returns the value of a PEM attribute in the configuration
+// when the diff is suppressed after an import and the state is still empty.
+func configX509PEMValue(d *schema.ResourceData, key string) string {
+	if v := d.Get(key).(string); v != "" {
+		return v
+	}
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return ""
+	}
+	v := rawConfig.GetAttr(key)
+	if v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+
+	return v.AsString()
+}
+
 func fillConfigX509(d *schema.ResourceData, jsonData jsonConfigX509) error {
+	if err := d.Set("default", jsonData.Default); err != nil {
+		return fmt.Errorf("setting default: %w", err)
+	}
+	if err := d.Set("ca_certificate_dn", jsonData.CaCertificate); err != nil {
+		return fmt.Errorf("setting ca_certificate_dn: %w", err)
+	}
+	if err := d.Set("server_public_key_dn", jsonData.ServerPublicKey); err != nil {
+		return fmt.Errorf("setting server_public_key_dn: %w", err)
+	}
+	if err := d.Set("ca_certificate_fingerprint",
+		normalizeX509Fingerprint(jsonData.CaCertificateFingerprint)); err != nil {
+		return fmt.Errorf("setting ca_certificate_fingerprint: %w", err)
+	}
+	if err := d.Set("server_public_key_fingerprint",
+		normalizeX509Fingerprint(jsonData.ServerPublicKeyFingerprint)); err != nil {
+		return fmt.Errorf("setting server_public_key_fingerprint: %w", err)
+	}
 	if _, enableExplicitlySet := d.GetOk("enable"); enableExplicitlySet || jsonData.Enable {
 		if err := d.Set("enable", jsonData.Enable); err != nil {
-			return err
+			return fmt.Errorf("setting enable: %w", err)
 		}
 	}
 
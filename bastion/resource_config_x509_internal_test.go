@@ -0,0 +1,274 @@
+package bastion
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testConfigX509Certificate returns a self-signed CA certificate with the common name bastion and its key.
+func testConfigX509Certificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "bastion"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling key: %s", err)
+	}
+
+	return certDER, keyDER
+}
+
+func TestResourceConfigX509ValidatePEM(t *testing.T) {
+	certDER, keyDER := testConfigX509Certificate(t)
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	leafPEM, _ := testDeviceCACertificate(t, false)
+
+	tests := map[string]struct {
+		config   map[string]interface{}
+		errMatch string
+	}{
+		"valid": {
+			config: map[string]interface{}{
+				"ca_certificate":     certPEM,
+				"server_public_key":  certPEM,
+				"server_private_key": keyPEM,
+			},
+		},
+		"base64 without headers": {
+			config: map[string]interface{}{
+				"server_public_key":  base64.StdEncoding.EncodeToString(certDER),
+				"server_private_key": keyPEM,
+			},
+			errMatch: "server_public_key is not a PEM encoded value",
+		},
+		"key as certificate": {
+			config: map[string]interface{}{
+				"ca_certificate":     keyPEM,
+				"server_public_key":  certPEM,
+				"server_private_key": keyPEM,
+			},
+			errMatch: `ca_certificate has a PEM block of type "EC PRIVATE KEY", expected CERTIFICATE`,
+		},
+		"certificate as key": {
+			config: map[string]interface{}{
+				"server_public_key":  certPEM,
+				"server_private_key": certPEM,
+			},
+			errMatch: `expected RSA PRIVATE KEY or EC PRIVATE KEY`,
+		},
+		"leaf certificate as CA": {
+			config: map[string]interface{}{
+				"ca_certificate":     leafPEM,
+				"server_public_key":  certPEM,
+				"server_private_key": keyPEM,
+			},
+			errMatch: "ca_certificate (CN=device CA) is not a CA certificate",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := resourceConfigX509().Validate(terraform.NewResourceConfigRaw(tt.config))
+			if tt.errMatch == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+
+				return
+			}
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.errMatch) {
+				t.Fatalf("expected error matching %q, got %v", tt.errMatch, diags)
+			}
+		})
+	}
+}
+
+func TestResourceConfigX509ReadCACertificateDN(t *testing.T) {
+	certDER, keyDER := testConfigX509Certificate(t)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3.12/config/x509" || r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jsonConfigX509{
+			CaCertificate:   "/C=FR/O=Wallix/CN=Bastion CA",
+			ServerPublicKey: "/C=FR/CN=bastion",
+			Enable:          true,
+		})
+	})
+	d := schema.TestResourceDataRaw(t, resourceConfigX509().Schema, map[string]interface{}{
+		"server_public_key":  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		"server_private_key": string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	})
+	d.SetId("x509Config")
+	if diags := resourceConfigX509Read(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("ca_certificate_dn").(string); got != "/C=FR/O=Wallix/CN=Bastion CA" {
+		t.Errorf("expected ca_certificate_dn from the api, got %q", got)
+	}
+	if d.Get("ca_certificate").(string) != "" {
+		t.Errorf("expected ca_certificate to be left as configured")
+	}
+}
+
+func TestResourceConfigX509ReadCACertificateCommonName(t *testing.T) {
+	certDER, _ := testConfigX509Certificate(t)
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	tests := map[string]struct {
+		caCertificate string
+		apiDN         string
+		expectID      bool
+	}{
+		"matching common name": {
+			caCertificate: certPEM,
+			apiDN:         "/C=FR/O=Wallix/CN=bastion",
+			expectID:      true,
+		},
+		"other common name": {
+			caCertificate: certPEM,
+			apiDN:         "/C=FR/O=Wallix/CN=other",
+			expectID:      false,
+		},
+		"longer common name": {
+			caCertificate: certPEM,
+			apiDN:         "/C=FR/O=Wallix/CN=bastion-old",
+			expectID:      false,
+		},
+		"no ca_certificate": {
+			caCertificate: "",
+			apiDN:         "/C=FR/O=Wallix/CN=other",
+			expectID:      true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v3.12/config/x509" || r.Method != http.MethodGet {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				_ = json.NewEncoder(w).Encode(jsonConfigX509{
+					CaCertificate: tt.apiDN,
+					Enable:        true,
+				})
+			})
+			d := schema.TestResourceDataRaw(t, resourceConfigX509().Schema, map[string]interface{}{
+				"ca_certificate": tt.caCertificate,
+			})
+			d.SetId("x509Config")
+			if diags := resourceConfigX509Read(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := d.Id() != ""; got != tt.expectID {
+				t.Errorf("expected ID kept %t, got %t", tt.expectID, got)
+			}
+		})
+	}
+}
+
+func TestResourceConfigX509Import(t *testing.T) {
+	certDER, _ := testConfigX509Certificate(t)
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	renewedDER, _ := testConfigX509Certificate(t)
+	renewedPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: renewedDER}))
+	sum := sha256.Sum256(certDER)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3.12/config/x509" || r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jsonConfigX509{
+			CaCertificate:              "/C=FR/O=Wallix/CN=Bastion CA",
+			ServerPublicKey:            "/C=FR/CN=bastion",
+			Enable:                     true,
+			CaCertificateFingerprint:   strings.Repeat("AB:", 31) + "AB",
+			ServerPublicKeyFingerprint: strings.ToUpper(hex.EncodeToString(sum[:])),
+		})
+	})
+	d := schema.TestResourceDataRaw(t, resourceConfigX509().Schema, map[string]interface{}{})
+	d.SetId("x509_config")
+	result, err := resourceConfigX509Import(d, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(result) != 1 || result[0].Id() != "x509Config" {
+		t.Fatalf("expected the static ID x509Config")
+	}
+	if got := d.Get("ca_certificate_dn").(string); got != "/C=FR/O=Wallix/CN=Bastion CA" {
+		t.Errorf("expected ca_certificate_dn from the api, got %q", got)
+	}
+	if got := d.Get("server_public_key_dn").(string); got != "/C=FR/CN=bastion" {
+		t.Errorf("expected server_public_key_dn from the api, got %q", got)
+	}
+	if !d.Get("enable").(bool) {
+		t.Errorf("expected enable from the api")
+	}
+
+	if got := d.Get("server_public_key_fingerprint").(string); got != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected the normalized server_public_key_fingerprint, got %q", got)
+	}
+
+	suppressServerPublicKey := suppressConfigX509CertificateDiffAfterImport("server_public_key_fingerprint")
+	if !suppressServerPublicKey("server_public_key", "", certPEM, d) {
+		t.Errorf("expected the diff of server_public_key to be suppressed with the same fingerprint")
+	}
+	if suppressServerPublicKey("server_public_key", "", renewedPEM, d) {
+		t.Errorf("expected the diff of a renewed server_public_key with the same common name to be kept")
+	}
+	if suppressConfigX509CertificateDiffAfterImport("ca_certificate_fingerprint")("ca_certificate", "", certPEM, d) {
+		t.Errorf("expected the diff of ca_certificate to be kept with a different fingerprint")
+	}
+	if suppressServerPublicKey("server_public_key", certPEM, certPEM+"\n", d) {
+		t.Errorf("expected the diff of server_public_key to be kept when the state isn't empty")
+	}
+}
+
+func TestResourceConfigX509ImportDefault(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jsonConfigX509{Default: true})
+	})
+	d := schema.TestResourceDataRaw(t, resourceConfigX509().Schema, map[string]interface{}{})
+	d.SetId("x509_config")
+	if _, err := resourceConfigX509Import(d, c); err == nil ||
+		!strings.Contains(err.Error(), "default x509 configuration") {
+		t.Errorf("expected an error on the default configuration, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package bastion_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -27,6 +28,9 @@ func TestAccResourceConfigX509_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "server_public_key"),
 					resource.TestCheckResourceAttrSet(resourceName, "server_private_key"),
 					resource.TestCheckResourceAttr(resourceName, "enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "default", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "ca_certificate_dn"),
+					resource.TestCheckResourceAttrSet(resourceName, "server_public_key_dn"),
 				),
 			},
 			// Test updating the resource
@@ -44,8 +48,10 @@ func TestAccResourceConfigX509_basic(t *testing.T) {
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
-				ImportStateVerify: false,
+				ImportStateVerify: true,
 				ImportStateId:     "x509_config",
+				// the API only returns the distinguished names of the certificates
+				ImportStateVerifyIgnore: []string{"ca_certificate", "server_public_key", "server_private_key"},
 			},
 		},
 		PreventPostDestroyRefresh: true, // Prevent deletion
@@ -416,3 +422,57 @@ resource "wallix-bastion_config_x509" "test" {
 }
 `
 }
+
+// TestAccResourceConfigX509_leafCA tests that a leaf certificate is rejected as ca_certificate.
+func TestAccResourceConfigX509_leafCA(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"tls": {
+				Source: "hashicorp/tls",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigX509LeafCA(),
+				ExpectError: regexp.MustCompile(`is not a CA certificate`),
+			},
+		},
+	})
+}
+
+// Test configuration with a self-signed leaf certificate used as ca_certificate.
+func testAccResourceConfigX509LeafCA() string {
+	return `
+resource "tls_private_key" "server" {
+  algorithm = "RSA"
+  rsa_bits  = 4096
+}
+
+resource "tls_self_signed_cert" "server" {
+  private_key_pem = tls_private_key.server.private_key_pem
+
+  subject {
+    common_name  = "bastion.test.local"
+    organization = "Wallix Test"
+    country      = "FR"
+  }
+
+  validity_period_hours = 720 # 30 days
+
+  allowed_uses = [
+    "key_encipherment",
+    "digital_signature",
+    "server_auth",
+  ]
+}
+
+resource "wallix-bastion_config_x509" "test" {
+  ca_certificate     = tls_self_signed_cert.server.cert_pem
+  server_public_key  = tls_self_signed_cert.server.cert_pem
+  server_private_key = tls_private_key.server.private_key_pem
+  enable             = true
+}
+`
+}
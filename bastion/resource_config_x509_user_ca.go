@@ -0,0 +1,185 @@
+package bastion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceConfigX509UserCA() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigX509UserCACreate,
+		ReadContext:   resourceConfigX509UserCARead,
+		UpdateContext: resourceConfigX509UserCAUpdate,
+		DeleteContext: resourceConfigX509UserCADelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigX509UserCAImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"ca_certificate": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePEM("CERTIFICATE"),
+			},
+			"enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ca_certificate_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ca_certificate_dn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceConfigX509UserCACreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkConfigX509CACertificate(d.Get("ca_certificate").(string)); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigX509UserCA(ctx, d.Get("ca_certificate").(string), d.Get("enable").(bool), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("x509UserCAConfig")
+
+	return resourceConfigX509UserCARead(ctx, d, m)
+}
+
+func resourceConfigX509UserCARead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cfg, err := readConfigX509Options(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := fillConfigX509UserCA(d, cfg); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigX509UserCAUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkConfigX509CACertificate(d.Get("ca_certificate").(string)); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateConfigX509UserCA(ctx, d.Get("ca_certificate").(string), d.Get("enable").(bool), m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return resourceConfigX509UserCARead(ctx, d, m)
+}
+
+func resourceConfigX509UserCADelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Remove the CA and disable the X509 users authentication, the server keypair is kept
+	if err := updateConfigX509UserCA(ctx, "", false, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceConfigX509UserCAImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "x509UserCAConfig" ID
+	d.SetId("x509UserCAConfig")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateConfigX509UserCA reads the current configuration and sends it back with only the CA and the enable flag
+// changed, without server_public_key and server_private_key so the server keypair isn't replaced.
+func updateConfigX509UserCA(ctx context.Context, caCertificate string, enable bool, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/x509", http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return newAPIError("API returned error", code, body)
+	}
+	var jsonData map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &jsonData); err != nil {
+		return fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+	if jsonData == nil {
+		jsonData = make(map[string]interface{})
+	}
+	for _, key := range []string{"server_public_key", "server_private_key", "default", "ca_certificate_fingerprint"} {
+		delete(jsonData, key)
+	}
+	jsonData["ca_certificate"] = caCertificate
+	jsonData["enable"] = enable
+	body, code, err = c.newRequest(ctx, "/config/x509", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("API returned error", code, body)
+	}
+
+	// sleep after modifying the x509 configuration
+	// to wait for the API listener to restart
+	time.Sleep(sleepTimeAfterX509ConfigChange)
+
+	return nil
+}
+
+// x509CertificateFingerprint returns the SHA-256 fingerprint of the first certificate of a PEM value
+// as lowercase hex digits.
+func x509CertificateFingerprint(certificatePEM string) (string, error) {
+	block, _ := pem.Decode([]byte(certificatePEM))
+	if block == nil {
+		return "", errors.New("failed to decode PEM block from ca_certificate")
+	}
+	sum := sha256.Sum256(block.Bytes)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// normalizeX509Fingerprint allows the comparison of fingerprints with colons or in uppercase.
+func normalizeX509Fingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+}
+
+// fillConfigX509UserCA compares the CA by fingerprint as the API doesn't return the PEM,
+// a different CA on the bastion empties ca_certificate to plan an update.
+func fillConfigX509UserCA(d *schema.ResourceData, jsonData jsonConfigX509) error {
+	if tfErr := d.Set("enable", jsonData.Enable); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ca_certificate_dn", jsonData.CaCertificate); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ca_certificate_fingerprint",
+		normalizeX509Fingerprint(jsonData.CaCertificateFingerprint)); tfErr != nil {
+		panic(tfErr)
+	}
+	if d.Get("ca_certificate").(string) == "" {
+		return nil
+	}
+	fingerprint, err := x509CertificateFingerprint(d.Get("ca_certificate").(string))
+	if err != nil {
+		return err
+	}
+	if fingerprint != normalizeX509Fingerprint(jsonData.CaCertificateFingerprint) {
+		if tfErr := d.Set("ca_certificate", ""); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package bastion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFillConfigX509UserCA(t *testing.T) {
+	certDER, _ := testConfigX509Certificate(t)
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	sum := sha256.Sum256(certDER)
+	fingerprint := hex.EncodeToString(sum[:])
+	// the same fingerprint as displayed by openssl
+	colonFingerprint := make([]string, len(sum))
+	for i, b := range sum {
+		colonFingerprint[i] = strings.ToUpper(hex.EncodeToString([]byte{b}))
+	}
+
+	tests := map[string]struct {
+		apiFingerprint string
+		expectedCA     string
+	}{
+		"same CA": {
+			apiFingerprint: fingerprint,
+			expectedCA:     certPEM,
+		},
+		"same CA with colons": {
+			apiFingerprint: strings.Join(colonFingerprint, ":"),
+			expectedCA:     certPEM,
+		},
+		"other CA": {
+			apiFingerprint: strings.Repeat("00", sha256.Size),
+		},
+		"no CA": {},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceConfigX509UserCA().Schema, map[string]interface{}{
+				"ca_certificate": certPEM,
+			})
+			err := fillConfigX509UserCA(d, jsonConfigX509{
+				CaCertificate:            "/CN=bastion",
+				CaCertificateFingerprint: tt.apiFingerprint,
+				Enable:                   true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := d.Get("ca_certificate").(string); got != tt.expectedCA {
+				t.Errorf("expected ca_certificate %q, got %q", tt.expectedCA, got)
+			}
+			if got := d.Get("ca_certificate_dn").(string); got != "/CN=bastion" {
+				t.Errorf("expected ca_certificate_dn from the api, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceConfigX509UserCA_basic(t *testing.T) {
+	resourceName := "wallix-bastion_config_x509_user_ca.testacc_ConfigX509UserCA"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"tls": {
+				Source: "hashicorp/tls",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigX509UserCACreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "ca_certificate_fingerprint"),
+					resource.TestCheckResourceAttr(resourceName, "enable", "true"),
+				),
+			},
+			{
+				Config: testAccResourceConfigX509UserCAUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enable", "false"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConfigX509UserCACreate() string {
+	return `
+resource "tls_private_key" "ca" {
+  algorithm = "RSA"
+  rsa_bits  = 4096
+}
+
+resource "tls_self_signed_cert" "ca" {
+  private_key_pem = tls_private_key.ca.private_key_pem
+
+  subject {
+    common_name  = "Wallix Bastion Test User CA"
+    organization = "Wallix Test"
+    country      = "FR"
+  }
+
+  validity_period_hours = 8760 # 1 year
+
+  is_ca_certificate = true
+
+  allowed_uses = [
+    "cert_signing",
+    "crl_signing",
+  ]
+}
+
+resource "wallix-bastion_config_x509_user_ca" "testacc_ConfigX509UserCA" {
+  ca_certificate = tls_self_signed_cert.ca.cert_pem
+}
+`
+}
+
+func testAccResourceConfigX509UserCAUpdate() string {
+	return `
+resource "tls_private_key" "ca" {
+  algorithm = "RSA"
+  rsa_bits  = 4096
+}
+
+resource "tls_self_signed_cert" "ca" {
+  private_key_pem = tls_private_key.ca.private_key_pem
+
+  subject {
+    common_name  = "Wallix Bastion Test User CA"
+    organization = "Wallix Test"
+    country      = "FR"
+  }
+
+  validity_period_hours = 8760 # 1 year
+
+  is_ca_certificate = true
+
+  allowed_uses = [
+    "cert_signing",
+    "crl_signing",
+  ]
+}
+
+resource "wallix-bastion_config_x509_user_ca" "testacc_ConfigX509UserCA" {
+  ca_certificate = tls_self_signed_cert.ca.cert_pem
+  enable         = false
+}
+`
+}
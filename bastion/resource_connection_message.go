@@ -47,23 +47,23 @@ func resourceConnectionMessage() *schema.Resource {
 	}
 }
 
-func resourceConnectionMessageVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceConnectionMessageVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_connection_message not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_connection_message not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceConnectionMessageCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceConnectionMessageVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceConnectionMessageVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateConnectionMessage(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.SetId(d.Get("message_name").(string))
 
@@ -74,12 +74,12 @@ func resourceConnectionMessageRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceConnectionMessageVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceConnectionMessageVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readConnectionMessage(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	cfg.MessageName = d.Get("message_name").(string)
 	fillConnectionMessage(d, cfg)
@@ -92,11 +92,11 @@ func resourceConnectionMessageUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceConnectionMessageVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceConnectionMessageVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateConnectionMessage(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -116,7 +116,7 @@ func resourceConnectionMessageImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceConnectionMessageVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceConnectionMessageVersionCheck(c); err != nil {
 		return nil, err
 	}
 	cfg, err := readConnectionMessage(ctx, d.Id(), m)
@@ -145,7 +145,7 @@ func updateConnectionMessage(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -174,7 +174,7 @@ func readConnectionMessage(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"slices"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -23,6 +24,75 @@ type jsonConnectionPolicy struct {
 	AuthenticationMethods []string               `json:"authentication_methods"`
 }
 
+// connectionPolicyOption maps an attribute of a typed options block to a key of a section of the options.
+type connectionPolicyOption struct {
+	section   string
+	key       string
+	valueType schema.ValueType
+}
+
+// connectionPolicyOptionsBlocks returns the protocol of each typed options block.
+func connectionPolicyOptionsBlocks() map[string]string {
+	return map[string]string{
+		"ssh": "SSH",
+		"rdp": "RDP",
+	}
+}
+
+func connectionPolicyBlockOptions(block string) map[string]connectionPolicyOption {
+	options := map[string]connectionPolicyOption{
+		"transformation_rule":       {section: "general", key: "transformation_rule", valueType: schema.TypeString},
+		"vault_transformation_rule": {section: "general", key: "vault_transformation_rule", valueType: schema.TypeString},
+		"store_file":                {section: "file_storage", key: "store_file", valueType: schema.TypeString},
+		"file_verification_up":      {section: "file_verification", key: "enable_up", valueType: schema.TypeBool},
+		"file_verification_down":    {section: "file_verification", key: "enable_down", valueType: schema.TypeBool},
+	}
+	switch block {
+	case "ssh":
+		options["inactivity_timeout"] = connectionPolicyOption{
+			section: "session", key: "inactivity_timeout", valueType: schema.TypeInt,
+		}
+		options["allow_multi_channels"] = connectionPolicyOption{
+			section: "session", key: "allow_multi_channels", valueType: schema.TypeBool,
+		}
+		options["log_all_kbd"] = connectionPolicyOption{
+			section: "trace", key: "log_all_kbd", valueType: schema.TypeBool,
+		}
+		for _, v := range []string{"kex_algos", "cipher_algos", "integrity_algos", "hostkey_algos"} {
+			options[v] = connectionPolicyOption{section: "algorithms", key: v, valueType: schema.TypeString}
+		}
+	case "rdp":
+		options["enable_session_probe"] = connectionPolicyOption{
+			section: "session_probe", key: "enable_session_probe", valueType: schema.TypeBool,
+		}
+	}
+
+	return options
+}
+
+func connectionPolicyOptionsBlockSchema(block string) *schema.Schema {
+	blockSchema := make(map[string]*schema.Schema)
+	for name, option := range connectionPolicyBlockOptions(block) {
+		blockSchema[name] = &schema.Schema{
+			Type:     option.valueType,
+			Optional: true,
+			Computed: true,
+		}
+	}
+	blockSchema["store_file"].ValidateFunc = validation.StringInSlice(
+		[]string{"never", "always", "on_invalid_verification"}, false)
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"options"},
+		Elem: &schema.Resource{
+			Schema: blockSchema,
+		},
+	}
+}
+
 func resourceConnectionPolicy() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceConnectionPolicyCreate,
@@ -32,6 +102,7 @@ func resourceConnectionPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceConnectionPolicyImport,
 		},
+		CustomizeDiff: resourceConnectionPolicyCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"connection_policy_name": {
 				Type:     schema.TypeString,
@@ -69,42 +140,60 @@ func resourceConnectionPolicy() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringIsJSON,
 			},
+			"ssh": connectionPolicyOptionsBlockSchema("ssh"),
+			"rdp": connectionPolicyOptionsBlockSchema("rdp"),
 		},
 	}
 }
 
-func resourceConnectionPolicyVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+// resourceConnectionPolicyCustomizeDiff rejects a typed options block which doesn't match the protocol.
+func resourceConnectionPolicyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("protocol") {
 		return nil
 	}
+	protocol := d.Get("protocol").(string)
+	for block, blockProtocol := range connectionPolicyOptionsBlocks() {
+		if len(d.Get(block).([]interface{})) > 0 && protocol != blockProtocol {
+			return fmt.Errorf("%s block can only be set with protocol = %s", block, blockProtocol)
+		}
+	}
 
-	return fmt.Errorf("resource wallix-bastion_connection_policy not available with api version %s", version)
+	return nil
+}
+
+func resourceConnectionPolicyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_connection_policy not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceConnectionPolicyCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceConnectionPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceConnectionPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceConnectionPolicy(ctx, d.Get("connection_policy_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("connection_policy_name %s already exists", d.Get("connection_policy_name").(string)))
+		return diagFromAPIError(fmt.Errorf("connection_policy_name %s already exists",
+			d.Get("connection_policy_name").(string)))
 	}
 	err = addConnectionPolicy(ctx, d, m, c.bastionAPIVersion)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceConnectionPolicy(ctx, d.Get("connection_policy_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("connection_policy_name %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("connection_policy_name %s not found after POST",
 			d.Get("connection_policy_name").(string)))
 	}
 	d.SetId(id)
@@ -116,12 +205,12 @@ func resourceConnectionPolicyRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceConnectionPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceConnectionPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readConnectionPolicyOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -137,11 +226,11 @@ func resourceConnectionPolicyUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceConnectionPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceConnectionPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateConnectionPolicy(ctx, d, m, c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -152,11 +241,11 @@ func resourceConnectionPolicyDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceConnectionPolicyVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceConnectionPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteConnectionPolicy(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -169,7 +258,7 @@ func resourceConnectionPolicyImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceConnectionPolicyVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceConnectionPolicyVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceConnectionPolicy(ctx, d.Id(), m)
@@ -197,13 +286,13 @@ func searchResourceConnectionPolicy(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
+	body, code, err := c.newRequestPaged(ctx,
 		"/connectionpolicies/?q=connection_policy_name="+connectionPolicyName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonConnectionPolicy
 	err = json.Unmarshal([]byte(body), &results)
@@ -230,7 +319,7 @@ func addConnectionPolicy(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -249,7 +338,7 @@ func updateConnectionPolicy(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -264,7 +353,7 @@ func deleteConnectionPolicy(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -304,12 +393,53 @@ func prepareConnectionPolicyJSON(
 		_ = json.Unmarshal([]byte(v), &options)
 	} else {
 		_ = json.Unmarshal([]byte(`{}`), &options)
+		prepareConnectionPolicyBlockOptions(d, options)
 	}
 	jsonData.Options = options
 
 	return jsonData, nil
 }
 
+// prepareConnectionPolicyBlockOptions adds to options the attributes set in the typed options blocks,
+// so the options not configured keep the value of the Bastion.
+func prepareConnectionPolicyBlockOptions(d *schema.ResourceData, options map[string]interface{}) {
+	for block := range connectionPolicyOptionsBlocks() {
+		listBlock := d.Get(block).([]interface{})
+		if len(listBlock) == 0 || listBlock[0] == nil {
+			continue
+		}
+		values := listBlock[0].(map[string]interface{})
+		for name, option := range connectionPolicyBlockOptions(block) {
+			if !connectionPolicyBlockOptionConfigured(d, block, name) {
+				continue
+			}
+			section, ok := options[option.section].(map[string]interface{})
+			if !ok {
+				section = make(map[string]interface{})
+				options[option.section] = section
+			}
+			section[option.key] = values[name]
+		}
+	}
+}
+
+// connectionPolicyBlockOptionConfigured returns true if the attribute of the typed options block
+// is in the configuration, the attributes are computed so the value of an unset attribute isn't sent.
+func connectionPolicyBlockOptionConfigured(d *schema.ResourceData, block, name string) bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		_, ok := d.GetOk(block + ".0." + name)
+
+		return ok
+	}
+	rawBlock := rawConfig.GetAttr(block)
+	if rawBlock.IsNull() || !rawBlock.IsKnown() || rawBlock.LengthInt() == 0 {
+		return false
+	}
+
+	return !rawBlock.Index(cty.NumberIntVal(0)).GetAttr(name).IsNull()
+}
+
 func validAuthenticationMethods() []string {
 	return []string{
 		"KERBEROS_FORWARDING",
@@ -336,7 +466,7 @@ func readConnectionPolicyOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -368,8 +498,40 @@ func fillConnectionPolicy(d *schema.ResourceData, jsonData jsonConnectionPolicy)
 	if tfErr := d.Set("authentication_methods", jsonData.AuthenticationMethods); tfErr != nil {
 		panic(tfErr)
 	}
+	// the typed options block replaces options when it's used
+	for block := range connectionPolicyOptionsBlocks() {
+		if len(d.Get(block).([]interface{})) > 0 {
+			if tfErr := d.Set(block, []interface{}{readConnectionPolicyBlockOptions(block, jsonData.Options)}); tfErr != nil {
+				panic(tfErr)
+			}
+			if tfErr := d.Set("options", ""); tfErr != nil {
+				panic(tfErr)
+			}
+
+			return
+		}
+	}
 	options, _ := json.Marshal(jsonData.Options) //nolint: errchkjson
 	if tfErr := d.Set("options", string(options)); tfErr != nil {
 		panic(tfErr)
 	}
 }
+
+func readConnectionPolicyBlockOptions(block string, options map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+	for name, option := range connectionPolicyBlockOptions(block) {
+		section, _ := options[option.section].(map[string]interface{})
+		switch option.valueType {
+		case schema.TypeBool:
+			values[name], _ = section[option.key].(bool)
+		case schema.TypeInt:
+			// numbers are decoded as float64 in the options
+			v, _ := section[option.key].(float64)
+			values[name] = int(v)
+		default:
+			values[name], _ = section[option.key].(string)
+		}
+	}
+
+	return values
+}
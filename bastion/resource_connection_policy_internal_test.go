@@ -0,0 +1,129 @@
+package bastion
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceConnectionPolicyCustomizeDiff(t *testing.T) {
+	tests := map[string]struct {
+		config   map[string]interface{}
+		errMatch string
+	}{
+		"ssh block with SSH": {
+			config: map[string]interface{}{
+				"protocol": "SSH",
+				"ssh":      []interface{}{map[string]interface{}{"log_all_kbd": true}},
+			},
+		},
+		"rdp block with RDP": {
+			config: map[string]interface{}{
+				"protocol": "RDP",
+				"rdp":      []interface{}{map[string]interface{}{"enable_session_probe": true}},
+			},
+		},
+		"ssh block with RDP": {
+			config: map[string]interface{}{
+				"protocol": "RDP",
+				"ssh":      []interface{}{map[string]interface{}{"log_all_kbd": true}},
+			},
+			errMatch: "ssh block can only be set with protocol = SSH",
+		},
+		"rdp block with VNC": {
+			config: map[string]interface{}{
+				"protocol": "VNC",
+				"rdp":      []interface{}{map[string]interface{}{"store_file": "always"}},
+			},
+			errMatch: "rdp block can only be set with protocol = RDP",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"connection_policy_name": "policy",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			_, err := resourceConnectionPolicy().Diff(
+				context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.errMatch == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMatch) {
+				t.Fatalf("expected error matching %q, got %v", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestPrepareConnectionPolicyJSONBlockOptions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConnectionPolicy().Schema, map[string]interface{}{
+		"connection_policy_name": "policy",
+		"protocol":               "SSH",
+		"ssh": []interface{}{map[string]interface{}{
+			"inactivity_timeout": 600,
+			"log_all_kbd":        true,
+			"store_file":         "always",
+		}},
+	})
+	jsonData, err := prepareConnectionPolicyJSON(d, true, VersionWallixAPI312)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"session":      map[string]interface{}{"inactivity_timeout": 600},
+		"trace":        map[string]interface{}{"log_all_kbd": true},
+		"file_storage": map[string]interface{}{"store_file": "always"},
+	}
+	if !reflect.DeepEqual(jsonData.Options, expected) {
+		t.Errorf("expected options %v, got %v", expected, jsonData.Options)
+	}
+}
+
+func TestFillConnectionPolicyBlockOptions(t *testing.T) {
+	jsonData := jsonConnectionPolicy{
+		ID:                   "id",
+		ConnectionPolicyName: "policy",
+		Protocol:             "SSH",
+		Options: map[string]interface{}{
+			"session":    map[string]interface{}{"inactivity_timeout": float64(600)},
+			"trace":      map[string]interface{}{"log_all_kbd": true},
+			"algorithms": map[string]interface{}{"kex_algos": "curve25519-sha256"},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceConnectionPolicy().Schema, map[string]interface{}{
+		"connection_policy_name": "policy",
+		"protocol":               "SSH",
+		"ssh":                    []interface{}{map[string]interface{}{"log_all_kbd": true}},
+	})
+	fillConnectionPolicy(d, jsonData)
+	if v := d.Get("ssh.0.inactivity_timeout").(int); v != 600 {
+		t.Errorf("expected inactivity_timeout 600 from the options, got %d", v)
+	}
+	if v := d.Get("ssh.0.kex_algos").(string); v != "curve25519-sha256" {
+		t.Errorf("expected kex_algos from the options, got %q", v)
+	}
+	if v := d.Get("options").(string); v != "" {
+		t.Errorf("expected options to be empty with the ssh block, got %q", v)
+	}
+
+	d = resourceConnectionPolicy().TestResourceData()
+	fillConnectionPolicy(d, jsonData)
+	if v := d.Get("ssh").([]interface{}); len(v) != 0 {
+		t.Errorf("expected no ssh block without the block in state, got %v", v)
+	}
+	if v := d.Get("options").(string); !strings.Contains(v, `"log_all_kbd":true`) {
+		t.Errorf("expected options from the api, got %q", v)
+	}
+}
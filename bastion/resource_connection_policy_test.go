@@ -1,6 +1,7 @@
 package bastion_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -32,6 +33,45 @@ func TestAccResourceConnectionPolicy_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceConnectionPolicy_sshBlock(t *testing.T) {
+	resourceName := "wallix-bastion_connection_policy.testacc_ConnectionPolicySSHBlock"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConnectionPolicySSHBlock(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ssh.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "ssh.0.log_all_kbd", "false"),
+					resource.TestCheckResourceAttr(resourceName, "options", ""),
+				),
+			},
+			{
+				Config: testAccResourceConnectionPolicySSHBlock(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ssh.0.log_all_kbd", "true"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceConnectionPolicySSHBlock(logAllKbd bool) string {
+	return fmt.Sprintf(`
+resource "wallix-bastion_connection_policy" "testacc_ConnectionPolicySSHBlock" {
+  connection_policy_name = "testacc_ConnectionPolicySSHBlock"
+  protocol               = "SSH"
+  authentication_methods = ["PASSWORD_VAULT"]
+  ssh {
+    log_all_kbd = %t
+    store_file  = "never"
+  }
+}
+`, logAllKbd)
+}
+
 // nolint: lll, nolintlint
 func testAccResourceConnectionPolicyCreate() string {
 	return `
@@ -0,0 +1,322 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonDataTransferLimit struct {
+	ID                string   `json:"id,omitempty"`
+	LimitName         string   `json:"limit_name"`
+	Description       string   `json:"description"`
+	MaxDownloadBytes  int      `json:"max_download_bytes,omitempty"`
+	MaxUploadBytes    int      `json:"max_upload_bytes,omitempty"`
+	MaxFileSizeBytes  int      `json:"max_file_size_bytes,omitempty"`
+	BlockedExtensions []string `json:"blocked_extensions"`
+}
+
+func resourceDataTransferLimit() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDataTransferLimitCreate,
+		ReadContext:   resourceDataTransferLimitRead,
+		UpdateContext: resourceDataTransferLimitUpdate,
+		DeleteContext: resourceDataTransferLimitDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDataTransferLimitImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"limit_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_download_bytes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_upload_bytes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_file_size_bytes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"blocked_extensions": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\.\S+$`),
+						"must start with a '.' character (e.g. '.exe')"),
+				},
+			},
+		},
+	}
+}
+
+func resourceDataTransferLimitVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_data_transfer_limit not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceDataTransferLimitCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDataTransferLimitVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceDataTransferLimit(ctx, d.Get("limit_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("limit_name %s already exists", d.Get("limit_name").(string)))
+	}
+	err = addDataTransferLimit(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceDataTransferLimit(ctx, d.Get("limit_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("limit_name %s not found after POST", d.Get("limit_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceDataTransferLimitRead(ctx, d, m)
+}
+
+func resourceDataTransferLimitRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDataTransferLimitVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readDataTransferLimitOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillDataTransferLimit(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceDataTransferLimitUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceDataTransferLimitVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateDataTransferLimit(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceDataTransferLimitRead(ctx, d, m)
+}
+
+func resourceDataTransferLimitDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDataTransferLimitVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteDataTransferLimit(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceDataTransferLimitImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceDataTransferLimitVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceDataTransferLimit(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find limit_name with id %s (id must be <limit_name>)", d.Id())
+	}
+	cfg, err := readDataTransferLimitOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillDataTransferLimit(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceDataTransferLimit(
+	ctx context.Context, limitName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/datatransferlimits/?q=limit_name="+limitName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonDataTransferLimit
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addDataTransferLimit(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareDataTransferLimitJSON(d)
+	body, code, err := c.newRequest(ctx, "/datatransferlimits/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateDataTransferLimit(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareDataTransferLimitJSON(d)
+	body, code, err := c.newRequest(ctx, "/datatransferlimits/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteDataTransferLimit(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/datatransferlimits/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareDataTransferLimitJSON(d *schema.ResourceData) jsonDataTransferLimit {
+	jsonData := jsonDataTransferLimit{
+		LimitName:        d.Get("limit_name").(string),
+		Description:      d.Get("description").(string),
+		MaxDownloadBytes: d.Get("max_download_bytes").(int),
+		MaxUploadBytes:   d.Get("max_upload_bytes").(int),
+		MaxFileSizeBytes: d.Get("max_file_size_bytes").(int),
+	}
+	listBlockedExtensions := d.Get("blocked_extensions").(*schema.Set).List()
+	jsonData.BlockedExtensions = make([]string, len(listBlockedExtensions))
+	for i, v := range listBlockedExtensions {
+		jsonData.BlockedExtensions[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readDataTransferLimitOptions(
+	ctx context.Context, limitID string, m interface{},
+) (
+	jsonDataTransferLimit, error,
+) {
+	c := m.(*Client)
+	var result jsonDataTransferLimit
+	body, code, err := c.newRequest(ctx, "/datatransferlimits/"+limitID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillDataTransferLimit(d *schema.ResourceData, jsonData jsonDataTransferLimit) {
+	if tfErr := d.Set("limit_name", jsonData.LimitName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("max_download_bytes", jsonData.MaxDownloadBytes); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("max_upload_bytes", jsonData.MaxUploadBytes); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("max_file_size_bytes", jsonData.MaxFileSizeBytes); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("blocked_extensions", jsonData.BlockedExtensions); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,69 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceDataTransferLimit_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDataTransferLimitInvalid(),
+				ExpectError: regexp.MustCompile(`must start with a '.' character`),
+			},
+			{
+				Config: testAccResourceDataTransferLimitCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_data_transfer_limit.testacc_DataTransferLimit",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceDataTransferLimitUpdate(),
+			},
+			{
+				ResourceName:  "wallix-bastion_data_transfer_limit.testacc_DataTransferLimit",
+				ImportState:   true,
+				ImportStateId: "testacc_DataTransferLimit",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDataTransferLimitInvalid() string {
+	return `
+resource "wallix-bastion_data_transfer_limit" "testacc_DataTransferLimit" {
+  limit_name         = "testacc_DataTransferLimit"
+  blocked_extensions = ["exe"]
+}
+`
+}
+
+func testAccResourceDataTransferLimitCreate() string {
+	return `
+resource "wallix-bastion_data_transfer_limit" "testacc_DataTransferLimit" {
+  limit_name         = "testacc_DataTransferLimit"
+  max_download_bytes = 104857600
+}
+`
+}
+
+func testAccResourceDataTransferLimitUpdate() string {
+	return `
+resource "wallix-bastion_data_transfer_limit" "testacc_DataTransferLimit" {
+  limit_name          = "testacc_DataTransferLimit"
+  description         = "testacc DataTransferLimit"
+  max_download_bytes  = 104857600
+  max_upload_bytes    = 10485760
+  max_file_size_bytes = 5242880
+  blocked_extensions  = [".exe", ".ps1"]
+}
+`
+}
@@ -133,38 +133,38 @@ func resourceDevice() *schema.Resource {
 	}
 }
 
-func resourceDeviceVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceDeviceVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_device not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceDeviceCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceDevice(ctx, d.Get("device_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("device_name %s already exists", d.Get("device_name").(string)))
+		return diagFromAPIError(fmt.Errorf("device_name %s already exists", d.Get("device_name").(string)))
 	}
 	err = addDevice(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceDevice(ctx, d.Get("device_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("device_name %s not found after POST", d.Get("device_name").(string)))
+		return diagFromAPIError(fmt.Errorf("device_name %s not found after POST", d.Get("device_name").(string)))
 	}
 	d.SetId(id)
 
@@ -175,12 +175,12 @@ func resourceDeviceRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDeviceOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -196,11 +196,11 @@ func resourceDeviceUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateDevice(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -211,11 +211,11 @@ func resourceDeviceDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteDevice(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -228,7 +228,7 @@ func resourceDeviceImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceDeviceVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceDevice(ctx, d.Id(), m)
@@ -256,12 +256,13 @@ func searchResourceDevice(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/?q=device_name="+deviceName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, c.uriWithFields("/devices/?q=device_name="+deviceName, "id"),
+		http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonDevice
 	err = json.Unmarshal([]byte(body), &results)
@@ -285,7 +286,7 @@ func addDevice(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -296,12 +297,23 @@ func updateDevice(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareDeviceJSON(d)
-	body, code, err := c.newRequest(ctx, "/devices/"+d.Id(), http.MethodPut, jsonData)
+	method := http.MethodPut
+	var requestData interface{} = jsonData
+	if patchSupported(c.bastionAPIVersion) {
+		// send only the changed fields to keep the attributes managed outside of Terraform
+		patchData, err := prepareJSONPatch(d, jsonData)
+		if err != nil {
+			return err
+		}
+		method = http.MethodPatch
+		requestData = patchData
+	}
+	body, code, err := c.newRequest(ctx, "/devices/"+d.Id(), method, requestData)
 	if err != nil {
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -316,7 +328,7 @@ func deleteDevice(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -346,7 +358,7 @@ func readDeviceOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
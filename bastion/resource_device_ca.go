@@ -0,0 +1,248 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonDeviceCA struct {
+	CaCertificate string `json:"ca_certificate"`
+	// only returned by the API
+	CaCertificateDN          string `json:"ca_certificate_dn,omitempty"`
+	CaCertificateFingerprint string `json:"ca_certificate_fingerprint,omitempty"`
+}
+
+func resourceDeviceCA() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeviceCACreate,
+		ReadContext:   resourceDeviceCARead,
+		UpdateContext: resourceDeviceCAUpdate,
+		DeleteContext: resourceDeviceCADelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeviceCAImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ca_certificate": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.All(validatePEM("CERTIFICATE"), validateX509CACertificate),
+			},
+			"ca_certificate_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ca_certificate_dn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDeviceCAVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_device_ca not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceDeviceCACreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceCAVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	// the certificate can be unknown during the validation of the configuration
+	if err := checkConfigX509CACertificate(d.Get("ca_certificate").(string)); err != nil {
+		return diagFromAPIError(err)
+	}
+	deviceID := d.Get("device_id").(string)
+	cfg, err := readDeviceCAOptions(ctx, deviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.CaCertificateFingerprint != "" {
+		return diagFromAPIError(fmt.Errorf("trusted CA of device_id %s already exists", deviceID))
+	}
+	if err := updateDeviceCA(ctx, deviceID, d.Get("ca_certificate").(string), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(deviceID)
+
+	return resourceDeviceCARead(ctx, d, m)
+}
+
+func resourceDeviceCARead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceCAVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readDeviceCAOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.CaCertificateFingerprint == "" {
+		d.SetId("")
+
+		return nil
+	}
+	if err := fillDeviceCA(d, cfg); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceDeviceCAUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceDeviceCAVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := checkConfigX509CACertificate(d.Get("ca_certificate").(string)); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateDeviceCA(ctx, d.Id(), d.Get("ca_certificate").(string), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceDeviceCARead(ctx, d, m)
+}
+
+func resourceDeviceCADelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceCAVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteDeviceCA(ctx, d.Id(), m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceDeviceCAImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceDeviceCAVersionCheck(c); err != nil {
+		return nil, err
+	}
+	cfg, err := readDeviceCAOptions(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CaCertificateFingerprint == "" {
+		return nil, fmt.Errorf("don't find trusted CA with id %s (id must be <device_id>)", d.Id())
+	}
+	if err := fillDeviceCA(d, cfg); err != nil {
+		return nil, err
+	}
+	if tfErr := d.Set("device_id", d.Id()); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readDeviceCAOptions(
+	ctx context.Context, deviceID string, m interface{},
+) (
+	jsonDeviceCA, error,
+) {
+	c := m.(*Client)
+	var result jsonDeviceCA
+	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/ca", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateDeviceCA(ctx context.Context, deviceID, caCertificate string, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/ca", http.MethodPut,
+		jsonDeviceCA{CaCertificate: caCertificate})
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteDeviceCA(ctx context.Context, deviceID string, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/ca", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+// fillDeviceCA compares the CA by fingerprint like fillConfigX509UserCA,
+// a different CA on the device empties ca_certificate to plan an update.
+func fillDeviceCA(d *schema.ResourceData, jsonData jsonDeviceCA) error {
+	fingerprint := normalizeX509Fingerprint(jsonData.CaCertificateFingerprint)
+	if tfErr := d.Set("ca_certificate_dn", jsonData.CaCertificateDN); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ca_certificate_fingerprint", fingerprint); tfErr != nil {
+		panic(tfErr)
+	}
+	if d.Get("ca_certificate").(string) == "" {
+		return nil
+	}
+	expected, err := x509CertificateFingerprint(d.Get("ca_certificate").(string))
+	if err != nil {
+		return err
+	}
+	if expected != fingerprint {
+		if tfErr := d.Set("ca_certificate", ""); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package bastion
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testDeviceCACertificate returns a self-signed PEM certificate with the common name device CA.
+func testDeviceCACertificate(t *testing.T, isCA bool) (string, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "device CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %s", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})), certDER
+}
+
+func TestResourceDeviceCAValidate(t *testing.T) {
+	caPEM, _ := testDeviceCACertificate(t, true)
+	leafPEM, _ := testDeviceCACertificate(t, false)
+	tests := map[string]struct {
+		caCertificate string
+		errMatch      string
+	}{
+		"valid": {
+			caCertificate: caPEM,
+		},
+		"not PEM": {
+			caCertificate: "MIIBkTCB+wIJAKHBfpegPjMCMA0GCSqGSIb3DQEBCwUAMBExDzANBgNVBAMMBnRlc3Rj",
+			errMatch:      "ca_certificate is not a PEM encoded value",
+		},
+		"truncated certificate": {
+			caCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a certificate")})),
+			errMatch:      "parsing ca_certificate",
+		},
+		"key": {
+			caCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")})),
+			errMatch:      `ca_certificate has a PEM block of type "EC PRIVATE KEY", expected CERTIFICATE`,
+		},
+		"leaf certificate": {
+			caCertificate: leafPEM,
+			errMatch:      "ca_certificate (CN=device CA) is not a CA certificate",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := resourceDeviceCA().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"device_id":      "d1",
+				"ca_certificate": tt.caCertificate,
+			}))
+			if tt.errMatch == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+
+				return
+			}
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.errMatch) {
+				t.Fatalf("expected error matching %q, got %v", tt.errMatch, diags)
+			}
+		})
+	}
+}
+
+func TestResourceDeviceCACreate(t *testing.T) {
+	caPEM, caDER := testDeviceCACertificate(t, true)
+	sum := sha256.Sum256(caDER)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+	tests := map[string]struct {
+		existing   string
+		expectedCA string
+		errMatch   string
+	}{
+		"new CA": {
+			expectedCA: caPEM,
+		},
+		"existing CA": {
+			existing: strings.Repeat("00", sha256.Size),
+			errMatch: "trusted CA of device_id d1 already exists",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			current := jsonDeviceCA{CaCertificateFingerprint: tt.existing}
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v3.12/devices/d1/ca" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				switch r.Method {
+				case http.MethodGet:
+					if current.CaCertificateFingerprint == "" {
+						w.WriteHeader(http.StatusNotFound)
+
+						return
+					}
+					_ = json.NewEncoder(w).Encode(current)
+				case http.MethodPut:
+					var jsonData jsonDeviceCA
+					if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+						t.Errorf("decoding request: %s", err)
+					}
+					if jsonData.CaCertificate != caPEM {
+						t.Errorf("unexpected request %v", jsonData)
+					}
+					current = jsonDeviceCA{CaCertificateDN: "/CN=device CA", CaCertificateFingerprint: fingerprint}
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusMethodNotAllowed)
+				}
+			})
+			d := schema.TestResourceDataRaw(t, resourceDeviceCA().Schema, map[string]interface{}{
+				"device_id":      "d1",
+				"ca_certificate": caPEM,
+			})
+			diags := resourceDeviceCACreate(context.Background(), d, c)
+			if tt.errMatch != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", tt.errMatch, diags)
+				}
+
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != "d1" || d.Get("ca_certificate").(string) != tt.expectedCA ||
+				d.Get("ca_certificate_fingerprint").(string) != strings.ToLower(fingerprint) ||
+				d.Get("ca_certificate_dn").(string) != "/CN=device CA" {
+				t.Errorf("unexpected state after creation: %v", d.State())
+			}
+			// a CA replaced outside of Terraform is planned for update
+			current.CaCertificateFingerprint = strings.Repeat("00", sha256.Size)
+			if diags := resourceDeviceCARead(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Get("ca_certificate").(string) != "" {
+				t.Errorf("expected ca_certificate to be emptied after a drift, got %q", d.Get("ca_certificate"))
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceDeviceCA_basic(t *testing.T) {
+	resourceName := "wallix-bastion_device_ca.testacc_DeviceCA"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"tls": {
+				Source: "hashicorp/tls",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceCAConfig(
+					`"-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydA==\n-----END CERTIFICATE-----\n"`),
+				ExpectError: regexp.MustCompile(`parsing ca_certificate`),
+			},
+			{
+				Config: testAccResourceDeviceCAConfig("tls_self_signed_cert.testacc_DeviceCA1.cert_pem"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "ca_certificate_fingerprint"),
+				),
+			},
+			{
+				Config: testAccResourceDeviceCAConfig("tls_self_signed_cert.testacc_DeviceCA2.cert_pem"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "ca_certificate",
+						"tls_self_signed_cert.testacc_DeviceCA2", "cert_pem"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ca_certificate"},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceCAConfig(caCertificate string) string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceCA" {
+  device_name = "testacc_DeviceCA"
+  host        = "testacc_deviceca.device"
+}
+
+resource "tls_private_key" "testacc_DeviceCA" {
+  algorithm = "RSA"
+  rsa_bits  = 4096
+}
+
+resource "tls_self_signed_cert" "testacc_DeviceCA1" {
+  private_key_pem = tls_private_key.testacc_DeviceCA.private_key_pem
+
+  subject {
+    common_name = "testacc_DeviceCA1"
+  }
+
+  validity_period_hours = 8760
+  is_ca_certificate     = true
+  allowed_uses          = ["cert_signing"]
+}
+
+resource "tls_self_signed_cert" "testacc_DeviceCA2" {
+  private_key_pem = tls_private_key.testacc_DeviceCA.private_key_pem
+
+  subject {
+    common_name = "testacc_DeviceCA2"
+  }
+
+  validity_period_hours = 8760
+  is_ca_certificate     = true
+  allowed_uses          = ["cert_signing"]
+}
+
+resource "wallix-bastion_device_ca" "testacc_DeviceCA" {
+  device_id      = wallix-bastion_device.testacc_DeviceCA.id
+  ca_certificate = ` + caCertificate + `
+}
+`
+}
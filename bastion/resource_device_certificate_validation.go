@@ -0,0 +1,382 @@
+package bastion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type jsonDeviceCertificateValidation struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	// only returned by the API
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+func resourceDeviceCertificateValidation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeviceCertificateValidationCreate,
+		ReadContext:   resourceDeviceCertificateValidationRead,
+		UpdateContext: resourceDeviceCertificateValidationUpdate,
+		DeleteContext: resourceDeviceCertificateValidationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeviceCertificateValidationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"service_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ssh_host_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"ssh_host_key", "certificate"},
+				ValidateFunc: validateSSHPublicKey,
+			},
+			"certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"ssh_host_key", "certificate"},
+				ValidateFunc: validatePEM("CERTIFICATE"),
+			},
+			"fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// validateSSHPublicKey checks that the value is a public key in the authorized_keys format
+// (<key type> <base64 key> [comment]).
+func validateSSHPublicKey(val interface{}, key string) ([]string, []error) {
+	if _, err := sshPublicKeyFingerprint(val.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q %w", key, err)}
+	}
+
+	return nil, nil
+}
+
+// sshPublicKeyFingerprint returns the fingerprint of a public key in the authorized_keys format
+// like ssh-keygen -l (SHA256:<unpadded base64 of the hash>).
+func sshPublicKeyFingerprint(publicKey string) (string, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", errors.New("must be a public key like '<key type> <base64 key>'")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("must be a public key with a valid base64 key: %w", err)
+	}
+	sum := sha256.Sum256(blob)
+
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
+
+func resourceDeviceCertificateValidationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_device_certificate_validation not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceDeviceCertificateValidationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceCertificateValidationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	serviceID, err := checkDeviceCertificateValidationService(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readDeviceCertificateValidationOptions(ctx, d.Get("device_id").(string), serviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.Value != "" {
+		return diagFromAPIError(fmt.Errorf("certificate validation of service_name %s on device_id %s already exists",
+			d.Get("service_name").(string), d.Get("device_id").(string)))
+	}
+	if err := updateDeviceCertificateValidation(ctx, serviceID, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(serviceID)
+
+	return resourceDeviceCertificateValidationRead(ctx, d, m)
+}
+
+func resourceDeviceCertificateValidationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceCertificateValidationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readDeviceCertificateValidationOptions(ctx, d.Get("device_id").(string), d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.Value == "" {
+		d.SetId("")
+	} else {
+		fillDeviceCertificateValidation(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceDeviceCertificateValidationUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceDeviceCertificateValidationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if _, err := checkDeviceCertificateValidationService(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateDeviceCertificateValidation(ctx, d.Id(), d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceDeviceCertificateValidationRead(ctx, d, m)
+}
+
+func resourceDeviceCertificateValidationDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceCertificateValidationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteDeviceCertificateValidation(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceDeviceCertificateValidationImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceDeviceCertificateValidationVersionCheck(c); err != nil {
+		return nil, err
+	}
+	idSplit := strings.Split(d.Id(), "/")
+	if len(idSplit) != 2 {
+		return nil, errors.New("id must be <device_id>/<service_name>")
+	}
+	id, ex, err := searchResourceDeviceService(ctx, idSplit[0], idSplit[1], m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find service_name with id %s (id must be <device_id>/<service_name>)", d.Id())
+	}
+	cfg, err := readDeviceCertificateValidationOptions(ctx, idSplit[0], id, m)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Value == "" {
+		return nil, fmt.Errorf("don't find certificate validation with id %s (id must be <device_id>/<service_name>)",
+			d.Id())
+	}
+	fillDeviceCertificateValidation(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	if tfErr := d.Set("device_id", idSplit[0]); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("service_name", idSplit[1]); tfErr != nil {
+		panic(tfErr)
+	}
+	result[0] = d
+
+	return result, nil
+}
+
+// checkDeviceCertificateValidationService returns the ID of the service
+// after checking that its protocol uses the configured type of key.
+func checkDeviceCertificateValidationService(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) (
+	string, error,
+) {
+	deviceID := d.Get("device_id").(string)
+	serviceName := d.Get("service_name").(string)
+	serviceID, ex, err := searchResourceDeviceService(ctx, deviceID, serviceName, m)
+	if err != nil {
+		return "", err
+	}
+	if !ex {
+		return "", fmt.Errorf("service_name %s on device_id %s doesn't exists", serviceName, deviceID)
+	}
+	cfg, err := readDeviceServiceOptions(ctx, deviceID, serviceID, m)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case d.Get("ssh_host_key").(string) != "" && cfg.Protocol != "SSH":
+		return "", fmt.Errorf("ssh_host_key can only be set for a SSH service, not for %s service", cfg.Protocol)
+	case d.Get("certificate").(string) != "" && cfg.Protocol != "RDP":
+		return "", fmt.Errorf("certificate can only be set for a RDP service, not for %s service", cfg.Protocol)
+	}
+
+	return serviceID, nil
+}
+
+// updateDeviceCertificateValidation pushes the expected key and fails if the fingerprint computed
+// by the Bastion doesn't match the configured key.
+func updateDeviceCertificateValidation(
+	ctx context.Context, serviceID string, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData, fingerprint, err := prepareDeviceCertificateValidationJSON(d)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx,
+		"/devices/"+d.Get("device_id").(string)+"/certificates/"+serviceID, http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+	cfg, err := readDeviceCertificateValidationOptions(ctx, d.Get("device_id").(string), serviceID, m)
+	if err != nil {
+		return err
+	}
+
+	return checkDeviceCertificateValidationFingerprint(fingerprint, cfg)
+}
+
+func checkDeviceCertificateValidationFingerprint(expected string, jsonData jsonDeviceCertificateValidation) error {
+	if jsonData.Value == "" {
+		return errors.New("certificate validation not found after PUT")
+	}
+	if normalizeDeviceCertificateValidationFingerprint(jsonData.Type, jsonData.Fingerprint) != expected {
+		return fmt.Errorf("fingerprint %s reported by the Bastion doesn't match the configured key (%s)",
+			jsonData.Fingerprint, expected)
+	}
+
+	return nil
+}
+
+// normalizeDeviceCertificateValidationFingerprint allows the comparison with the fingerprints computed
+// by the provider, the x509 fingerprints can have colons or be in uppercase.
+func normalizeDeviceCertificateValidationFingerprint(keyType, fingerprint string) string {
+	if keyType == "x509" {
+		return normalizeX509Fingerprint(fingerprint)
+	}
+
+	return fingerprint
+}
+
+func deleteDeviceCertificateValidation(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx,
+		"/devices/"+d.Get("device_id").(string)+"/certificates/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+// prepareDeviceCertificateValidationJSON returns the payload and the fingerprint of the configured key.
+func prepareDeviceCertificateValidationJSON(
+	d *schema.ResourceData,
+) (
+	jsonDeviceCertificateValidation, string, error,
+) {
+	if v := d.Get("ssh_host_key").(string); v != "" {
+		fingerprint, err := sshPublicKeyFingerprint(v)
+		if err != nil {
+			return jsonDeviceCertificateValidation{}, "", fmt.Errorf("ssh_host_key %w", err)
+		}
+
+		return jsonDeviceCertificateValidation{Type: "ssh_host_key", Value: v}, fingerprint, nil
+	}
+	v := d.Get("certificate").(string)
+	fingerprint, err := x509CertificateFingerprint(v)
+	if err != nil {
+		return jsonDeviceCertificateValidation{}, "", err
+	}
+
+	return jsonDeviceCertificateValidation{Type: "x509", Value: v}, fingerprint, nil
+}
+
+func readDeviceCertificateValidationOptions(
+	ctx context.Context, deviceID, serviceID string, m interface{},
+) (
+	jsonDeviceCertificateValidation, error,
+) {
+	c := m.(*Client)
+	var result jsonDeviceCertificateValidation
+	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/certificates/"+serviceID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillDeviceCertificateValidation(d *schema.ResourceData, jsonData jsonDeviceCertificateValidation) {
+	sshHostKey, certificate := "", ""
+	if jsonData.Type == "x509" {
+		certificate = jsonData.Value
+	} else {
+		sshHostKey = jsonData.Value
+	}
+	if tfErr := d.Set("ssh_host_key", sshHostKey); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("certificate", certificate); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("fingerprint",
+		normalizeDeviceCertificateValidationFingerprint(jsonData.Type, jsonData.Fingerprint)); tfErr != nil {
+		panic(tfErr)
+	}
+}
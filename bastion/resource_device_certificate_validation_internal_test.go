@@ -0,0 +1,57 @@
+package bastion
+
+import (
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSSHPublicKeyFingerprint(t *testing.T) {
+	// fingerprint from ssh-keygen -l
+	fingerprint, err := sshPublicKeyFingerprint(
+		"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFRxL2UK3SHkjC9tx4OP35JuLrEBlodERo3XtJ5MqM5V test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fingerprint != "SHA256:jJpoID/HpyS25oxXVLulqiLwQoVDrQ7ZEHJtHwQKDTg" {
+		t.Errorf("unexpected fingerprint %s", fingerprint)
+	}
+	if _, err := sshPublicKeyFingerprint("ssh-ed25519"); err == nil {
+		t.Errorf("expected an error without the base64 key")
+	}
+	if _, err := sshPublicKeyFingerprint("ssh-ed25519 not_base64!"); err == nil {
+		t.Errorf("expected an error with an invalid base64 key")
+	}
+}
+
+func TestCheckDeviceCertificateValidationFingerprint(t *testing.T) {
+	certDER, _ := testConfigX509Certificate(t)
+	d := schema.TestResourceDataRaw(t, resourceDeviceCertificateValidation().Schema, map[string]interface{}{
+		"device_id":    "d1",
+		"service_name": "RDP",
+		"certificate":  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+	})
+	jsonData, fingerprint, err := prepareDeviceCertificateValidationJSON(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if jsonData.Type != "x509" {
+		t.Errorf("expected type x509, got %s", jsonData.Type)
+	}
+	// the Bastion can report the fingerprint with colons in uppercase
+	reported := make([]string, 0, len(fingerprint)/2)
+	for i := 0; i < len(fingerprint); i += 2 {
+		reported = append(reported, strings.ToUpper(fingerprint[i:i+2]))
+	}
+	jsonData.Fingerprint = strings.Join(reported, ":")
+	if err := checkDeviceCertificateValidationFingerprint(fingerprint, jsonData); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	jsonData.Fingerprint = "00:11"
+	if err := checkDeviceCertificateValidationFingerprint(fingerprint, jsonData); err == nil ||
+		!strings.Contains(err.Error(), "doesn't match the configured key") {
+		t.Errorf("expected a mismatch error, got %v", err)
+	}
+}
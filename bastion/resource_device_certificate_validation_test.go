@@ -0,0 +1,82 @@
+package bastion_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceDeviceCertificateValidation_basic(t *testing.T) {
+	resourceName := "wallix-bastion_device_certificate_validation.testacc_DeviceCertificateValidation"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDeviceCertificateValidationInvalid(),
+				ExpectError: regexp.MustCompile(`must be a public key like`),
+			},
+			{
+				Config: testAccResourceDeviceCertificateValidationCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "fingerprint",
+						"SHA256:jJpoID/HpyS25oxXVLulqiLwQoVDrQ7ZEHJtHwQKDTg"),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("Resource %s not found", resourceName)
+					}
+					devID := rs.Primary.Attributes["device_id"]
+					if devID == "" {
+						return "", fmt.Errorf("Attribute %s not found:\n%+v", "device_id", rs.Primary.Attributes)
+					}
+
+					return devID + "/testacc_DeviceCertificateValidation", nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceCertificateValidationInvalid() string {
+	return `
+resource "wallix-bastion_device_certificate_validation" "testacc_DeviceCertificateValidation" {
+  device_id    = "unknown"
+  service_name = "testacc_DeviceCertificateValidation"
+  ssh_host_key = "ssh-ed25519"
+}
+`
+}
+
+func testAccResourceDeviceCertificateValidationCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceCertificateValidation" {
+  device_name = "testacc_DeviceCertificateValidation"
+  host        = "testacc_certificate_validation.device"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceCertificateValidation" {
+  device_id         = wallix-bastion_device.testacc_DeviceCertificateValidation.id
+  service_name      = "testacc_DeviceCertificateValidation"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  subprotocols      = ["SSH_SHELL_SESSION"]
+}
+resource "wallix-bastion_device_certificate_validation" "testacc_DeviceCertificateValidation" {
+  device_id    = wallix-bastion_device.testacc_DeviceCertificateValidation.id
+  service_name = wallix-bastion_device_service.testacc_DeviceCertificateValidation.service_name
+  ssh_host_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFRxL2UK3SHkjC9tx4OP35JuLrEBlodERo3XtJ5MqM5V test"
+}
+`
+}
@@ -0,0 +1,251 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonDeviceHostKey struct {
+	HostKey string `json:"host_key"`
+	KeyType string `json:"key_type"`
+	Verify  string `json:"verify"`
+}
+
+func resourceDeviceHostKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeviceHostKeyCreate,
+		ReadContext:   resourceDeviceHostKeyRead,
+		UpdateContext: resourceDeviceHostKeyUpdate,
+		DeleteContext: resourceDeviceHostKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeviceHostKeyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"host_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"key_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ssh-rsa",
+					"ssh-ed25519",
+					"ecdsa-sha2-nistp256",
+					"ecdsa-sha2-nistp384",
+					"ecdsa-sha2-nistp521",
+				}, false),
+			},
+			"verify": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "strict",
+				ValidateFunc: validation.StringInSlice([]string{"strict", "accept_new", "none"}, false),
+			},
+		},
+	}
+}
+
+func resourceDeviceHostKeyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_device_hostkey not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceDeviceHostKeyCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceHostKeyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfgDevice, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfgDevice.ID == "" {
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
+	}
+	cfg, err := readDeviceHostKeyOptions(ctx, d.Get("device_id").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.HostKey != "" {
+		return diagFromAPIError(fmt.Errorf("host key on device_id %s already exists", d.Get("device_id").(string)))
+	}
+	if err := updateDeviceHostKey(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(d.Get("device_id").(string))
+
+	return resourceDeviceHostKeyRead(ctx, d, m)
+}
+
+func resourceDeviceHostKeyRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceHostKeyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readDeviceHostKeyOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.HostKey == "" {
+		d.SetId("")
+	} else {
+		fillDeviceHostKey(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceDeviceHostKeyUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceDeviceHostKeyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateDeviceHostKey(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceDeviceHostKeyRead(ctx, d, m)
+}
+
+func resourceDeviceHostKeyDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceHostKeyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteDeviceHostKey(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceDeviceHostKeyImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceDeviceHostKeyVersionCheck(c); err != nil {
+		return nil, err
+	}
+	cfg, err := readDeviceHostKeyOptions(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.HostKey == "" {
+		return nil, fmt.Errorf("don't find host key with id %s (id must be <device_id>)", d.Id())
+	}
+	fillDeviceHostKey(d, cfg)
+	if tfErr := d.Set("device_id", d.Id()); tfErr != nil {
+		panic(tfErr)
+	}
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+func updateDeviceHostKey(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareDeviceHostKeyJSON(d)
+	body, code, err := c.newRequest(ctx,
+		"/devices/"+d.Get("device_id").(string)+"/hostkey", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteDeviceHostKey(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/devices/"+d.Id()+"/hostkey", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareDeviceHostKeyJSON(d *schema.ResourceData) jsonDeviceHostKey {
+	return jsonDeviceHostKey{
+		HostKey: d.Get("host_key").(string),
+		KeyType: d.Get("key_type").(string),
+		Verify:  d.Get("verify").(string),
+	}
+}
+
+func readDeviceHostKeyOptions(
+	ctx context.Context, deviceID string, m interface{},
+) (
+	jsonDeviceHostKey, error,
+) {
+	c := m.(*Client)
+	var result jsonDeviceHostKey
+	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/hostkey", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillDeviceHostKey(d *schema.ResourceData, jsonData jsonDeviceHostKey) {
+	if tfErr := d.Set("host_key", jsonData.HostKey); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("key_type", jsonData.KeyType); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("verify", jsonData.Verify); tfErr != nil {
+		panic(tfErr)
+	}
+}
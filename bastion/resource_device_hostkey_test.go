@@ -0,0 +1,72 @@
+package bastion_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceDeviceHostKey_basic(t *testing.T) {
+	resourceName := "wallix-bastion_device_hostkey.testacc_DeviceHostKey"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceHostKeyCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						resourceName,
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceDeviceHostKeyUpdate(),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("Resource %s not found", resourceName)
+					}
+
+					return rs.Primary.Attributes["device_id"], nil
+				},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceHostKeyCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceHostKey" {
+  device_name = "testacc_DeviceHostKey"
+  host        = "testacc_hostkey.device"
+}
+resource "wallix-bastion_device_hostkey" "testacc_DeviceHostKey" {
+  device_id = wallix-bastion_device.testacc_DeviceHostKey.id
+  key_type  = "ssh-ed25519"
+  host_key  = "AAAAC3NzaC1lZDI1NTE5AAAAIGIGb8G2RfT6r4+4ZpjWxE39nJ6rBqJ0uxXZWYDV7Vd7"
+}
+`
+}
+
+func testAccResourceDeviceHostKeyUpdate() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceHostKey" {
+  device_name = "testacc_DeviceHostKey"
+  host        = "testacc_hostkey.device"
+}
+resource "wallix-bastion_device_hostkey" "testacc_DeviceHostKey" {
+  device_id = wallix-bastion_device.testacc_DeviceHostKey.id
+  key_type  = "ssh-ed25519"
+  host_key  = "AAAAC3NzaC1lZDI1NTE5AAAAIGIGb8G2RfT6r4+4ZpjWxE39nJ6rBqJ0uxXZWYDV7Vd7"
+  verify    = "accept_new"
+}
+`
+}
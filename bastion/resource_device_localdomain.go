@@ -97,46 +97,46 @@ func resourceDeviceLocalDomain() *schema.Resource {
 	}
 }
 
-func resourceDeviceLocalDomainVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceDeviceLocalDomainVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device_localdomain not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_device_localdomain not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceDeviceLocalDomainCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfgDevice, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDevice.ID == "" {
-		return diag.FromErr(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
 	}
 	_, ex, err := searchResourceDeviceLocalDomain(ctx, d.Get("device_id").(string), d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s on device_id %s already exists",
+		return diagFromAPIError(fmt.Errorf("domain_name %s on device_id %s already exists",
 			d.Get("domain_name").(string), d.Get("device_id").(string)))
 	}
 	err = addDeviceLocalDomain(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceDeviceLocalDomain(ctx, d.Get("device_id").(string), d.Get("domain_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("domain_name %s on device_id %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("domain_name %s on device_id %s not found after POST",
 			d.Get("domain_name").(string), d.Get("device_id").(string)))
 	}
 	d.SetId(id)
@@ -148,12 +148,12 @@ func resourceDeviceLocalDomainRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDeviceLocalDomainOptions(ctx, d.Get("device_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -169,11 +169,11 @@ func resourceDeviceLocalDomainUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateDeviceLocalDomain(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -184,11 +184,11 @@ func resourceDeviceLocalDomainDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteDeviceLocalDomain(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -201,7 +201,7 @@ func resourceDeviceLocalDomainImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceDeviceLocalDomainVersionCheck(c); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -236,13 +236,13 @@ func searchResourceDeviceLocalDomain(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+
+	body, code, err := c.newRequestPaged(ctx, "/devices/"+deviceID+
 		"/localdomains/?q=domain_name="+domainName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonDeviceLocalDomain
 	err = json.Unmarshal([]byte(body), &results)
@@ -267,7 +267,7 @@ func addDeviceLocalDomain(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -284,7 +284,7 @@ func updateDeviceLocalDomain(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -300,7 +300,7 @@ func deleteDeviceLocalDomain(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -358,7 +358,7 @@ func readDeviceLocalDomainOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -109,56 +109,57 @@ func resourceDeviceLocalDomainAccount() *schema.Resource {
 	}
 }
 
-func resourceDeviceLocalDomainAccountVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceDeviceLocalDomainAccountVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device_localdomain_account not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_device_localdomain_account not available with api version %s",
+		c.bastionAPIVersion)
 }
 
 func resourceDeviceLocalDomainAccountCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfgDevice, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDevice.ID == "" {
-		return diag.FromErr(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
 	}
 	cfgDomain, err := readDeviceLocalDomainOptions(ctx, d.Get("device_id").(string), d.Get("domain_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDomain.ID == "" {
-		return diag.FromErr(fmt.Errorf("domain_id with ID %s on device_id %s doesn't exists",
+		return diagFromAPIError(fmt.Errorf("domain_id with ID %s on device_id %s doesn't exists",
 			d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
 	_, ex, err := searchResourceDeviceLocalDomainAccount(ctx,
 		d.Get("device_id").(string), d.Get("domain_id").(string), d.Get("account_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("account_name %s on domain_id %s, device_id %s already exists",
+		return diagFromAPIError(fmt.Errorf("account_name %s on domain_id %s, device_id %s already exists",
 			d.Get("account_name").(string), d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
 	err = addDeviceLocalDomainAccount(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceDeviceLocalDomainAccount(ctx,
 		d.Get("device_id").(string), d.Get("domain_id").(string), d.Get("account_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("account_name %s on domain_id %s, device_id %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("account_name %s on domain_id %s, device_id %s not found after POST",
 			d.Get("account_name").(string), d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
 	d.SetId(id)
@@ -170,13 +171,13 @@ func resourceDeviceLocalDomainAccountRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDeviceLocalDomainAccountOptions(ctx,
 		d.Get("device_id").(string), d.Get("domain_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -192,11 +193,11 @@ func resourceDeviceLocalDomainAccountUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateDeviceLocalDomainAccount(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -207,11 +208,11 @@ func resourceDeviceLocalDomainAccountDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteDeviceLocalDomainAccount(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -224,7 +225,7 @@ func resourceDeviceLocalDomainAccountImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceDeviceLocalDomainAccountVersionCheck(c); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -263,13 +264,13 @@ func searchResourceDeviceLocalDomainAccount(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/localdomains/"+domainID+
+	body, code, err := c.newRequestPaged(ctx, "/devices/"+deviceID+"/localdomains/"+domainID+
 		"/accounts/?q=account_name="+accountName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonDeviceLocalDomainAccount
 	err = json.Unmarshal([]byte(body), &results)
@@ -295,7 +296,7 @@ func addDeviceLocalDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -313,7 +314,7 @@ func updateDeviceLocalDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -330,7 +331,7 @@ func deleteDeviceLocalDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -373,7 +374,7 @@ func readDeviceLocalDomainAccountOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -70,66 +70,66 @@ func resourceDeviceLocalDomainAccountCredential() *schema.Resource {
 	}
 }
 
-func resourceDeviceLocalDomainAccountCredentialVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceDeviceLocalDomainAccountCredentialVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
 	return fmt.Errorf("resource wallix-bastion_device_localdomain_account_credential "+
-		"not available with api version %s", version)
+		"not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceDeviceLocalDomainAccountCredentialCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfgDevice, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDevice.ID == "" {
-		return diag.FromErr(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
 	}
 	cfgDomain, err := readDeviceLocalDomainOptions(ctx, d.Get("device_id").(string), d.Get("domain_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDomain.ID == "" {
-		return diag.FromErr(fmt.Errorf("domain_id with ID %s on device_id %s doesn't exists",
+		return diagFromAPIError(fmt.Errorf("domain_id with ID %s on device_id %s doesn't exists",
 			d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
 	cfgAccount, err := readDeviceLocalDomainAccountOptions(ctx,
 		d.Get("device_id").(string), d.Get("domain_id").(string), d.Get("account_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgAccount.ID == "" {
-		return diag.FromErr(fmt.Errorf("account_id with ID %s on domain_id %s, device_id %s doesn't exists",
+		return diagFromAPIError(fmt.Errorf("account_id with ID %s on domain_id %s, device_id %s doesn't exists",
 			d.Get("account_id").(string), d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
 	_, ex, err := searchResourceDeviceLocalDomainAccountCredential(ctx,
 		d.Get("device_id").(string), d.Get("domain_id").(string), d.Get("account_id").(string), d.Get("type").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("credential type %s on account_id %s, domain_id %s, device_id %s already exists",
+		return diagFromAPIError(fmt.Errorf("credential type %s on account_id %s, domain_id %s, device_id %s already exists",
 			d.Get("type").(string), d.Get("account_id").(string), d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
 	err = addDeviceLocalDomainAccountCredential(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceDeviceLocalDomainAccountCredential(ctx,
 		d.Get("device_id").(string), d.Get("domain_id").(string), d.Get("account_id").(string), d.Get("type").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf(
+		return diagFromAPIError(fmt.Errorf(
 			"credential type %s on account_id %s, domain_id %s, device_id %s not found after POST",
 			d.Get("type").(string), d.Get("account_id").(string), d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
@@ -142,13 +142,13 @@ func resourceDeviceLocalDomainAccountCredentialRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDeviceLocalDomainAccountCredentialOptions(ctx,
 		d.Get("device_id").(string), d.Get("domain_id").(string), d.Get("account_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -164,11 +164,11 @@ func resourceDeviceLocalDomainAccountCredentialUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateDeviceLocalDomainAccountCredential(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -179,11 +179,11 @@ func resourceDeviceLocalDomainAccountCredentialDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteDeviceLocalDomainAccountCredential(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -196,7 +196,7 @@ func resourceDeviceLocalDomainAccountCredentialImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -238,14 +238,14 @@ func searchResourceDeviceLocalDomainAccountCredential(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
+	body, code, err := c.newRequestPaged(ctx,
 		"/devices/"+deviceID+"/localdomains/"+domainID+"/accounts/"+accountID+
 			"/credentials/", http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonCredential
 	err = json.Unmarshal([]byte(body), &results)
@@ -273,7 +273,7 @@ func addDeviceLocalDomainAccountCredential(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -291,7 +291,7 @@ func updateDeviceLocalDomainAccountCredential(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -308,7 +308,7 @@ func deleteDeviceLocalDomainAccountCredential(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -349,7 +349,7 @@ func readDeviceLocalDomainAccountCredentialOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
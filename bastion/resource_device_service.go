@@ -8,20 +8,46 @@ import (
 	"net/http"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/mod/semver"
 )
 
+const (
+	deviceServiceReadyTimeout      = 10 * time.Minute
+	deviceServiceReadyPollInterval = 500 * time.Millisecond
+
+	deviceServiceStatusPending      = "pending"
+	deviceServiceStatusInitializing = "initializing"
+	deviceServiceStatusReady        = "ready"
+
+	deviceServicePrivilegedPortMax = 1023
+)
+
+var errDeviceServiceConflict = errors.New("api returns Conflict")
+
 type jsonDeviceService struct {
-	Port             int       `json:"port"`
-	ID               string    `json:"id,omitempty"`
-	ConnectionPolicy string    `json:"connection_policy"`
-	Protocol         string    `json:"protocol,omitempty"`
-	ServiceName      string    `json:"service_name,omitempty"`
-	GlobalDomains    *[]string `json:"global_domains,omitempty"`
-	SubProtocols     *[]string `json:"subprotocols,omitempty"`
+	Port             int                `json:"port"`
+	ID               string             `json:"id,omitempty"`
+	ConnectionPolicy string             `json:"connection_policy,omitempty"`
+	Protocol         string             `json:"protocol,omitempty"`
+	ServiceName      string             `json:"service_name,omitempty"`
+	GlobalDomains    *[]string          `json:"global_domains,omitempty"`
+	SubProtocols     *[]string          `json:"subprotocols,omitempty"`
+	JumpHost         *string            `json:"jump_host,omitempty"`
+	JumpService      *string            `json:"jump_service,omitempty"`
+	Tags             *map[string]string `json:"tags,omitempty"`
+	TLSEnable        *bool              `json:"tls_enable,omitempty"`
+	TLSMinVersion    *string            `json:"tls_min_version,omitempty"`
+	TLSCiphers       *string            `json:"tls_ciphers,omitempty"`
+	Description      *string            `json:"description,omitempty"`
+	// only returned by the API
+	Status string `json:"status,omitempty"`
 }
 
 func resourceDeviceService() *schema.Resource {
@@ -33,6 +59,13 @@ func resourceDeviceService() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDeviceServiceImport,
 		},
+		CustomizeDiff: resourceDeviceServiceCustomizeDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateDeviceServiceDisruptiveChange,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(deviceServiceReadyTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"device_id": {
 				Type:     schema.TypeString,
@@ -49,9 +82,9 @@ func resourceDeviceService() *schema.Resource {
 				Required: true,
 			},
 			"port": {
-				Type:         schema.TypeInt,
-				Required:     true,
-				ValidateFunc: validation.IntBetween(1, 65535),
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validateDeviceServicePort,
 			},
 			"protocol": {
 				Type:     schema.TypeString,
@@ -73,75 +106,441 @@ func resourceDeviceService() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"jump_host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"jump_service"},
+			},
+			"jump_service": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"jump_host"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tls_enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"tls_min_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"TLSv1.2", "TLSv1.3"}, false),
+			},
+			"tls_ciphers": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"require_disruptive_change_confirmation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"confirm_disruptive_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_ready": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
-func resourceDeviceServiceVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+// validateDeviceServicePort returns an error when the port is out of range
+// and a warning when it's a privileged port (1-1023) without failing the plan.
+func validateDeviceServicePort(i interface{}, path cty.Path) diag.Diagnostics {
+	diags := validation.ToDiagFunc(validation.IntBetween(1, 65535))(i, path)
+	if diags.HasError() {
+		return diags
+	}
+	if port, ok := i.(int); ok && port <= deviceServicePrivilegedPortMax {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Privileged port for the service",
+			Detail: fmt.Sprintf("port %d is a privileged port (1-%d) of the operating system, "+
+				"check it's the port the service listens on.", port, deviceServicePrivilegedPortMax),
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// resourceDeviceServiceCustomizeDiff refuses to change the connection_policy of an existing service
+// with require_disruptive_change_confirmation until confirm_disruptive_change is true,
+// the change can drop the live sessions of the service.
+// The SDK doesn't allow warnings in a CustomizeDiff, so the reminder of the impact is returned
+// by validateDeviceServiceDisruptiveChange and by the update.
+func resourceDeviceServiceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("connection_policy") {
 		return nil
 	}
+	if d.Get("require_disruptive_change_confirmation").(bool) && !d.Get("confirm_disruptive_change").(bool) {
+		oldValue, newValue := d.GetChange("connection_policy")
+
+		return fmt.Errorf("changing connection_policy from %s to %s can interrupt the live sessions of the service, "+
+			"set confirm_disruptive_change = true to allow it", oldValue, newValue)
+	}
+
+	return nil
+}
 
-	return fmt.Errorf("resource wallix-bastion_device_service not available with api version %s", version)
+// validateDeviceServiceDisruptiveChange reminds at plan time that a change of connection_policy
+// can interrupt the live sessions while confirm_disruptive_change is true.
+// The validation doesn't see the state, so it can't tell if connection_policy changes.
+func validateDeviceServiceDisruptiveChange(
+	_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+	confirm := req.RawConfig.GetAttr("confirm_disruptive_change")
+	if !confirm.IsKnown() || confirm.IsNull() || confirm.False() {
+		return
+	}
+	resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Disruptive change of the service confirmed",
+		Detail: "confirm_disruptive_change is true, a change of connection_policy is applied " +
+			"and can interrupt the live sessions of the service. " +
+			"Set confirm_disruptive_change back to false after the change window.",
+		AttributePath: cty.GetAttrPath("confirm_disruptive_change"),
+	})
+}
+
+// resourceDeviceServiceVersionCheck checks the api version,
+// and the protocol of the service if it isn't empty.
+func resourceDeviceServiceVersionCheck(c *Client, protocol string) error {
+	if !slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return fmt.Errorf("resource wallix-bastion_device_service not available with api version %s", c.bastionAPIVersion)
+	}
+	if protocol != "" {
+		return checkDeviceServiceProtocol(c.bastionAPIVersion, protocol)
+	}
+
+	return nil
+}
+
+// deviceServiceProtocolsValid returns the protocols of a service available with the api version.
+func deviceServiceProtocolsValid(apiVersion string) []string {
+	protocols := []string{"SSH", "RAWTCPIP", "RDP", "TELNET", "VNC"}
+	if semver.Compare(apiVersion, VersionWallixAPI312) >= 0 {
+		protocols = append(protocols, "RLOGIN")
+	}
+
+	return protocols
+}
+
+func checkDeviceServiceProtocol(apiVersion, protocol string) error {
+	if !slices.Contains(deviceServiceProtocolsValid(apiVersion), protocol) {
+		return fmt.Errorf("protocol %s not available with api version %s (available: %s)",
+			protocol, apiVersion, strings.Join(deviceServiceProtocolsValid(apiVersion), ", "))
+	}
+
+	return nil
 }
 
 func resourceDeviceServiceCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceServiceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceServiceVersionCheck(c, d.Get("protocol").(string)); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
-		return diag.FromErr(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", d.Get("device_id").(string)))
 	}
-	_, ex, err := searchResourceDeviceService(ctx, d.Get("device_id").(string), d.Get("service_name").(string), m)
+	existingID, ex, err := searchResourceDeviceService(
+		ctx, d.Get("device_id").(string), d.Get("service_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("service_name %s on device_id %s already exists",
+		if d.Get("force_create").(bool) {
+			return resourceDeviceServiceForceCreate(ctx, d, existingID, m)
+		}
+		if d.Get("adopt_existing").(bool) {
+			return resourceDeviceServiceAdopt(ctx, d, existingID, m)
+		}
+
+		return diagFromAPIError(fmt.Errorf("service_name %s on device_id %s already exists",
 			d.Get("service_name").(string), d.Get("device_id").(string)))
 	}
+	if err := checkDeviceServicePortConflict(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := checkDeviceServiceJump(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
 	err = addDeviceService(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		if !errors.Is(err, errDeviceServiceConflict) ||
+			(!d.Get("adopt_existing").(bool) && !d.Get("force_create").(bool)) {
+			return diagFromAPIError(err)
+		}
+		// The service has been created by someone else since the search
+		existingID, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+			return searchResourceDeviceService(ctx, d.Get("device_id").(string), d.Get("service_name").(string), m)
+		})
+		if err != nil {
+			return diagFromAPIError(err)
+		}
+		if !ex {
+			return diagFromAPIError(fmt.Errorf("service_name %s on device_id %s not found after Conflict on POST",
+				d.Get("service_name").(string), d.Get("device_id").(string)))
+		}
+
+		if d.Get("force_create").(bool) {
+			return resourceDeviceServiceForceCreate(ctx, d, existingID, m)
+		}
+
+		return resourceDeviceServiceAdopt(ctx, d, existingID, m)
 	}
-	id, ex, err := searchResourceDeviceService(ctx, d.Get("device_id").(string), d.Get("service_name").(string), m)
+	id, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+		return searchResourceDeviceService(ctx, d.Get("device_id").(string), d.Get("service_name").(string), m)
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("service_name %s on device_id %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("service_name %s on device_id %s not found after POST",
 			d.Get("service_name").(string), d.Get("device_id").(string)))
 	}
 	d.SetId(id)
+	if d.Get("wait_for_ready").(bool) {
+		if err := waitDeviceServiceReady(ctx, d.Get("device_id").(string), id,
+			d.Timeout(schema.TimeoutCreate), m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+
+	return resourceDeviceServiceRead(ctx, d, m)
+}
+
+// waitDeviceServiceReady polls the service until the Bastion reports it ready,
+// an api version without status returns the service ready immediately.
+func waitDeviceServiceReady(
+	ctx context.Context, deviceID, serviceID string, timeout time.Duration, m interface{},
+) error {
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{deviceServiceStatusPending, deviceServiceStatusInitializing},
+		Target:       []string{deviceServiceStatusReady},
+		Refresh:      deviceServiceReadyRefresh(withoutCache(ctx), deviceID, serviceID, m),
+		Timeout:      timeout,
+		PollInterval: deviceServiceReadyPollInterval,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for service %s on device_id %s to be ready: %w", serviceID, deviceID, err)
+	}
+
+	return nil
+}
+
+func deviceServiceReadyRefresh(
+	ctx context.Context, deviceID, serviceID string, m interface{},
+) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		cfg, err := readDeviceServiceOptions(ctx, deviceID, serviceID, m)
+		if err != nil {
+			return nil, "", err
+		}
+		if cfg.ID == "" {
+			// not found yet, retried up to NotFoundChecks times
+			return nil, "", nil
+		}
+		if cfg.Status == "" {
+			return cfg, deviceServiceStatusReady, nil
+		}
+
+		return cfg, cfg.Status, nil
+	}
+}
+
+// resourceDeviceServiceAdopt sets the ID of an existing service in state
+// if its fields match the configuration.
+func resourceDeviceServiceAdopt(
+	ctx context.Context, d *schema.ResourceData, serviceID string, m interface{},
+) diag.Diagnostics {
+	cfg, err := readDeviceServiceOptions(ctx, d.Get("device_id").(string), serviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := checkDeviceServiceAdoption(d, cfg); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(serviceID)
 
 	return resourceDeviceServiceRead(ctx, d, m)
 }
 
+// resourceDeviceServiceForceCreate sets the ID of an existing service in state
+// and updates it to match the configuration.
+func resourceDeviceServiceForceCreate(
+	ctx context.Context, d *schema.ResourceData, serviceID string, m interface{},
+) diag.Diagnostics {
+	cfg, err := readDeviceServiceOptions(ctx, d.Get("device_id").(string), serviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	// the protocol of a service can't be updated
+	if cfg.Protocol != d.Get("protocol").(string) {
+		return diagFromAPIError(fmt.Errorf("service_name %s on device_id %s already exists with protocol %s "+
+			"and can't be updated to protocol %s",
+			d.Get("service_name").(string), d.Get("device_id").(string), cfg.Protocol, d.Get("protocol").(string)))
+	}
+	d.SetId(serviceID)
+	if err := checkDeviceServicePortConflict(ctx, d, m); err != nil {
+		d.SetId("")
+
+		return diagFromAPIError(err)
+	}
+	if err := checkDeviceServiceJump(ctx, d, m); err != nil {
+		d.SetId("")
+
+		return diagFromAPIError(err)
+	}
+	if err := updateDeviceService(ctx, d, m); err != nil {
+		d.SetId("")
+
+		return diagFromAPIError(err)
+	}
+
+	return resourceDeviceServiceRead(ctx, d, m)
+}
+
+// checkDeviceServiceAdoption returns an error if the existing service differs from the configuration.
+func checkDeviceServiceAdoption(d *schema.ResourceData, existing jsonDeviceService) error {
+	mismatchErr := func(key string, existingValue, value interface{}) error {
+		return fmt.Errorf("service_name %s on device_id %s already exists with a different %s: %v instead of %v",
+			d.Get("service_name").(string), d.Get("device_id").(string), key, existingValue, value)
+	}
+	if existing.ConnectionPolicy != d.Get("connection_policy").(string) {
+		return mismatchErr("connection_policy", existing.ConnectionPolicy, d.Get("connection_policy"))
+	}
+	if existing.Port != d.Get("port").(int) {
+		return mismatchErr("port", existing.Port, d.Get("port"))
+	}
+	if existing.Protocol != d.Get("protocol").(string) {
+		return mismatchErr("protocol", existing.Protocol, d.Get("protocol"))
+	}
+	// global_domains is computed when not set, so it's only compared when set
+	if _, ok := d.GetOk("global_domains"); ok {
+		existingList, list := deviceServiceSortedLists(d, "global_domains", existing.GlobalDomains)
+		if !slices.Equal(existingList, list) {
+			return mismatchErr("global_domains", existingList, list)
+		}
+	}
+	existingList, list := deviceServiceSortedLists(d, "subprotocols", existing.SubProtocols)
+	if !slices.Equal(existingList, list) {
+		return mismatchErr("subprotocols", existingList, list)
+	}
+
+	return nil
+}
+
+// deviceServiceSortedLists returns the sorted values of a set attribute
+// for an existing service and for the configuration.
+func deviceServiceSortedLists(
+	d *schema.ResourceData, key string, existing *[]string,
+) (
+	[]string, []string,
+) {
+	existingList := make([]string, 0)
+	if existing != nil {
+		existingList = append(existingList, *existing...)
+	}
+	slices.Sort(existingList)
+	list := make([]string, 0)
+	for _, v := range d.Get(key).(*schema.Set).List() {
+		list = append(list, v.(string))
+	}
+	slices.Sort(list)
+
+	return existingList, list
+}
+
 func resourceDeviceServiceRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceServiceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceServiceVersionCheck(c, ""); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDeviceServiceOptions(ctx, d.Get("device_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
-	} else {
-		fillDeviceService(d, cfg)
+
+		return nil
+	}
+	diags, err := checkDeviceServiceConnectionPolicyDrift(ctx, d, cfg, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := fillDeviceService(d, cfg); err != nil {
+		return append(diags, diagFromAPIError(err)...)
 	}
 
-	return nil
+	return diags
+}
+
+// checkDeviceServiceConnectionPolicyDrift returns a warning when the connection policy of the state
+// doesn't exist anymore and the Bastion has replaced it on the service, i.e. the policy has been deleted
+// outside Terraform. The new value is set in the state by fillDeviceService, so the next plan shows the update.
+func checkDeviceServiceConnectionPolicyDrift(
+	ctx context.Context, d *schema.ResourceData, jsonData jsonDeviceService, m interface{},
+) (
+	diag.Diagnostics, error,
+) {
+	previous := d.Get("connection_policy").(string)
+	if previous == "" || previous == jsonData.ConnectionPolicy {
+		return nil, nil
+	}
+	_, ex, err := searchResourceConnectionPolicy(ctx, previous, m)
+	if err != nil {
+		return nil, err
+	}
+	if ex {
+		return nil, nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Connection policy of the service reset by the Bastion",
+		Detail: fmt.Sprintf("The connection_policy %s of the service %s doesn't exist anymore, "+
+			"the Bastion has replaced it with %s. The plan shows the update to the configured policy.",
+			previous, jsonData.ServiceName, jsonData.ConnectionPolicy),
+		AttributePath: cty.GetAttrPath("connection_policy"),
+	}}, nil
 }
 
 func resourceDeviceServiceUpdate(
@@ -149,26 +548,53 @@ func resourceDeviceServiceUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("port") {
+		if err := checkDeviceServicePortConflict(ctx, d, m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	if d.HasChanges("jump_host", "jump_service") {
+		if err := checkDeviceServiceJump(ctx, d, m); err != nil {
+			return diagFromAPIError(err)
+		}
 	}
 	if err := updateDeviceService(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
+	var diags diag.Diagnostics
+	if d.HasChange("connection_policy") {
+		oldValue, newValue := d.GetChange("connection_policy")
+		hint := "Set require_disruptive_change_confirmation = true to require " +
+			"confirm_disruptive_change = true before the next change."
+		if d.Get("require_disruptive_change_confirmation").(bool) {
+			hint = "Set confirm_disruptive_change back to false to require a confirmation before the next change."
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Connection policy of the service changed",
+			Detail: fmt.Sprintf("The connection_policy of the service %s has been changed from %s to %s, "+
+				"the live sessions of the service may have been interrupted. %s",
+				d.Get("service_name").(string), oldValue, newValue, hint),
+			AttributePath: cty.GetAttrPath("connection_policy"),
+		})
+	}
 
-	return resourceDeviceServiceRead(ctx, d, m)
+	return append(diags, resourceDeviceServiceRead(ctx, d, m)...)
 }
 
 func resourceDeviceServiceDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDeviceServiceVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDeviceServiceVersionCheck(c, ""); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteDeviceService(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -181,7 +607,7 @@ func resourceDeviceServiceImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceDeviceServiceVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceDeviceServiceVersionCheck(c, ""); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -199,11 +625,16 @@ func resourceDeviceServiceImport(
 	if err != nil {
 		return nil, err
 	}
-	fillDeviceService(d, cfg)
+	if err := fillDeviceService(d, cfg); err != nil {
+		return nil, err
+	}
 	result := make([]*schema.ResourceData, 1)
 	d.SetId(id)
-	if tfErr := d.Set("device_id", idSplit[0]); tfErr != nil {
-		panic(tfErr)
+	if err := d.Set("device_id", idSplit[0]); err != nil {
+		return nil, fmt.Errorf("setting device_id: %w", err)
+	}
+	if err := d.Set("adopt_existing", false); err != nil {
+		return nil, fmt.Errorf("setting adopt_existing: %w", err)
 	}
 	result[0] = d
 
@@ -216,13 +647,13 @@ func searchResourceDeviceService(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+
-		"/services/?q=service_name="+serviceName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, c.uriWithFields("/devices/"+deviceID+
+		"/services/?q=service_name="+serviceName, "id"), http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonDeviceService
 	err = json.Unmarshal([]byte(body), &results)
@@ -236,6 +667,80 @@ func searchResourceDeviceService(
 	return "", false, nil
 }
 
+// listDeviceServices returns the services of the device,
+// with only the given fields if the field selection is enabled.
+func listDeviceServices(
+	ctx context.Context, deviceID string, m interface{}, fields ...string,
+) (
+	[]jsonDeviceService, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, c.uriWithFields("/devices/"+deviceID+"/services/", fields...),
+		http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonDeviceService
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return results, nil
+}
+
+// checkDeviceServicePortConflict returns an error if another service on the device
+// already uses the same port and protocol, which the API rejects with an opaque error.
+func checkDeviceServicePortConflict(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	services, err := listDeviceServices(ctx, d.Get("device_id").(string), m,
+		"id", "service_name", "port", "protocol")
+	if err != nil {
+		return err
+	}
+	for _, v := range services {
+		if v.ID != d.Id() &&
+			v.Port == d.Get("port").(int) &&
+			v.Protocol == d.Get("protocol").(string) {
+			return fmt.Errorf("port %d with protocol %s on device_id %s is already used by service_name %s",
+				v.Port, v.Protocol, d.Get("device_id").(string), v.ServiceName)
+		}
+	}
+
+	return nil
+}
+
+// checkDeviceServiceJump returns an error if the jump_service of the jump_host
+// used to reach the service doesn't exist.
+func checkDeviceServiceJump(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	jumpHost := d.Get("jump_host").(string)
+	if jumpHost == "" {
+		return nil
+	}
+	jumpHostID, ex, err := searchResourceDevice(ctx, jumpHost, m)
+	if err != nil {
+		return err
+	}
+	if !ex {
+		return fmt.Errorf("jump_host %s doesn't exists", jumpHost)
+	}
+	_, ex, err = searchResourceDeviceService(ctx, jumpHostID, d.Get("jump_service").(string), m)
+	if err != nil {
+		return err
+	}
+	if !ex {
+		return fmt.Errorf("jump_service %s on jump_host %s doesn't exists", d.Get("jump_service").(string), jumpHost)
+	}
+
+	return nil
+}
+
 func addDeviceService(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) error {
@@ -244,12 +749,18 @@ func addDeviceService(
 	if err != nil {
 		return err
 	}
+	if err := prepareDeviceServiceDescription(d, c.bastionAPIVersion, &json); err != nil {
+		return err
+	}
 	body, code, err := c.newRequest(ctx, "/devices/"+d.Get("device_id").(string)+"/services/", http.MethodPost, json)
 	if err != nil {
 		return err
 	}
+	if code == http.StatusConflict {
+		return fmt.Errorf("%w with body:\n%s", errDeviceServiceConflict, body)
+	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -263,13 +774,27 @@ func updateDeviceService(
 	if err != nil {
 		return err
 	}
+	if err := prepareDeviceServiceDescription(d, c.bastionAPIVersion, &json); err != nil {
+		return err
+	}
+	method := http.MethodPut
+	var requestData interface{} = json
+	if patchSupported(c.bastionAPIVersion) {
+		// send only the changed fields to keep the attributes managed outside of Terraform
+		patchData, err := prepareJSONPatch(d, json)
+		if err != nil {
+			return err
+		}
+		method = http.MethodPatch
+		requestData = patchData
+	}
 	body, code, err := c.newRequest(ctx,
-		"/devices/"+d.Get("device_id").(string)+"/services/"+d.Id()+"?force=true", http.MethodPut, json)
+		"/devices/"+d.Get("device_id").(string)+"/services/"+d.Id()+"?force=true", method, requestData)
 	if err != nil {
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -285,7 +810,7 @@ func deleteDeviceService(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -321,6 +846,29 @@ func rdpSubProtocolsValid() []string {
 	}
 }
 
+// checkDeviceServiceSubProtocolsMix rejects a set with both SSH and RDP subprotocols
+// as the protocol of a service is fixed.
+func checkDeviceServiceSubProtocolsMix(listSubProtocols []interface{}) error {
+	var sshSubProtocols, rdpSubProtocols []string
+	for _, v := range listSubProtocols {
+		switch {
+		case slices.Contains(sshSubProtocolsValid(), v.(string)):
+			sshSubProtocols = append(sshSubProtocols, v.(string))
+		case slices.Contains(rdpSubProtocolsValid(), v.(string)):
+			rdpSubProtocols = append(rdpSubProtocols, v.(string))
+		}
+	}
+	if len(sshSubProtocols) > 0 && len(rdpSubProtocols) > 0 {
+		slices.Sort(sshSubProtocols)
+		slices.Sort(rdpSubProtocols)
+
+		return fmt.Errorf("subprotocols can't mix SSH (%s) and RDP (%s) subprotocols on a single service",
+			strings.Join(sshSubProtocols, ", "), strings.Join(rdpSubProtocols, ", "))
+	}
+
+	return nil
+}
+
 func prepareDeviceServiceJSON(
 	d *schema.ResourceData, newResource bool,
 ) (
@@ -345,7 +893,28 @@ func prepareDeviceServiceJSON(
 		jsonData.GlobalDomains = &globalDomains
 	}
 
-	if listSubProtocols := d.Get("subprotocols").(*schema.Set).List(); len(listSubProtocols) > 0 {
+	if d.HasChanges("jump_host", "jump_service") {
+		jumpHost := d.Get("jump_host").(string)
+		jumpService := d.Get("jump_service").(string)
+		jsonData.JumpHost = &jumpHost
+		jsonData.JumpService = &jumpService
+	}
+
+	// an empty map is only sent to remove the previous tags
+	if mapTags := d.Get("tags").(map[string]interface{}); len(mapTags) > 0 || d.HasChange("tags") {
+		tags := make(map[string]string, len(mapTags))
+		for k, v := range mapTags {
+			tags[k] = v.(string)
+		}
+		jsonData.Tags = &tags
+	}
+
+	// an empty list is only sent to remove the previous subprotocols
+	listSubProtocols := d.Get("subprotocols").(*schema.Set).List()
+	if len(listSubProtocols) > 0 || (!newResource && d.HasChange("subprotocols")) {
+		if err := checkDeviceServiceSubProtocolsMix(listSubProtocols); err != nil {
+			return jsonData, err
+		}
 		subProtocols := make([]string, len(listSubProtocols))
 		for i, v := range listSubProtocols {
 			switch d.Get("protocol").(string) {
@@ -360,15 +929,66 @@ func prepareDeviceServiceJSON(
 				}
 				subProtocols[i] = v.(string)
 			default:
-				return jsonData, fmt.Errorf("subprotocols need to not set for %s service", d.Get("protocol").(string))
+				return jsonData, fmt.Errorf("subprotocols can only be set for SSH and RDP services, not for %s service",
+					d.Get("protocol").(string))
 			}
 		}
 		jsonData.SubProtocols = &subProtocols
 	}
 
+	if err := prepareDeviceServiceTLS(d, &jsonData); err != nil {
+		return jsonData, err
+	}
+
 	return jsonData, nil
 }
 
+// deviceServiceDescriptionSupported returns true if the api version accepts a description on the services.
+func deviceServiceDescriptionSupported(apiVersion string) bool {
+	return semver.Compare(apiVersion, VersionWallixAPI312) >= 0
+}
+
+// prepareDeviceServiceDescription adds the description when it changes,
+// it's never sent to the api versions which don't know the field.
+func prepareDeviceServiceDescription(d *schema.ResourceData, apiVersion string, jsonData *jsonDeviceService) error {
+	if !d.HasChange("description") {
+		return nil
+	}
+	if !deviceServiceDescriptionSupported(apiVersion) {
+		return fmt.Errorf("description not available with api version %s", apiVersion)
+	}
+	description := d.Get("description").(string)
+	jsonData.Description = &description
+
+	return nil
+}
+
+// prepareDeviceServiceTLS adds the TLS settings changed in the configuration,
+// they're omitted when unspecified so the Bastion keeps its settings.
+func prepareDeviceServiceTLS(d *schema.ResourceData, jsonData *jsonDeviceService) error {
+	if !d.HasChanges("tls_enable", "tls_min_version", "tls_ciphers") {
+		return nil
+	}
+	if protocol := d.Get("protocol").(string); protocol != "RDP" {
+		return fmt.Errorf("tls_enable, tls_min_version and tls_ciphers can only be set for RDP services, "+
+			"not for %s service", protocol)
+	}
+	if d.HasChange("tls_enable") {
+		tlsEnable := d.Get("tls_enable").(bool)
+		jsonData.TLSEnable = &tlsEnable
+	}
+	if d.HasChange("tls_min_version") {
+		tlsMinVersion := d.Get("tls_min_version").(string)
+		jsonData.TLSMinVersion = &tlsMinVersion
+	}
+	if d.HasChange("tls_ciphers") {
+		tlsCiphers := d.Get("tls_ciphers").(string)
+		jsonData.TLSCiphers = &tlsCiphers
+	}
+
+	return nil
+}
+
 func readDeviceServiceOptions(
 	ctx context.Context, deviceID, serviceID string, m interface{},
 ) (
@@ -384,7 +1004,7 @@ func readDeviceServiceOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -394,23 +1014,46 @@ func readDeviceServiceOptions(
 	return result, nil
 }
 
-func fillDeviceService(d *schema.ResourceData, jsonData jsonDeviceService) {
-	if tfErr := d.Set("service_name", jsonData.ServiceName); tfErr != nil {
-		panic(tfErr)
+func fillDeviceService(d *schema.ResourceData, jsonData jsonDeviceService) error {
+	if err := d.Set("service_name", jsonData.ServiceName); err != nil {
+		return fmt.Errorf("setting service_name: %w", err)
+	}
+	if err := d.Set("connection_policy", jsonData.ConnectionPolicy); err != nil {
+		return fmt.Errorf("setting connection_policy: %w", err)
+	}
+	if err := d.Set("port", jsonData.Port); err != nil {
+		return fmt.Errorf("setting port: %w", err)
 	}
-	if tfErr := d.Set("connection_policy", jsonData.ConnectionPolicy); tfErr != nil {
-		panic(tfErr)
+	if err := d.Set("protocol", jsonData.Protocol); err != nil {
+		return fmt.Errorf("setting protocol: %w", err)
 	}
-	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
-		panic(tfErr)
+	if err := d.Set("global_domains", jsonData.GlobalDomains); err != nil {
+		return fmt.Errorf("setting global_domains: %w", err)
 	}
-	if tfErr := d.Set("protocol", jsonData.Protocol); tfErr != nil {
-		panic(tfErr)
+	if err := d.Set("subprotocols", jsonData.SubProtocols); err != nil {
+		return fmt.Errorf("setting subprotocols: %w", err)
 	}
-	if tfErr := d.Set("global_domains", jsonData.GlobalDomains); tfErr != nil {
-		panic(tfErr)
+	if err := d.Set("jump_host", jsonData.JumpHost); err != nil {
+		return fmt.Errorf("setting jump_host: %w", err)
 	}
-	if tfErr := d.Set("subprotocols", jsonData.SubProtocols); tfErr != nil {
-		panic(tfErr)
+	if err := d.Set("jump_service", jsonData.JumpService); err != nil {
+		return fmt.Errorf("setting jump_service: %w", err)
 	}
+	if err := d.Set("description", jsonData.Description); err != nil {
+		return fmt.Errorf("setting description: %w", err)
+	}
+	if err := d.Set("tags", jsonData.Tags); err != nil {
+		return fmt.Errorf("setting tags: %w", err)
+	}
+	if err := d.Set("tls_enable", jsonData.TLSEnable); err != nil {
+		return fmt.Errorf("setting tls_enable: %w", err)
+	}
+	if err := d.Set("tls_min_version", jsonData.TLSMinVersion); err != nil {
+		return fmt.Errorf("setting tls_min_version: %w", err)
+	}
+	if err := d.Set("tls_ciphers", jsonData.TLSCiphers); err != nil {
+		return fmt.Errorf("setting tls_ciphers: %w", err)
+	}
+
+	return nil
 }
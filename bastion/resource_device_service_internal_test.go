@@ -0,0 +1,1025 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCheckDeviceServiceAdoption(t *testing.T) {
+	config := map[string]interface{}{
+		"device_id":         "d1",
+		"service_name":      "SSH",
+		"connection_policy": "SSH",
+		"port":              22,
+		"protocol":          "SSH",
+		"subprotocols":      []interface{}{"SSH_SHELL_SESSION", "SFTP_SESSION"},
+		"adopt_existing":    true,
+	}
+	tests := map[string]struct {
+		existing jsonDeviceService
+		errMatch string
+	}{
+		"matching": {
+			existing: jsonDeviceService{
+				ID:               "s1",
+				ServiceName:      "SSH",
+				ConnectionPolicy: "SSH",
+				Port:             22,
+				Protocol:         "SSH",
+				GlobalDomains:    &[]string{"domain"},
+				SubProtocols:     &[]string{"SFTP_SESSION", "SSH_SHELL_SESSION"},
+			},
+		},
+		"mismatching port": {
+			existing: jsonDeviceService{
+				ID:               "s1",
+				ServiceName:      "SSH",
+				ConnectionPolicy: "SSH",
+				Port:             2222,
+				Protocol:         "SSH",
+				SubProtocols:     &[]string{"SFTP_SESSION", "SSH_SHELL_SESSION"},
+			},
+			errMatch: "already exists with a different port: 2222 instead of 22",
+		},
+		"mismatching subprotocols": {
+			existing: jsonDeviceService{
+				ID:               "s1",
+				ServiceName:      "SSH",
+				ConnectionPolicy: "SSH",
+				Port:             22,
+				Protocol:         "SSH",
+				SubProtocols:     &[]string{"SSH_SHELL_SESSION"},
+			},
+			errMatch: "already exists with a different subprotocols",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, config)
+			err := checkDeviceServiceAdoption(d, tt.existing)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestResourceDeviceServiceProtocolChange(t *testing.T) {
+	r := resourceDeviceService()
+	state := &terraform.InstanceState{
+		ID: "s1",
+		Attributes: map[string]string{
+			"id":                "s1",
+			"device_id":         "d1",
+			"service_name":      "SSH",
+			"connection_policy": "SSH",
+			"port":              "22",
+			"protocol":          "SSH",
+			"adopt_existing":    "false",
+			"force_create":      "false",
+			"global_domains.#":  "0",
+			"subprotocols.#":    "0",
+		},
+	}
+	tests := map[string]struct {
+		protocol    string
+		requiresNew bool
+	}{
+		"same protocol":    {protocol: "SSH"},
+		"protocol changed": {protocol: "RDP", requiresNew: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"device_id":         "d1",
+				"service_name":      "SSH",
+				"connection_policy": "SSH",
+				"port":              22,
+				"protocol":          tt.protocol,
+			})
+			diff, err := r.Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff.RequiresNew() != tt.requiresNew {
+				t.Errorf("expected replacement %t, got diff %v", tt.requiresNew, diff)
+			}
+		})
+	}
+}
+
+func TestPrepareDeviceServiceJSONSubProtocols(t *testing.T) {
+	tests := map[string]struct {
+		protocol     string
+		subProtocols []interface{}
+		errMatch     string
+	}{
+		"ssh": {
+			protocol:     "SSH",
+			subProtocols: []interface{}{"SSH_SHELL_SESSION", "SSH_SCP_UP"},
+		},
+		"rdp": {
+			protocol:     "RDP",
+			subProtocols: []interface{}{"RDP_CLIPBOARD_UP", "RDP_DRIVE"},
+		},
+		"mix on ssh": {
+			protocol:     "SSH",
+			subProtocols: []interface{}{"SSH_SHELL_SESSION", "SSH_X11", "RDP_PRINTER"},
+			errMatch:     "subprotocols can't mix SSH (SSH_SHELL_SESSION, SSH_X11) and RDP (RDP_PRINTER)",
+		},
+		"mix on rdp": {
+			protocol:     "RDP",
+			subProtocols: []interface{}{"RDP_DRIVE", "SFTP_SESSION"},
+			errMatch:     "subprotocols can't mix SSH (SFTP_SESSION) and RDP (RDP_DRIVE)",
+		},
+		"rdp on ssh": {
+			protocol:     "SSH",
+			subProtocols: []interface{}{"RDP_DRIVE"},
+			errMatch:     "subprotocols RDP_DRIVE not valid for SSH service",
+		},
+		"invalid ssh": {
+			protocol:     "SSH",
+			subProtocols: []interface{}{"SSH_SHELL_SESSION", "SSH_UNKNOWN"},
+			errMatch:     "subprotocols SSH_UNKNOWN not valid for SSH service",
+		},
+		"invalid rdp": {
+			protocol:     "RDP",
+			subProtocols: []interface{}{"RDP_DRIVE", "RDP_UNKNOWN"},
+			errMatch:     "subprotocols RDP_UNKNOWN not valid for RDP service",
+		},
+		"telnet": {
+			protocol:     "TELNET",
+			subProtocols: []interface{}{"SSH_SHELL_SESSION"},
+			errMatch:     "subprotocols can only be set for SSH and RDP services, not for TELNET service",
+		},
+		"rlogin": {
+			protocol:     "RLOGIN",
+			subProtocols: []interface{}{"SSH_SHELL_SESSION"},
+			errMatch:     "subprotocols can only be set for SSH and RDP services, not for RLOGIN service",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, map[string]interface{}{
+				"device_id":         "d1",
+				"service_name":      tt.protocol,
+				"connection_policy": tt.protocol,
+				"port":              22,
+				"protocol":          tt.protocol,
+				"subprotocols":      tt.subProtocols,
+			})
+			_, err := prepareDeviceServiceJSON(d, true)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestResourceDeviceServiceForceCreate(t *testing.T) {
+	tests := map[string]struct {
+		forceCreate bool
+		protocol    string
+		errMatch    string
+	}{
+		"force create": {
+			forceCreate: true,
+			protocol:    "SSH",
+		},
+		"without force create": {
+			protocol: "SSH",
+			errMatch: "service_name SSH on device_id d1 already exists",
+		},
+		"protocol mismatch": {
+			forceCreate: true,
+			protocol:    "TELNET",
+			errMatch:    "already exists with protocol SSH and can't be updated to protocol TELNET",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			existing := jsonDeviceService{
+				ID:               "s1",
+				ServiceName:      "SSH",
+				ConnectionPolicy: "SSH",
+				Port:             2222,
+				Protocol:         "SSH",
+			}
+			updated := false
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api/v3.12/devices/d1":
+					_ = json.NewEncoder(w).Encode(jsonDevice{ID: "d1", DeviceName: "device"})
+				case r.URL.Path == "/api/v3.12/devices/d1/services/":
+					_ = json.NewEncoder(w).Encode([]jsonDeviceService{existing})
+				case r.URL.Path == "/api/v3.12/devices/d1/services/s1" && r.Method == http.MethodGet:
+					_ = json.NewEncoder(w).Encode(existing)
+				case r.URL.Path == "/api/v3.12/devices/d1/services/s1" && r.Method == http.MethodPatch:
+					var patch map[string]interface{}
+					_ = json.NewDecoder(r.Body).Decode(&patch)
+					if port, ok := patch["port"].(float64); ok {
+						existing.Port = int(port)
+					}
+					updated = true
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, map[string]interface{}{
+				"device_id":         "d1",
+				"service_name":      "SSH",
+				"connection_policy": "SSH",
+				"port":              22,
+				"protocol":          tt.protocol,
+				"force_create":      tt.forceCreate,
+			})
+			diags := resourceDeviceServiceCreate(context.Background(), d, c)
+			if tt.errMatch != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", tt.errMatch, diags)
+				}
+				if d.Id() != "" || updated {
+					t.Errorf("expected no update and no ID, got ID %q", d.Id())
+				}
+
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != "s1" || !updated {
+				t.Fatalf("expected the existing service s1 to be updated, got ID %q", d.Id())
+			}
+			if d.Get("port").(int) != 22 {
+				t.Errorf("expected port 22 after update, got %d", d.Get("port").(int))
+			}
+		})
+	}
+}
+
+func TestResourceDeviceServiceCreateSearchRetry(t *testing.T) {
+	searches := 0
+	created := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v3.12/devices/d1":
+			_ = json.NewEncoder(w).Encode(jsonDevice{ID: "d1", DeviceName: "device"})
+		case r.URL.Path == "/api/v3.12/devices/d1/services/" && r.Method == http.MethodPost:
+			created = true
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/v3.12/devices/d1/services/" && r.URL.Query().Get("q") == "":
+			_ = json.NewEncoder(w).Encode([]jsonDeviceService{})
+		case r.URL.Path == "/api/v3.12/devices/d1/services/":
+			// the first search after the POST doesn't find the service yet
+			if created {
+				searches++
+			}
+			if searches < 2 {
+				_ = json.NewEncoder(w).Encode([]jsonDeviceService{})
+
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]jsonDeviceService{{ID: "s1", ServiceName: "SSH"}})
+		case r.URL.Path == "/api/v3.12/devices/d1/services/s1":
+			_ = json.NewEncoder(w).Encode(jsonDeviceService{
+				ID: "s1", ServiceName: "SSH", ConnectionPolicy: "SSH", Port: 22, Protocol: "SSH",
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, map[string]interface{}{
+		"device_id":         "d1",
+		"service_name":      "SSH",
+		"connection_policy": "SSH",
+		"port":              22,
+		"protocol":          "SSH",
+	})
+	if diags := resourceDeviceServiceCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "s1" || searches != 2 {
+		t.Fatalf("expected service s1 found on the second search after POST, got ID %q after %d searches",
+			d.Id(), searches)
+	}
+}
+
+func TestPrepareDeviceServiceJSONPatchEmptySubProtocols(t *testing.T) {
+	r := resourceDeviceService()
+	state := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"device_id":         "d1",
+		"service_name":      "SSH",
+		"connection_policy": "SSH",
+		"port":              22,
+		"protocol":          "SSH",
+		"subprotocols":      []interface{}{"SSH_SHELL_SESSION"},
+	})
+	state.SetId("s1")
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"device_id":         "d1",
+		"service_name":      "SSH",
+		"connection_policy": "SSH",
+		"port":              22,
+		"protocol":          "SSH",
+	}), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state.State(), diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	jsonData, err := prepareDeviceServiceJSON(d, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if jsonData.SubProtocols == nil || len(*jsonData.SubProtocols) != 0 {
+		t.Errorf("expected an empty list of subprotocols in the PUT, got %v", jsonData.SubProtocols)
+	}
+	patchData, err := prepareJSONPatch(d, jsonData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v, ok := patchData["subprotocols"]; !ok || !reflect.DeepEqual(v, []interface{}{}) {
+		t.Errorf("expected an empty list of subprotocols in the PATCH, got %v", patchData)
+	}
+}
+
+func TestPrepareDeviceServiceJSONValidSubProtocols(t *testing.T) {
+	for protocol, subProtocols := range map[string][]string{
+		"SSH": sshSubProtocolsValid(),
+		"RDP": rdpSubProtocolsValid(),
+	} {
+		for _, subProtocol := range subProtocols {
+			t.Run(subProtocol, func(t *testing.T) {
+				d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, map[string]interface{}{
+					"device_id":         "d1",
+					"service_name":      protocol,
+					"connection_policy": protocol,
+					"port":              22,
+					"protocol":          protocol,
+					"subprotocols":      []interface{}{subProtocol},
+				})
+				jsonData, err := prepareDeviceServiceJSON(d, true)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if jsonData.SubProtocols == nil || !reflect.DeepEqual(*jsonData.SubProtocols, []string{subProtocol}) {
+					t.Errorf("expected subprotocols [%s], got %v", subProtocol, jsonData.SubProtocols)
+				}
+			})
+		}
+	}
+}
+
+func TestPrepareDeviceServiceJSONTags(t *testing.T) {
+	tests := map[string]struct {
+		tags     map[string]interface{}
+		expected *map[string]string
+	}{
+		"without tags": {},
+		"with tags": {
+			tags:     map[string]interface{}{"owner": "network", "cost_center": "1234"},
+			expected: &map[string]string{"owner": "network", "cost_center": "1234"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"device_id":         "d1",
+				"service_name":      "SSH",
+				"connection_policy": "SSH",
+				"port":              22,
+				"protocol":          "SSH",
+			}
+			if tt.tags != nil {
+				config["tags"] = tt.tags
+			}
+			d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, config)
+			jsonData, err := prepareDeviceServiceJSON(d, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(jsonData.Tags, tt.expected) {
+				t.Errorf("expected tags %v, got %v", tt.expected, jsonData.Tags)
+			}
+		})
+	}
+}
+
+func TestResourceDeviceServiceVersionCheckProtocol(t *testing.T) {
+	tests := map[string]struct {
+		apiVersion string
+		protocol   string
+		errMatch   string
+	}{
+		"rlogin v3.12": {
+			apiVersion: VersionWallixAPI312,
+			protocol:   "RLOGIN",
+		},
+		"rlogin v3.8": {
+			apiVersion: VersionWallixAPI38,
+			protocol:   "RLOGIN",
+			errMatch:   "protocol RLOGIN not available with api version v3.8",
+		},
+		"telnet v3.8": {
+			apiVersion: VersionWallixAPI38,
+			protocol:   "TELNET",
+		},
+		"without protocol": {
+			apiVersion: VersionWallixAPI38,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{bastionAPIVersion: tt.apiVersion}
+			err := resourceDeviceServiceVersionCheck(c, tt.protocol)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestResourceDeviceServiceReadConnectionPolicyDrift(t *testing.T) {
+	tests := map[string]struct {
+		apiPolicy     string
+		policyDeleted bool
+		expectWarn    bool
+		expectState   string
+	}{
+		"unchanged": {
+			apiPolicy:   "custom_ssh",
+			expectState: "custom_ssh",
+		},
+		"policy deleted": {
+			apiPolicy:     "SSH",
+			policyDeleted: true,
+			expectWarn:    true,
+			expectState:   "SSH",
+		},
+		"policy deleted and replaced with another name": {
+			apiPolicy:     "default_ssh",
+			policyDeleted: true,
+			expectWarn:    true,
+			expectState:   "default_ssh",
+		},
+		"changed to another policy": {
+			apiPolicy:   "other_ssh",
+			expectState: "other_ssh",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v3.12/connectionpolicies/" {
+					if tt.apiPolicy == "custom_ssh" {
+						t.Errorf("unexpected search of the connection policy")
+					}
+					if got := r.URL.Query().Get("q"); got != "connection_policy_name=custom_ssh" {
+						t.Errorf("unexpected search %q", got)
+					}
+					policies := []jsonConnectionPolicy{}
+					if !tt.policyDeleted {
+						policies = append(policies, jsonConnectionPolicy{ID: "p1"})
+					}
+					_ = json.NewEncoder(w).Encode(policies)
+
+					return
+				}
+				if r.URL.Path != "/api/v3.12/devices/d1/services/s1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				_ = json.NewEncoder(w).Encode(jsonDeviceService{
+					ID: "s1", ServiceName: "SSH", ConnectionPolicy: tt.apiPolicy, Port: 22, Protocol: "SSH",
+				})
+			})
+			d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, map[string]interface{}{
+				"device_id":         "d1",
+				"service_name":      "SSH",
+				"connection_policy": "custom_ssh",
+				"port":              22,
+				"protocol":          "SSH",
+			})
+			d.SetId("s1")
+			diags := resourceDeviceServiceRead(context.Background(), d, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if tt.expectWarn != (len(diags) == 1) {
+				t.Errorf("expected warning %t, got %v", tt.expectWarn, diags)
+			}
+			if got := d.Get("connection_policy").(string); got != tt.expectState {
+				t.Errorf("expected connection_policy %q in state, got %q", tt.expectState, got)
+			}
+		})
+	}
+}
+
+func TestWaitDeviceServiceReady(t *testing.T) {
+	tests := map[string]struct {
+		statuses []string
+		errMatch string
+	}{
+		"without status": {
+			statuses: []string{""},
+		},
+		"initializing": {
+			statuses: []string{"initializing", "ready"},
+		},
+		"error": {
+			statuses: []string{"initializing", "error"},
+			errMatch: "unexpected state 'error'",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reads := 0
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v3.12/devices/d1/services/s1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				status := tt.statuses[min(reads, len(tt.statuses)-1)]
+				reads++
+				_ = json.NewEncoder(w).Encode(jsonDeviceService{ID: "s1", ServiceName: "SSH", Status: status})
+			})
+			err := waitDeviceServiceReady(context.Background(), "d1", "s1", time.Minute, c)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch == "" && reads != len(tt.statuses):
+				t.Errorf("expected %d reads, got %d", len(tt.statuses), reads)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestResourceDeviceServiceImportID(t *testing.T) {
+	tests := map[string]struct {
+		id       string
+		errMatch string
+	}{
+		"valid": {
+			id: "d1/SSH",
+		},
+		"no separator": {
+			id:       "d1",
+			errMatch: "id must be <device_id>/<service_name>",
+		},
+		"too many separators": {
+			id:       "d1/SSH/extra",
+			errMatch: "id must be <device_id>/<service_name>",
+		},
+		"unknown service": {
+			id:       "d1/missing",
+			errMatch: "don't find service_name with id d1/missing",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v3.12/devices/d1/services/":
+					results := []jsonDeviceService{}
+					if r.URL.Query().Get("q") == "service_name=SSH" {
+						results = append(results, jsonDeviceService{ID: "s1", ServiceName: "SSH"})
+					}
+					_ = json.NewEncoder(w).Encode(results)
+				case "/api/v3.12/devices/d1/services/s1":
+					_ = json.NewEncoder(w).Encode(jsonDeviceService{
+						ID: "s1", ServiceName: "SSH", ConnectionPolicy: "SSH", Port: 22, Protocol: "SSH",
+					})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			d := resourceDeviceService().TestResourceData()
+			d.SetId(tt.id)
+			result, err := resourceDeviceServiceImport(d, c)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case tt.errMatch != "" && err == nil:
+				t.Fatalf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "":
+				if !strings.Contains(err.Error(), tt.errMatch) {
+					t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+				}
+
+				return
+			}
+			if len(result) != 1 || result[0].Id() != "s1" {
+				t.Fatalf("expected imported resource with ID s1, got %v", result)
+			}
+			if got := result[0].Get("device_id").(string); got != "d1" {
+				t.Errorf("expected device_id d1, got %q", got)
+			}
+			if got := result[0].Get("port").(int); got != 22 {
+				t.Errorf("expected port 22, got %d", got)
+			}
+		})
+	}
+}
+
+func TestFillDeviceServiceSetError(t *testing.T) {
+	resourceSchema := resourceDeviceService().Schema
+	// a schema not matching the api object makes d.Set fail
+	resourceSchema["port"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	err := fillDeviceService(d, jsonDeviceService{ServiceName: "SSH", ConnectionPolicy: "SSH", Port: 22, Protocol: "SSH"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "setting port") {
+		t.Errorf("expected error on port, got: %s", err)
+	}
+}
+
+func TestFillDeviceServiceDataSource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceDeviceService().Schema, map[string]interface{}{})
+	description := "RDP of the domain controller"
+	tlsEnable := true
+	tlsMinVersion := "TLSv1.2"
+	tlsCiphers := "HIGH:!aNULL"
+	err := fillDeviceService(d, jsonDeviceService{
+		ServiceName:      "RDP",
+		ConnectionPolicy: "RDP",
+		Port:             3389,
+		Protocol:         "RDP",
+		Description:      &description,
+		TLSEnable:        &tlsEnable,
+		TLSMinVersion:    &tlsMinVersion,
+		TLSCiphers:       &tlsCiphers,
+	})
+	if err != nil {
+		t.Fatalf("filling the data source: %s", err)
+	}
+	for key, expected := range map[string]interface{}{
+		"description":     description,
+		"tls_enable":      tlsEnable,
+		"tls_min_version": tlsMinVersion,
+		"tls_ciphers":     tlsCiphers,
+	} {
+		if got := d.Get(key); got != expected {
+			t.Errorf("expected %s %v, got %v", key, expected, got)
+		}
+	}
+}
+
+func TestValidateDeviceServicePort(t *testing.T) {
+	tests := map[string]struct {
+		port     int
+		warning  bool
+		errMatch string
+	}{
+		"unprivileged":  {port: 2222},
+		"first allowed": {port: 1024},
+		"last allowed":  {port: 65535},
+		"ssh":           {port: 22, warning: true},
+		"last reserved": {port: 1023, warning: true},
+		"zero":          {port: 0, errMatch: "expected port to be in the range (1 - 65535)"},
+		"out of range":  {port: 65536, errMatch: "expected port to be in the range (1 - 65535)"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := resourceDeviceService().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"device_id":         "d1",
+				"service_name":      "svc",
+				"connection_policy": "SSH",
+				"protocol":          "SSH",
+				"port":              tt.port,
+			}))
+			if tt.errMatch != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", tt.errMatch, diags)
+				}
+
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if tt.warning && (len(diags) != 1 || diags[0].Summary != "Privileged port for the service") {
+				t.Errorf("expected a privileged port warning, got %v", diags)
+			}
+			if !tt.warning && len(diags) != 0 {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestPrepareDeviceServiceJSONTLS(t *testing.T) {
+	tests := map[string]struct {
+		protocol string
+		tls      map[string]interface{}
+		errMatch string
+	}{
+		"rdp": {
+			protocol: "RDP",
+			tls: map[string]interface{}{
+				"tls_enable":      true,
+				"tls_min_version": "TLSv1.2",
+				"tls_ciphers":     "HIGH:!aNULL",
+			},
+		},
+		"rdp without tls": {
+			protocol: "RDP",
+		},
+		"ssh without tls": {
+			protocol: "SSH",
+		},
+		"ssh": {
+			protocol: "SSH",
+			tls:      map[string]interface{}{"tls_min_version": "TLSv1.3"},
+			errMatch: "tls_enable, tls_min_version and tls_ciphers can only be set for RDP services, not for SSH service",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"device_id":         "d1",
+				"service_name":      tt.protocol,
+				"connection_policy": tt.protocol,
+				"port":              3389,
+				"protocol":          tt.protocol,
+			}
+			for k, v := range tt.tls {
+				raw[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, raw)
+			jsonData, err := prepareDeviceServiceJSON(d, true)
+			if tt.errMatch != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", tt.errMatch, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			body, err := json.Marshal(jsonData)
+			if err != nil {
+				t.Fatalf("marshaling json: %s", err)
+			}
+			if len(tt.tls) == 0 {
+				if strings.Contains(string(body), "tls_") {
+					t.Errorf("expected the tls settings to be omitted, got %s", body)
+				}
+
+				return
+			}
+			if jsonData.TLSEnable == nil || !*jsonData.TLSEnable ||
+				jsonData.TLSMinVersion == nil || *jsonData.TLSMinVersion != "TLSv1.2" ||
+				jsonData.TLSCiphers == nil || *jsonData.TLSCiphers != "HIGH:!aNULL" {
+				t.Errorf("expected the tls settings to be sent, got %s", body)
+			}
+		})
+	}
+}
+
+func TestResourceDeviceServiceCustomizeDiffConnectionPolicy(t *testing.T) {
+	r := resourceDeviceService()
+	coreSchema := r.CoreConfigSchema()
+	config := func(connectionPolicy string, require, confirm cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for name, attrType := range coreSchema.ImpliedType().AttributeTypes() {
+			attrs[name] = cty.NullVal(attrType)
+		}
+		attrs["device_id"] = cty.StringVal("d1")
+		attrs["service_name"] = cty.StringVal("SSH")
+		attrs["connection_policy"] = cty.StringVal(connectionPolicy)
+		attrs["port"] = cty.NumberIntVal(22)
+		attrs["protocol"] = cty.StringVal("SSH")
+		attrs["require_disruptive_change_confirmation"] = require
+		attrs["confirm_disruptive_change"] = confirm
+
+		return cty.ObjectVal(attrs)
+	}
+	state := &terraform.InstanceState{
+		ID: "s1",
+		Attributes: map[string]string{
+			"id":                "s1",
+			"device_id":         "d1",
+			"service_name":      "SSH",
+			"connection_policy": "SSH",
+			"port":              "22",
+			"protocol":          "SSH",
+		},
+	}
+	tests := map[string]struct {
+		state            *terraform.InstanceState
+		connectionPolicy string
+		require          cty.Value
+		confirm          cty.Value
+		expectError      bool
+	}{
+		"create not confirmed": {
+			connectionPolicy: "SSH_STRICT",
+			require:          cty.True,
+			confirm:          cty.False,
+		},
+		"change without require_disruptive_change_confirmation": {
+			state:            state,
+			connectionPolicy: "SSH_STRICT",
+			require:          cty.NullVal(cty.Bool),
+			confirm:          cty.False,
+		},
+		"change required without confirm_disruptive_change": {
+			state:            state,
+			connectionPolicy: "SSH_STRICT",
+			require:          cty.True,
+			confirm:          cty.NullVal(cty.Bool),
+			expectError:      true,
+		},
+		"change required not confirmed": {
+			state:            state,
+			connectionPolicy: "SSH_STRICT",
+			require:          cty.True,
+			confirm:          cty.False,
+			expectError:      true,
+		},
+		"change required and confirmed": {
+			state:            state,
+			connectionPolicy: "SSH_STRICT",
+			require:          cty.True,
+			confirm:          cty.True,
+		},
+		"no change": {
+			state:            state,
+			connectionPolicy: "SSH",
+			require:          cty.True,
+			confirm:          cty.False,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rawConfig := config(test.connectionPolicy, test.require, test.confirm)
+			_, err := r.Diff(context.Background(), test.state, terraform.NewResourceConfigShimmed(rawConfig, coreSchema), nil)
+			if test.expectError {
+				if err == nil || !strings.Contains(err.Error(), "set confirm_disruptive_change = true") {
+					t.Fatalf("expected an error asking for the confirmation, got %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateDeviceServiceDisruptiveChange(t *testing.T) {
+	tests := map[string]struct {
+		confirm    cty.Value
+		expectWarn bool
+	}{
+		"not set": {
+			confirm: cty.NullVal(cty.Bool),
+		},
+		"not confirmed": {
+			confirm: cty.False,
+		},
+		"unknown": {
+			confirm: cty.UnknownVal(cty.Bool),
+		},
+		"confirmed": {
+			confirm:    cty.True,
+			expectWarn: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var resp schema.ValidateResourceConfigFuncResponse
+			validateDeviceServiceDisruptiveChange(context.Background(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"connection_policy":         cty.StringVal("SSH_STRICT"),
+					"confirm_disruptive_change": tt.confirm,
+				}),
+			}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if tt.expectWarn != (len(resp.Diagnostics) == 1) {
+				t.Errorf("expected warning %t, got %v", tt.expectWarn, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPrepareDeviceServiceDescription(t *testing.T) {
+	config := map[string]interface{}{
+		"device_id":         "d1",
+		"service_name":      "SSH",
+		"connection_policy": "SSH",
+		"port":              22,
+		"protocol":          "SSH",
+	}
+	tests := map[string]struct {
+		description string
+		apiVersion  string
+		errMatch    string
+	}{
+		"set": {
+			description: "bastion access",
+			apiVersion:  VersionWallixAPI312,
+		},
+		"unset": {
+			apiVersion: VersionWallixAPI312,
+		},
+		"unset on old version": {
+			apiVersion: VersionWallixAPI38,
+		},
+		"set on old version": {
+			description: "bastion access",
+			apiVersion:  VersionWallixAPI38,
+			errMatch:    "description not available with api version v3.8",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := make(map[string]interface{}, len(config)+1)
+			for k, v := range config {
+				cfg[k] = v
+			}
+			if test.description != "" {
+				cfg["description"] = test.description
+			}
+			d := schema.TestResourceDataRaw(t, resourceDeviceService().Schema, cfg)
+			jsonData, err := prepareDeviceServiceJSON(d, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			err = prepareDeviceServiceDescription(d, test.apiVersion, &jsonData)
+			if test.errMatch != "" {
+				if err == nil || !strings.Contains(err.Error(), test.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", test.errMatch, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if test.description != "" {
+				if jsonData.Description == nil || *jsonData.Description != test.description {
+					t.Errorf("expected description %q, got %v", test.description, jsonData.Description)
+				}
+
+				return
+			}
+			// older appliances don't know the field, so it must be left out of the request
+			body, err := json.Marshal(jsonData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(body), `"description"`) {
+				t.Errorf("expected description to be omitted, got %s", body)
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package bastion_test
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/mod/semver"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
 )
 
 func TestAccResourceDeviceService_basic(t *testing.T) {
@@ -24,6 +30,9 @@ func TestAccResourceDeviceService_basic(t *testing.T) {
 			},
 			{
 				Config: testAccResourceDeviceServiceUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "testacc"),
+				),
 			},
 			{
 				ResourceName: resourceName,
@@ -40,6 +49,52 @@ func TestAccResourceDeviceService_basic(t *testing.T) {
 
 					return devID + "/testacc_DeviceService", nil
 				},
+				ImportStateVerify: true,
+				// not returned by the api, only used on creation
+				ImportStateVerifyIgnore: []string{"force_create", "wait_for_ready"},
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "testacc_DeviceService",
+				ExpectError:   regexp.MustCompile(`id must be <device_id>/<service_name>`),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("Resource %s not found", resourceName)
+					}
+
+					return rs.Primary.Attributes["device_id"] + "/testacc_DeviceServiceMissing", nil
+				},
+				ExpectError: regexp.MustCompile(`don't find service_name with id`),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func TestAccResourceDeviceService_connectionPolicyDrift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceServiceDriftCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_device_service.testacc_DeviceServiceDrift",
+						"connection_policy", "testacc_DeviceServiceDrift"),
+				),
+			},
+			// the connection policy is deleted while the service still references it by name,
+			// the Bastion replaces the policy of the service and the plan shows the drift
+			{
+				Config:             testAccResourceDeviceServiceDriftPolicyDeleted(),
+				ExpectNonEmptyPlan: true,
 			},
 		},
 		PreventPostDestroyRefresh: true,
@@ -63,6 +118,7 @@ resource "wallix-bastion_device_service" "testacc_DeviceService" {
   protocol          = "SSH"
   subprotocols      = ["SSH_SHELL_SESSION"]
   global_domains    = [wallix-bastion_domain.testacc_DeviceService.domain_name]
+  wait_for_ready    = true
 }
 `
 }
@@ -84,6 +140,351 @@ resource "wallix-bastion_device_service" "testacc_DeviceService" {
   protocol          = "SSH"
   subprotocols      = ["SSH_SHELL_SESSION"]
   global_domains    = [wallix-bastion_domain.testacc_DeviceService.domain_name]
+  tags = {
+    owner = "testacc"
+  }
+}
+`
+}
+
+func TestAccResourceDeviceService_globalDomains(t *testing.T) {
+	resourceName := "wallix-bastion_device_service.testacc_DeviceServiceGlobalDomains"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceServiceGlobalDomains(
+					"wallix-bastion_domain.testacc_DeviceServiceGlobalDomains1.domain_name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "global_domains.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "global_domains.*",
+						"testacc_DeviceServiceGlobalDomains1"),
+				),
+			},
+			{
+				Config: testAccResourceDeviceServiceGlobalDomains(
+					"wallix-bastion_domain.testacc_DeviceServiceGlobalDomains1.domain_name",
+					"wallix-bastion_domain.testacc_DeviceServiceGlobalDomains2.domain_name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "global_domains.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "global_domains.*",
+						"testacc_DeviceServiceGlobalDomains2"),
+				),
+			},
+			// global_domains is computed when not set, so an empty set keeps the domains on the service,
+			// the disassociation is checked by removing one domain of the set
+			{
+				Config: testAccResourceDeviceServiceGlobalDomains(
+					"wallix-bastion_domain.testacc_DeviceServiceGlobalDomains2.domain_name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "global_domains.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "global_domains.*",
+						"testacc_DeviceServiceGlobalDomains2"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func TestAccResourceDeviceService_portConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceServicePortConflictFirst(),
+			},
+			{
+				Config: testAccResourceDeviceServicePortConflictSecond(),
+				ExpectError: regexp.MustCompile(
+					`port 22 with protocol SSH on device_id .* is already used by service_name testacc_DeviceServiceConflict1`),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func TestAccResourceDeviceService_jump(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceServiceJump(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_device_service.testacc_DeviceServiceJumped",
+						"jump_host", "testacc_DeviceServiceJumpGateway"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_device_service.testacc_DeviceServiceJumped",
+						"jump_service", "testacc_DeviceServiceJumpGateway"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceServiceJump() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceJumpGateway" {
+  device_name = "testacc_DeviceServiceJumpGateway"
+  host        = "testacc_jumpgateway.device"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceJumpGateway" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceJumpGateway.id
+  service_name      = "testacc_DeviceServiceJumpGateway"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+}
+resource "wallix-bastion_device" "testacc_DeviceServiceJumped" {
+  device_name = "testacc_DeviceServiceJumped"
+  host        = "testacc_jumped.device"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceJumped" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceJumped.id
+  service_name      = "testacc_DeviceServiceJumped"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  jump_host         = wallix-bastion_device.testacc_DeviceServiceJumpGateway.device_name
+  jump_service      = wallix-bastion_device_service.testacc_DeviceServiceJumpGateway.service_name
+}
+`
+}
+
+func testAccResourceDeviceServicePortConflictFirst() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceConflict" {
+  device_name = "testacc_DeviceServiceConflict"
+  host        = "testacc_serviceconflict.device"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceConflict1" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceConflict.id
+  service_name      = "testacc_DeviceServiceConflict1"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+}
+`
+}
+
+func testAccResourceDeviceServicePortConflictSecond() string {
+	return testAccResourceDeviceServicePortConflictFirst() + `
+resource "wallix-bastion_device_service" "testacc_DeviceServiceConflict2" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceConflict.id
+  service_name      = "testacc_DeviceServiceConflict2"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+}
+`
+}
+
+func testAccResourceDeviceServiceDriftCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceDrift" {
+  device_name = "testacc_DeviceServiceDrift"
+  host        = "testacc_service_drift.device"
+}
+resource "wallix-bastion_connection_policy" "testacc_DeviceServiceDrift" {
+  connection_policy_name = "testacc_DeviceServiceDrift"
+  protocol               = "RAWTCPIP"
+  options = jsonencode({
+    nat_redirection = {
+      enable = false
+      host   = ""
+      port   = 0
+    }
+  })
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceDrift" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceDrift.id
+  service_name      = "testacc_DeviceServiceDrift"
+  connection_policy = "testacc_DeviceServiceDrift"
+  port              = 3306
+  protocol          = "RAWTCPIP"
+
+  depends_on = [wallix-bastion_connection_policy.testacc_DeviceServiceDrift]
+}
+`
+}
+
+func testAccResourceDeviceServiceDriftPolicyDeleted() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceDrift" {
+  device_name = "testacc_DeviceServiceDrift"
+  host        = "testacc_service_drift.device"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceDrift" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceDrift.id
+  service_name      = "testacc_DeviceServiceDrift"
+  connection_policy = "testacc_DeviceServiceDrift"
+  port              = 3306
+  protocol          = "RAWTCPIP"
+}
+`
+}
+
+func testAccResourceDeviceServiceGlobalDomains(globalDomains ...string) string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceGlobalDomains" {
+  device_name = "testacc_DeviceServiceGlobalDomains"
+  host        = "testacc_service_global_domains.device"
+}
+resource "wallix-bastion_domain" "testacc_DeviceServiceGlobalDomains1" {
+  domain_name = "testacc_DeviceServiceGlobalDomains1"
+}
+resource "wallix-bastion_domain" "testacc_DeviceServiceGlobalDomains2" {
+  domain_name = "testacc_DeviceServiceGlobalDomains2"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceGlobalDomains" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceGlobalDomains.id
+  service_name      = "testacc_DeviceServiceGlobalDomains"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  global_domains    = [` + strings.Join(globalDomains, ", ") + `]
+}
+`
+}
+
+func TestAccResourceDeviceService_tls(t *testing.T) {
+	resourceName := "wallix-bastion_device_service.testacc_DeviceServiceTLS"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceServiceTLS(""),
+			},
+			{
+				Config: testAccResourceDeviceServiceTLS(`
+  tls_enable      = true
+  tls_min_version = "TLSv1.2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tls_enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "tls_min_version", "TLSv1.2"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceServiceTLS(tls string) string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceTLS" {
+  device_name = "testacc_DeviceServiceTLS"
+  host        = "testacc_service_tls.device"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceTLS" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceTLS.id
+  service_name      = "testacc_DeviceServiceTLS"
+  connection_policy = "RDP"
+  port              = 3389
+  protocol          = "RDP"` + tls + `
+}
+`
+}
+
+func TestAccResourceDeviceService_confirmDisruptiveChange(t *testing.T) {
+	resourceName := "wallix-bastion_device_service.testacc_DeviceServiceDisruptive"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceServiceDisruptive("RAWTCPIP", "false"),
+			},
+			{
+				Config:      testAccResourceDeviceServiceDisruptive("testacc_DeviceServiceDisruptive", "false"),
+				ExpectError: regexp.MustCompile(`set confirm_disruptive_change = true to allow it`),
+			},
+			{
+				Config: testAccResourceDeviceServiceDisruptive("testacc_DeviceServiceDisruptive", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "connection_policy", "testacc_DeviceServiceDisruptive"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceServiceDisruptive(connectionPolicy, confirm string) string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceDisruptive" {
+  device_name = "testacc_DeviceServiceDisruptive"
+  host        = "testacc_service_disruptive.device"
+}
+resource "wallix-bastion_connection_policy" "testacc_DeviceServiceDisruptive" {
+  connection_policy_name = "testacc_DeviceServiceDisruptive"
+  protocol               = "RAWTCPIP"
+  options = jsonencode({
+    nat_redirection = {
+      enable = false
+      host   = ""
+      port   = 0
+    }
+  })
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceDisruptive" {
+  device_id                              = wallix-bastion_device.testacc_DeviceServiceDisruptive.id
+  service_name                           = "testacc_DeviceServiceDisruptive"
+  connection_policy                      = "` + connectionPolicy + `"
+  port                                   = 3306
+  protocol                               = "RAWTCPIP"
+  require_disruptive_change_confirmation = true
+  confirm_disruptive_change              = ` + confirm + `
+
+  depends_on = [wallix-bastion_connection_policy.testacc_DeviceServiceDisruptive]
+}
+`
+}
+
+func TestAccResourceDeviceService_description(t *testing.T) {
+	if v := os.Getenv("WALLIX_BASTION_API_VERSION"); semver.Compare(v, bastion.VersionWallixAPI312) >= 0 {
+		resourceName := "wallix-bastion_device_service.testacc_DeviceServiceDescription"
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceDeviceServiceDescription(`description = "testacc"`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "description", "testacc"),
+					),
+				},
+				{
+					Config: testAccResourceDeviceServiceDescription(`description = ""`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "description", ""),
+					),
+				},
+			},
+			PreventPostDestroyRefresh: true,
+		})
+	}
+}
+
+func testAccResourceDeviceServiceDescription(description string) string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServiceDescription" {
+  device_name = "testacc_DeviceServiceDescription"
+  host        = "testacc_servicedescription.device"
+}
+resource "wallix-bastion_device_service" "testacc_DeviceServiceDescription" {
+  device_id         = wallix-bastion_device.testacc_DeviceServiceDescription.id
+  service_name      = "testacc_DeviceServiceDescription"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  subprotocols      = ["SSH_SHELL_SESSION"]
+  ` + description + `
 }
 `
 }
@@ -0,0 +1,538 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// deviceServicesMaxConcurrentRequests bounds the requests sent in parallel for the services of a device,
+// the API doesn't have a batch endpoint for the services.
+const deviceServicesMaxConcurrentRequests = 4
+
+func resourceDeviceServices() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeviceServicesCreate,
+		ReadContext:   resourceDeviceServicesRead,
+		UpdateContext: resourceDeviceServicesUpdate,
+		DeleteContext: resourceDeviceServicesDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDeviceServicesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"services": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"connection_policy": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 65535),
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice(
+								[]string{"SSH", "RAWTCPIP", "RDP", "RLOGIN", "TELNET", "VNC"},
+								false,
+							),
+						},
+						"global_domains": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"subprotocols": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDeviceServicesVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_device_services not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceDeviceServicesCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceServicesVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	deviceID := d.Get("device_id").(string)
+	cfg, err := readDeviceOptions(ctx, deviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		return diagFromAPIError(fmt.Errorf("device with ID %s doesn't exists", deviceID))
+	}
+	existing, err := listDeviceServices(ctx, deviceID, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	listServices := d.Get("services").(*schema.Set).List()
+	if err := checkDeviceServicesUniqueName(listServices); err != nil {
+		return diagFromAPIError(err)
+	}
+	for _, v := range listServices {
+		serviceName := v.(map[string]interface{})["service_name"].(string)
+		if slices.ContainsFunc(existing, func(s jsonDeviceService) bool { return s.ServiceName == serviceName }) {
+			return diagFromAPIError(fmt.Errorf("service_name %s on device_id %s already exists", serviceName, deviceID))
+		}
+	}
+	d.SetId(deviceID)
+	services := make([]map[string]interface{}, len(listServices))
+	for i, v := range listServices {
+		services[i] = v.(map[string]interface{})
+	}
+	if err := forEachDeviceServicesService(ctx, services, deviceServicesMaxConcurrentRequests,
+		func(ctx context.Context, service map[string]interface{}) error {
+			return addDeviceServicesService(ctx, deviceID, service, m)
+		},
+	); err != nil {
+		// keep the services already created in the state
+		if diags := resourceDeviceServicesRead(ctx, d, m); diags.HasError() {
+			return diags
+		}
+
+		return diagFromAPIError(err)
+	}
+
+	return resourceDeviceServicesRead(ctx, d, m)
+}
+
+func resourceDeviceServicesRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceServicesVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readDeviceOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+
+		return nil
+	}
+	services, err := listDeviceServices(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillDeviceServices(d, services)
+
+	return nil
+}
+
+func resourceDeviceServicesUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceDeviceServicesVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("services") {
+		oldServices, newServices := d.GetChange("services")
+		if err := checkDeviceServicesUniqueName(newServices.(*schema.Set).List()); err != nil {
+			return diagFromAPIError(err)
+		}
+		toDelete, toUpdate, toCreate := diffDeviceServices(
+			oldServices.(*schema.Set).List(), newServices.(*schema.Set).List())
+		existing, err := listDeviceServices(ctx, d.Id(), m)
+		if err != nil {
+			return diagFromAPIError(err)
+		}
+		serviceIDs := make(map[string]string, len(existing))
+		for _, v := range existing {
+			serviceIDs[v.ServiceName] = v.ID
+		}
+		// delete first to release the ports used by the removed services
+		if err := forEachDeviceServicesService(ctx, toDelete, deviceServicesMaxConcurrentRequests,
+			func(ctx context.Context, service map[string]interface{}) error {
+				serviceID, ok := serviceIDs[service["service_name"].(string)]
+				if !ok {
+					return nil
+				}
+
+				return deleteDeviceServicesService(ctx, d.Id(), serviceID, m)
+			},
+		); err != nil {
+			return diagFromAPIError(err)
+		}
+		if err := forEachDeviceServicesService(ctx, toUpdate, deviceServicesMaxConcurrentRequests,
+			func(ctx context.Context, service map[string]interface{}) error {
+				serviceID, ok := serviceIDs[service["service_name"].(string)]
+				if !ok {
+					return fmt.Errorf("service_name %s on device_id %s doesn't exists anymore",
+						service["service_name"].(string), d.Id())
+				}
+
+				return updateDeviceServicesService(ctx, d.Id(), serviceID, service, m)
+			},
+		); err != nil {
+			return diagFromAPIError(err)
+		}
+		if err := forEachDeviceServicesService(ctx, toCreate, deviceServicesMaxConcurrentRequests,
+			func(ctx context.Context, service map[string]interface{}) error {
+				return addDeviceServicesService(ctx, d.Id(), service, m)
+			},
+		); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	d.Partial(false)
+
+	return resourceDeviceServicesRead(ctx, d, m)
+}
+
+func resourceDeviceServicesDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDeviceServicesVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	existing, err := listDeviceServices(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	listServices := d.Get("services").(*schema.Set).List()
+	services := make([]map[string]interface{}, len(listServices))
+	for i, v := range listServices {
+		services[i] = v.(map[string]interface{})
+	}
+	if err := forEachDeviceServicesService(ctx, services, deviceServicesMaxConcurrentRequests,
+		func(ctx context.Context, service map[string]interface{}) error {
+			serviceName := service["service_name"].(string)
+			idx := slices.IndexFunc(existing, func(s jsonDeviceService) bool { return s.ServiceName == serviceName })
+			if idx < 0 {
+				return nil
+			}
+
+			return deleteDeviceServicesService(ctx, d.Id(), existing[idx].ID, m)
+		},
+	); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceDeviceServicesImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceDeviceServicesVersionCheck(c); err != nil {
+		return nil, err
+	}
+	cfg, err := readDeviceOptions(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ID == "" {
+		return nil, fmt.Errorf("don't find device with id %s (id must be <device_id>)", d.Id())
+	}
+	services, err := listDeviceServices(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if tfErr := d.Set("device_id", d.Id()); tfErr != nil {
+		panic(tfErr)
+	}
+	fillDeviceServices(d, services)
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+// forEachDeviceServicesService calls request for each service with at most limit requests in parallel,
+// no new request is started after an error and the errors of the started requests are joined.
+func forEachDeviceServicesService(
+	ctx context.Context, services []map[string]interface{}, limit int,
+	request func(context.Context, map[string]interface{}) error,
+) error {
+	requestCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var errs []error
+	semaphore := make(chan struct{}, limit)
+	for _, service := range services {
+		select {
+		case semaphore <- struct{}{}:
+		case <-requestCtx.Done():
+		}
+		if requestCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := request(requestCtx, service); err != nil {
+				mutex.Lock()
+				// the requests canceled after the first error only add noise
+				if len(errs) == 0 || !errors.Is(err, context.Canceled) {
+					errs = append(errs, err)
+				}
+				mutex.Unlock()
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return ctx.Err()
+}
+
+func checkDeviceServicesUniqueName(listServices []interface{}) error {
+	names := make([]string, 0, len(listServices))
+	for _, v := range listServices {
+		serviceName := v.(map[string]interface{})["service_name"].(string)
+		if slices.Contains(names, serviceName) {
+			return fmt.Errorf("service_name %s is declared more than once in services", serviceName)
+		}
+		names = append(names, serviceName)
+	}
+
+	return nil
+}
+
+// diffDeviceServices compares the services by service_name and returns only the changed members.
+// A service with a new protocol is deleted and created again, the protocol can't be updated.
+func diffDeviceServices(
+	oldServices, newServices []interface{},
+) (
+	toDelete, toUpdate, toCreate []map[string]interface{},
+) {
+	oldByName := make(map[string]map[string]interface{}, len(oldServices))
+	for _, v := range oldServices {
+		service := v.(map[string]interface{})
+		oldByName[service["service_name"].(string)] = service
+	}
+	newNames := make(map[string]bool, len(newServices))
+	for _, v := range newServices {
+		service := v.(map[string]interface{})
+		newNames[service["service_name"].(string)] = true
+		oldService, ok := oldByName[service["service_name"].(string)]
+		switch {
+		case !ok:
+			toCreate = append(toCreate, service)
+		case oldService["protocol"] != service["protocol"]:
+			toDelete = append(toDelete, oldService)
+			toCreate = append(toCreate, service)
+		case !deviceServicesServiceEqual(oldService, service):
+			toUpdate = append(toUpdate, service)
+		}
+	}
+	for _, v := range oldServices {
+		service := v.(map[string]interface{})
+		if !newNames[service["service_name"].(string)] {
+			toDelete = append(toDelete, service)
+		}
+	}
+
+	return toDelete, toUpdate, toCreate
+}
+
+func deviceServicesServiceEqual(a, b map[string]interface{}) bool {
+	if a["connection_policy"] != b["connection_policy"] || a["port"] != b["port"] {
+		return false
+	}
+	for _, key := range []string{"global_domains", "subprotocols"} {
+		setA, _ := a[key].(*schema.Set)
+		setB, _ := b[key].(*schema.Set)
+		if setA == nil || setB == nil {
+			if (setA != nil && setA.Len() > 0) || (setB != nil && setB.Len() > 0) {
+				return false
+			}
+
+			continue
+		}
+		if !setA.Equal(setB) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func addDeviceServicesService(
+	ctx context.Context, deviceID string, service map[string]interface{}, m interface{},
+) error {
+	c := m.(*Client)
+	if err := checkDeviceServiceProtocol(c.bastionAPIVersion, service["protocol"].(string)); err != nil {
+		return fmt.Errorf("service_name %s: %w", service["service_name"].(string), err)
+	}
+	jsonData, err := prepareDeviceServicesServiceJSON(service, true)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/services/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code == http.StatusConflict {
+		return fmt.Errorf("service_name %s: %w with body:\n%s", jsonData.ServiceName, errDeviceServiceConflict, body)
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateDeviceServicesService(
+	ctx context.Context, deviceID, serviceID string, service map[string]interface{}, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData, err := prepareDeviceServicesServiceJSON(service, false)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx,
+		"/devices/"+deviceID+"/services/"+serviceID+"?force=true", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteDeviceServicesService(
+	ctx context.Context, deviceID, serviceID string, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/services/"+serviceID, http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent && code != http.StatusNotFound {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+// prepareDeviceServicesServiceJSON is like prepareDeviceServiceJSON for a member of services,
+// the lists are always sent as the whole service is sent.
+func prepareDeviceServicesServiceJSON(
+	service map[string]interface{}, newResource bool,
+) (
+	jsonDeviceService, error,
+) {
+	jsonData := jsonDeviceService{
+		ConnectionPolicy: service["connection_policy"].(string),
+		Port:             service["port"].(int),
+	}
+	protocol := service["protocol"].(string)
+	if newResource {
+		jsonData.ServiceName = service["service_name"].(string)
+		jsonData.Protocol = protocol
+	}
+	globalDomains := make([]string, 0)
+	if setGlobalDomains, ok := service["global_domains"].(*schema.Set); ok {
+		for _, v := range setGlobalDomains.List() {
+			globalDomains = append(globalDomains, v.(string))
+		}
+	}
+	jsonData.GlobalDomains = &globalDomains
+	var listSubProtocols []interface{}
+	if setSubProtocols, ok := service["subprotocols"].(*schema.Set); ok {
+		listSubProtocols = setSubProtocols.List()
+	}
+	if err := checkDeviceServiceSubProtocolsMix(listSubProtocols); err != nil {
+		return jsonData, fmt.Errorf("service_name %s: %w", service["service_name"].(string), err)
+	}
+	subProtocols := make([]string, len(listSubProtocols))
+	for i, v := range listSubProtocols {
+		var valid []string
+		switch protocol {
+		case "SSH":
+			valid = sshSubProtocolsValid()
+		case "RDP":
+			valid = rdpSubProtocolsValid()
+		default:
+			return jsonData, fmt.Errorf("service_name %s: subprotocols can only be set for SSH and RDP services, "+
+				"not for %s service", service["service_name"].(string), protocol)
+		}
+		if !slices.Contains(valid, v.(string)) {
+			return jsonData, fmt.Errorf("service_name %s: subprotocols %s not valid for %s service",
+				service["service_name"].(string), v, protocol)
+		}
+		subProtocols[i] = v.(string)
+	}
+	if len(subProtocols) > 0 || !newResource {
+		jsonData.SubProtocols = &subProtocols
+	}
+
+	return jsonData, nil
+}
+
+func fillDeviceServices(d *schema.ResourceData, jsonData []jsonDeviceService) {
+	services := make([]map[string]interface{}, len(jsonData))
+	for i, v := range jsonData {
+		services[i] = map[string]interface{}{
+			"service_name":      v.ServiceName,
+			"connection_policy": v.ConnectionPolicy,
+			"port":              v.Port,
+			"protocol":          v.Protocol,
+			"global_domains":    []string{},
+			"subprotocols":      []string{},
+		}
+		if v.GlobalDomains != nil {
+			services[i]["global_domains"] = *v.GlobalDomains
+		}
+		if v.SubProtocols != nil {
+			services[i]["subprotocols"] = *v.SubProtocols
+		}
+	}
+	if tfErr := d.Set("services", services); tfErr != nil {
+		panic(tfErr)
+	}
+}
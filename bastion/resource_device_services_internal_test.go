@@ -0,0 +1,199 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testDeviceServicesList(t *testing.T, services ...map[string]interface{}) []interface{} {
+	t.Helper()
+	listServices := make([]interface{}, len(services))
+	for i, v := range services {
+		listServices[i] = v
+	}
+	d := schema.TestResourceDataRaw(t, resourceDeviceServices().Schema, map[string]interface{}{
+		"device_id": "d1",
+		"services":  listServices,
+	})
+
+	return d.Get("services").(*schema.Set).List()
+}
+
+func TestDiffDeviceServices(t *testing.T) {
+	ssh := map[string]interface{}{
+		"service_name": "SSH", "connection_policy": "SSH", "port": 22, "protocol": "SSH",
+		"subprotocols": []interface{}{"SSH_SHELL_SESSION"},
+	}
+	sshNewPort := map[string]interface{}{
+		"service_name": "SSH", "connection_policy": "SSH", "port": 2222, "protocol": "SSH",
+		"subprotocols": []interface{}{"SSH_SHELL_SESSION"},
+	}
+	rdp := map[string]interface{}{
+		"service_name": "RDP", "connection_policy": "RDP", "port": 3389, "protocol": "RDP",
+	}
+	vnc := map[string]interface{}{
+		"service_name": "VNC", "connection_policy": "VNC", "port": 5900, "protocol": "VNC",
+	}
+	vncAsRDP := map[string]interface{}{
+		"service_name": "VNC", "connection_policy": "RDP", "port": 5900, "protocol": "RDP",
+	}
+	names := func(services []map[string]interface{}) []string {
+		result := make([]string, len(services))
+		for i, v := range services {
+			result[i] = v["service_name"].(string)
+		}
+		slices.Sort(result)
+
+		return result
+	}
+	tests := map[string]struct {
+		oldServices    []interface{}
+		newServices    []interface{}
+		expectedDelete []string
+		expectedUpdate []string
+		expectedCreate []string
+	}{
+		"unchanged": {
+			oldServices: testDeviceServicesList(t, ssh, rdp),
+			newServices: testDeviceServicesList(t, rdp, ssh),
+		},
+		"only the changed member": {
+			oldServices:    testDeviceServicesList(t, ssh, rdp),
+			newServices:    testDeviceServicesList(t, sshNewPort, rdp),
+			expectedUpdate: []string{"SSH"},
+		},
+		"added and removed": {
+			oldServices:    testDeviceServicesList(t, ssh, rdp),
+			newServices:    testDeviceServicesList(t, ssh, vnc),
+			expectedDelete: []string{"RDP"},
+			expectedCreate: []string{"VNC"},
+		},
+		"new protocol": {
+			oldServices:    testDeviceServicesList(t, ssh, vnc),
+			newServices:    testDeviceServicesList(t, ssh, vncAsRDP),
+			expectedDelete: []string{"VNC"},
+			expectedCreate: []string{"VNC"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			toDelete, toUpdate, toCreate := diffDeviceServices(tt.oldServices, tt.newServices)
+			if got := names(toDelete); !slices.Equal(got, tt.expectedDelete) && len(got)+len(tt.expectedDelete) > 0 {
+				t.Errorf("expected to delete %v, got %v", tt.expectedDelete, got)
+			}
+			if got := names(toUpdate); !slices.Equal(got, tt.expectedUpdate) && len(got)+len(tt.expectedUpdate) > 0 {
+				t.Errorf("expected to update %v, got %v", tt.expectedUpdate, got)
+			}
+			if got := names(toCreate); !slices.Equal(got, tt.expectedCreate) && len(got)+len(tt.expectedCreate) > 0 {
+				t.Errorf("expected to create %v, got %v", tt.expectedCreate, got)
+			}
+		})
+	}
+}
+
+func TestForEachDeviceServicesService(t *testing.T) {
+	services := make([]map[string]interface{}, 10)
+	for i := range services {
+		services[i] = map[string]interface{}{"service_name": fmt.Sprintf("SVC%d", i)}
+	}
+	t.Run("bounded", func(t *testing.T) {
+		var running, maxRunning, calls atomic.Int32
+		err := forEachDeviceServicesService(context.Background(), services, 3,
+			func(_ context.Context, _ map[string]interface{}) error {
+				calls.Add(1)
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					current := maxRunning.Load()
+					if n <= current || maxRunning.CompareAndSwap(current, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls.Load() != 10 {
+			t.Errorf("expected 10 requests, got %d", calls.Load())
+		}
+		if maxRunning.Load() > 3 {
+			t.Errorf("expected at most 3 requests in parallel, got %d", maxRunning.Load())
+		}
+	})
+	t.Run("stop after error", func(t *testing.T) {
+		var calls atomic.Int32
+		err := forEachDeviceServicesService(context.Background(), services, 1,
+			func(_ context.Context, service map[string]interface{}) error {
+				calls.Add(1)
+				if service["service_name"] == "SVC1" {
+					return errors.New("SVC1 rejected")
+				}
+
+				return nil
+			})
+		if err == nil || !strings.Contains(err.Error(), "SVC1 rejected") {
+			t.Fatalf("expected the error of SVC1, got %v", err)
+		}
+		if calls.Load() != 2 {
+			t.Errorf("expected no request after the error, got %d requests", calls.Load())
+		}
+	})
+}
+
+// benchmarkDeviceServicesCreate creates the services of a device on a Bastion answering after latency,
+// with at most limit requests in parallel.
+func benchmarkDeviceServicesCreate(b *testing.B, count, limit int, latency time.Duration) {
+	b.Helper()
+	c := newTestClient(b, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3.12/devices/d1/services/" {
+			b.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var jsonData jsonDeviceService
+		if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+			b.Errorf("decoding request: %s", err)
+		}
+		time.Sleep(latency)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	services := make([]map[string]interface{}, count)
+	for i := range services {
+		services[i] = map[string]interface{}{
+			"service_name":      fmt.Sprintf("SSH%d", i),
+			"connection_policy": "SSH",
+			"port":              2200 + i,
+			"protocol":          "SSH",
+		}
+	}
+	b.ResetTimer()
+	for range b.N {
+		if err := forEachDeviceServicesService(context.Background(), services, limit,
+			func(ctx context.Context, service map[string]interface{}) error {
+				return addDeviceServicesService(ctx, "d1", service, c)
+			},
+		); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+func BenchmarkDeviceServicesCreate(b *testing.B) {
+	b.Run("sequential", func(b *testing.B) {
+		benchmarkDeviceServicesCreate(b, 24, 1, 2*time.Millisecond)
+	})
+	b.Run("bounded", func(b *testing.B) {
+		benchmarkDeviceServicesCreate(b, 24, deviceServicesMaxConcurrentRequests, 2*time.Millisecond)
+	})
+}
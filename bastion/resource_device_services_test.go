@@ -0,0 +1,98 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceDeviceServices_basic(t *testing.T) {
+	resourceName := "wallix-bastion_device_services.testacc_DeviceServices"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceServicesCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "services.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceDeviceServicesUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "services.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "services.*", map[string]string{
+						"service_name": "testacc_DeviceServices_ssh",
+						"port":         "2222",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "services.*", map[string]string{
+						"service_name": "testacc_DeviceServices_vnc",
+						"protocol":     "VNC",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resourceName].Primary.Attributes["device_id"], nil
+				},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceServicesCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServices" {
+  device_name = "testacc_DeviceServices"
+  host        = "testacc_services.device"
+}
+resource "wallix-bastion_device_services" "testacc_DeviceServices" {
+  device_id = wallix-bastion_device.testacc_DeviceServices.id
+  services {
+    service_name      = "testacc_DeviceServices_ssh"
+    connection_policy = "SSH"
+    port              = 22
+    protocol          = "SSH"
+    subprotocols      = ["SSH_SHELL_SESSION"]
+  }
+  services {
+    service_name      = "testacc_DeviceServices_rdp"
+    connection_policy = "RDP"
+    port              = 3389
+    protocol          = "RDP"
+  }
+}
+`
+}
+
+func testAccResourceDeviceServicesUpdate() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceServices" {
+  device_name = "testacc_DeviceServices"
+  host        = "testacc_services.device"
+}
+resource "wallix-bastion_device_services" "testacc_DeviceServices" {
+  device_id = wallix-bastion_device.testacc_DeviceServices.id
+  services {
+    service_name      = "testacc_DeviceServices_ssh"
+    connection_policy = "SSH"
+    port              = 2222
+    protocol          = "SSH"
+    subprotocols      = ["SSH_SHELL_SESSION"]
+  }
+  services {
+    service_name      = "testacc_DeviceServices_vnc"
+    connection_policy = "VNC"
+    port              = 5900
+    protocol          = "VNC"
+  }
+}
+`
+}
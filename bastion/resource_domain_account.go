@@ -105,46 +105,46 @@ func resourceDomainAccount() *schema.Resource {
 	}
 }
 
-func resourceDomainAccountVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceDomainAccountVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_domain_account not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_domain_account not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceDomainAccountCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfgDomain, err := readDomainOptions(ctx, d.Get("domain_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDomain.ID == "" {
-		return diag.FromErr(fmt.Errorf("domain_id with ID %s doesn't exists", d.Get("domain_id").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_id with ID %s doesn't exists", d.Get("domain_id").(string)))
 	}
 	_, ex, err := searchResourceDomainAccount(ctx, d.Get("domain_id").(string), d.Get("account_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("account_name %s on domain_id %s already exists",
+		return diagFromAPIError(fmt.Errorf("account_name %s on domain_id %s already exists",
 			d.Get("account_name").(string), d.Get("domain_id").(string)))
 	}
 	err = addDomainAccount(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceDomainAccount(ctx, d.Get("domain_id").(string), d.Get("account_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("account_name %s on domain_id %s not found after POST",
+		return diagFromAPIError(fmt.Errorf("account_name %s on domain_id %s not found after POST",
 			d.Get("account_name").(string), d.Get("domain_id").(string)))
 	}
 	d.SetId(id)
@@ -156,12 +156,12 @@ func resourceDomainAccountRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDomainAccountOptions(ctx, d.Get("domain_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -177,11 +177,11 @@ func resourceDomainAccountUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateDomainAccount(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -192,11 +192,11 @@ func resourceDomainAccountDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteDomainAccount(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -209,7 +209,7 @@ func resourceDomainAccountImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceDomainAccountVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceDomainAccountVersionCheck(c); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -245,13 +245,13 @@ func searchResourceDomainAccount(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
+	body, code, err := c.newRequestPaged(ctx,
 		"/domains/"+domainID+"/accounts/?q=account_name="+accountName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonDomainAccount
 	err = json.Unmarshal([]byte(body), &results)
@@ -279,7 +279,7 @@ func addDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -299,7 +299,7 @@ func updateDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -315,7 +315,7 @@ func deleteDomainAccount(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -363,7 +363,7 @@ func readDomainAccountOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
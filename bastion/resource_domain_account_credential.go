@@ -1,6 +1,7 @@
 package bastion
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,9 +10,11 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/ssh"
 )
 
 func resourceDomainAccountCredential() *schema.Resource {
@@ -23,6 +26,10 @@ func resourceDomainAccountCredential() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDomainAccountCredentialImport,
 		},
+		CustomizeDiff: forceNewWriteOnlyIfInState("private_key"),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateDomainAccountCredentialCertificate,
+		},
 		Schema: map[string]*schema.Schema{
 			"domain_id": {
 				Type:     schema.TypeString,
@@ -38,13 +45,14 @@ func resourceDomainAccountCredential() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"password", "ssh_key"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"password", "ssh_key", "certificate"}, false),
 			},
 			"passphrase": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Sensitive:    true,
-				RequiredWith: []string{"private_key"},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				RequiredWith:     []string{"private_key"},
+				DiffSuppressFunc: suppressDomainAccountCredentialKeyDiffAfterImport,
 			},
 			"password": {
 				Type:      schema.TypeString,
@@ -52,10 +60,16 @@ func resourceDomainAccountCredential() *schema.Resource {
 				Sensitive: true,
 			},
 			"private_key": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				Sensitive: true,
-				ForceNew:  true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressDomainAccountCredentialKeyDiffAfterImport,
+			},
+			"certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"private_key"},
 			},
 			"public_key": {
 				Type:     schema.TypeString,
@@ -65,60 +79,120 @@ func resourceDomainAccountCredential() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"auto_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceDomainAccountCredentialVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+// validateDomainAccountCredentialCertificate rejects at plan time the type certificate without certificate,
+// the values unknown until the apply are checked by the Bastion.
+func validateDomainAccountCredentialCertificate(
+	_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+	credType := req.RawConfig.GetAttr("type")
+	if !credType.IsKnown() || credType.IsNull() || credType.AsString() != "certificate" {
+		return
+	}
+	if certificate := req.RawConfig.GetAttr("certificate"); certificate.IsKnown() && certificate.IsNull() {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       `certificate must be set with type "certificate"`,
+			AttributePath: cty.GetAttrPath("certificate"),
+		})
+	}
+}
+
+// suppressDomainAccountCredentialKeyDiffAfterImport suppresses the diff on private_key and passphrase
+// which aren't in the state (i.e. just after an import) when the public key of the configured private key
+// matches the public_key returned by the API. Another key is still applied.
+func suppressDomainAccountCredentialKeyDiffAfterImport(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if d.Id() == "" || oldValue != "" || newValue == "" {
+		return false
+	}
+	if k == "passphrase" {
+		if privateKeyState, _ := d.GetChange("private_key"); privateKeyState.(string) != "" {
+			return false
+		}
+	}
+	publicKey := d.Get("public_key").(string)
+	if publicKey == "" {
+		return false
+	}
+	expected, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return false
+	}
+	var signer ssh.Signer
+	if passphrase := d.Get("passphrase").(string); passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(d.Get("private_key").(string)), []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(d.Get("private_key").(string)))
+	}
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(signer.PublicKey().Marshal(), expected.Marshal())
+}
+
+func resourceDomainAccountCredentialVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_domain_account_credential not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_domain_account_credential not available with api version %s",
+		c.bastionAPIVersion)
 }
 
 func resourceDomainAccountCredentialCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfgDomain, err := readDomainOptions(ctx, d.Get("domain_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgDomain.ID == "" {
-		return diag.FromErr(fmt.Errorf("domain_id with ID %s doesn't exists", d.Get("domain_id").(string)))
+		return diagFromAPIError(fmt.Errorf("domain_id with ID %s doesn't exists", d.Get("domain_id").(string)))
 	}
 	cfgAccount, err := readDomainAccountOptions(ctx, d.Get("domain_id").(string), d.Get("account_id").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfgAccount.ID == "" {
-		return diag.FromErr(fmt.Errorf("account_id with ID %s on domain_id %s doesn't exists",
+		return diagFromAPIError(fmt.Errorf("account_id with ID %s on domain_id %s doesn't exists",
 			d.Get("account_id").(string), d.Get("domain_id").(string)))
 	}
 	_, ex, err := searchResourceDomainAccountCredential(ctx,
 		d.Get("domain_id").(string), d.Get("account_id").(string), d.Get("type").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("credential type %s on account_id %s, domain_id %s already exists",
+		return diagFromAPIError(fmt.Errorf("credential type %s on account_id %s, domain_id %s already exists",
 			d.Get("type").(string), d.Get("account_id").(string), d.Get("domain_id").(string)))
 	}
 	err = addDomainAccountCredential(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceDomainAccountCredential(ctx,
 		d.Get("domain_id").(string), d.Get("account_id").(string), d.Get("type").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf(
+		return diagFromAPIError(fmt.Errorf(
 			"credential type %s on account_id %s, domain_id %s not found after POST",
 			d.Get("type").(string), d.Get("account_id").(string), d.Get("domain_id").(string)))
 	}
@@ -131,13 +205,13 @@ func resourceDomainAccountCredentialRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readDomainAccountCredentialOptions(ctx,
 		d.Get("domain_id").(string), d.Get("account_id").(string), d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -153,11 +227,11 @@ func resourceDomainAccountCredentialUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateDomainAccountCredential(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -168,11 +242,11 @@ func resourceDomainAccountCredentialDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceDomainAccountCredentialVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteDomainAccountCredential(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -185,7 +259,7 @@ func resourceDomainAccountCredentialImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceDomainAccountCredentialVersionCheck(c); err != nil {
 		return nil, err
 	}
 	idSplit := strings.Split(d.Id(), "/")
@@ -224,14 +298,14 @@ func searchResourceDomainAccountCredential(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
+	body, code, err := c.newRequestPaged(ctx,
 		"/domains/"+domainID+"/accounts/"+accountID+
 			"/credentials/", http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonCredential
 	err = json.Unmarshal([]byte(body), &results)
@@ -261,7 +335,7 @@ func addDomainAccountCredential(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	if propagate {
@@ -275,7 +349,7 @@ func addDomainAccountCredential(
 			return err
 		}
 		if code != http.StatusOK && code != http.StatusNoContent {
-			return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+			return newAPIError("api doesn't return OK or NoContent", code, body)
 		}
 	}
 
@@ -321,7 +395,7 @@ func updateDomainAccountCredential(
 	}
 
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("API didn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("API didn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -338,7 +412,7 @@ func deleteDomainAccountCredential(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -364,15 +438,33 @@ func prepareDomainAccountCredentialJSON(
 		switch jsonData.Type {
 		case "password":
 			jsonData.Password = d.Get("password").(string)
-		case "ssh_key":
+		case "ssh_key", "certificate":
 			jsonData.PrivateKey = d.Get("private_key").(string)
 			jsonData.Passphrase = d.Get("passphrase").(string)
+			jsonData.Certificate = d.Get("certificate").(string)
 		}
+		jsonData.AutoChange = domainAccountCredentialAutoChange(d)
 	}
 
 	return jsonData
 }
 
+// domainAccountCredentialAutoChange returns auto_change only when it's set in the configuration,
+// the Bastion keeps its value otherwise.
+func domainAccountCredentialAutoChange(d *schema.ResourceData) *bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	v := rawConfig.GetAttr("auto_change")
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	autoChange := v.True()
+
+	return &autoChange
+}
+
 func readDomainAccountCredentialOptions(
 	ctx context.Context, domainID, accountID, credentialID string, m interface{},
 ) (
@@ -390,7 +482,7 @@ func readDomainAccountCredentialOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -418,4 +510,12 @@ func fillDomainAccountCredential(d *schema.ResourceData, jsonData jsonCredential
 	if tfErr := d.Set("public_key", jsonData.PublicKey); tfErr != nil {
 		panic(tfErr)
 	}
+	if tfErr := d.Set("certificate", jsonData.Certificate); tfErr != nil {
+		panic(tfErr)
+	}
+	if jsonData.AutoChange != nil {
+		if tfErr := d.Set("auto_change", *jsonData.AutoChange); tfErr != nil {
+			panic(tfErr)
+		}
+	}
 }
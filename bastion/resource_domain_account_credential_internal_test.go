@@ -0,0 +1,302 @@
+package bastion
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/crypto/ssh"
+)
+
+// testDomainAccountCredentialKey returns a private key in the OpenSSH format,
+// encrypted when passphrase isn't empty, and its public key in the authorized_keys format.
+func testDomainAccountCredentialKey(t *testing.T, passphrase string) (string, string) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+	var block *pem.Block
+	if passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte(passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(privateKey, "")
+	}
+	if err != nil {
+		t.Fatalf("marshaling private key: %s", err)
+	}
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("converting public key: %s", err)
+	}
+
+	return string(pem.EncodeToMemory(block)), string(ssh.MarshalAuthorizedKey(sshPublicKey))
+}
+
+func TestResourceDomainAccountCredentialDiffAfterImport(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		first       string
+		changed     string
+		credType    string
+		requiresNew bool
+	}{
+		"password": {
+			key:      "password",
+			first:    "secret",
+			changed:  "changed",
+			credType: "password",
+		},
+		"private_key": {
+			key:         "private_key",
+			first:       "key",
+			changed:     "other_key",
+			credType:    "ssh_key",
+			requiresNew: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := resourceDomainAccountCredential()
+			// the state of an import doesn't contain the values never returned by the API
+			imported := &terraform.InstanceState{
+				ID: "c1",
+				Attributes: map[string]string{
+					"id":         "c1",
+					"domain_id":  "d1",
+					"account_id": "a1",
+					"type":       tt.credType,
+				},
+			}
+			config := func(value string) *terraform.ResourceConfig {
+				return terraform.NewResourceConfigRaw(map[string]interface{}{
+					"domain_id":  "d1",
+					"account_id": "a1",
+					"type":       tt.credType,
+					tt.key:       value,
+				})
+			}
+			diff, err := r.Diff(context.Background(), imported, config(tt.first), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff == nil || diff.Attributes[tt.key] == nil {
+				t.Fatalf("expected the first apply after import to send %s, got diff %v", tt.key, diff)
+			}
+			if diff.RequiresNew() {
+				t.Errorf("expected %s to be updated in place after import, got diff %v", tt.key, diff)
+			}
+			d, err := schema.InternalMap(r.Schema).Data(imported, diff)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			applied := d.State()
+			if applied.Attributes[tt.key] != tt.first {
+				t.Fatalf("expected the apply to keep %s in the state, got %v", tt.key, applied.Attributes)
+			}
+
+			diff, err = r.Diff(context.Background(), applied, config(tt.first), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff != nil && len(diff.Attributes) > 0 {
+				t.Errorf("expected no diff with the same %s, got %v", tt.key, diff)
+			}
+			diff, err = r.Diff(context.Background(), applied, config(tt.changed), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff == nil || diff.Attributes[tt.key] == nil {
+				t.Fatalf("expected a change of %s after import to be planned, got diff %v", tt.key, diff)
+			}
+			if diff.RequiresNew() != tt.requiresNew {
+				t.Errorf("expected replacement %t, got diff %v", tt.requiresNew, diff)
+			}
+		})
+	}
+}
+
+func TestResourceDomainAccountCredentialKeyDiffAfterImport(t *testing.T) {
+	privateKey, publicKey := testDomainAccountCredentialKey(t, "")
+	otherPrivateKey, _ := testDomainAccountCredentialKey(t, "")
+	encryptedPrivateKey, encryptedPublicKey := testDomainAccountCredentialKey(t, "secret")
+	tests := map[string]struct {
+		publicKey  string
+		config     map[string]interface{}
+		expectDiff []string
+	}{
+		"same key": {
+			publicKey: publicKey,
+			config:    map[string]interface{}{"private_key": privateKey},
+		},
+		"same encrypted key": {
+			publicKey: encryptedPublicKey,
+			config:    map[string]interface{}{"private_key": encryptedPrivateKey, "passphrase": "secret"},
+		},
+		"other key": {
+			publicKey:  publicKey,
+			config:     map[string]interface{}{"private_key": otherPrivateKey},
+			expectDiff: []string{"private_key"},
+		},
+		"wrong passphrase": {
+			publicKey:  encryptedPublicKey,
+			config:     map[string]interface{}{"private_key": encryptedPrivateKey, "passphrase": "other"},
+			expectDiff: []string{"private_key", "passphrase"},
+		},
+		"without public_key": {
+			config:     map[string]interface{}{"private_key": privateKey},
+			expectDiff: []string{"private_key"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := resourceDomainAccountCredential()
+			imported := &terraform.InstanceState{
+				ID: "c1",
+				Attributes: map[string]string{
+					"id":          "c1",
+					"domain_id":   "d1",
+					"account_id":  "a1",
+					"type":        "ssh_key",
+					"public_key":  tt.publicKey,
+					"certificate": "",
+				},
+			}
+			config := map[string]interface{}{
+				"domain_id":  "d1",
+				"account_id": "a1",
+				"type":       "ssh_key",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			diff, err := r.Diff(context.Background(), imported, terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := 0
+			if diff != nil {
+				got = len(diff.Attributes)
+			}
+			if got != len(tt.expectDiff) {
+				t.Fatalf("expected a diff on %v, got %v", tt.expectDiff, diff)
+			}
+			for _, k := range tt.expectDiff {
+				if diff.Attributes[k] == nil {
+					t.Errorf("expected a diff on %s, got %v", k, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDomainAccountCredentialCertificate(t *testing.T) {
+	tests := map[string]struct {
+		credType    cty.Value
+		certificate cty.Value
+		expectError bool
+	}{
+		"ssh_key without certificate": {
+			credType:    cty.StringVal("ssh_key"),
+			certificate: cty.NullVal(cty.String),
+		},
+		"certificate": {
+			credType:    cty.StringVal("certificate"),
+			certificate: cty.StringVal("ssh-ed25519-cert-v01@openssh.com AAAA"),
+		},
+		"certificate unknown": {
+			credType:    cty.StringVal("certificate"),
+			certificate: cty.UnknownVal(cty.String),
+		},
+		"certificate without certificate": {
+			credType:    cty.StringVal("certificate"),
+			certificate: cty.NullVal(cty.String),
+			expectError: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var resp schema.ValidateResourceConfigFuncResponse
+			validateDomainAccountCredentialCertificate(context.Background(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"type":        tt.credType,
+					"certificate": tt.certificate,
+				}),
+			}, &resp)
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Errorf("expected error %t, got %v", tt.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPrepareDomainAccountCredentialJSONAutoChange(t *testing.T) {
+	r := resourceDomainAccountCredential()
+	coreSchema := r.CoreConfigSchema()
+	tests := map[string]struct {
+		autoChange cty.Value
+		credType   string
+		expectSent bool
+		expect     bool
+	}{
+		"not set": {
+			autoChange: cty.NullVal(cty.Bool),
+			credType:   "password",
+		},
+		"enabled": {
+			autoChange: cty.True,
+			credType:   "password",
+			expectSent: true,
+			expect:     true,
+		},
+		"disabled on a certificate": {
+			autoChange: cty.False,
+			credType:   "certificate",
+			expectSent: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			attrs := make(map[string]cty.Value)
+			for attrName, attrType := range coreSchema.ImpliedType().AttributeTypes() {
+				attrs[attrName] = cty.NullVal(attrType)
+			}
+			attrs["domain_id"] = cty.StringVal("d1")
+			attrs["account_id"] = cty.StringVal("a1")
+			attrs["type"] = cty.StringVal(tt.credType)
+			attrs["auto_change"] = tt.autoChange
+			if tt.credType == "certificate" {
+				attrs["private_key"] = cty.StringVal("key")
+				attrs["certificate"] = cty.StringVal("cert")
+			} else {
+				attrs["password"] = cty.StringVal("secret")
+			}
+			rawConfig := cty.ObjectVal(attrs)
+			diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigShimmed(rawConfig, coreSchema), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			// the raw configuration is set in the diff by the gRPC server of the SDK
+			diff.RawConfig = rawConfig
+			d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			jsonData := prepareDomainAccountCredentialJSON(d, false, false)
+			switch {
+			case !tt.expectSent && jsonData.AutoChange != nil:
+				t.Errorf("expected auto_change not to be sent, got %t", *jsonData.AutoChange)
+			case tt.expectSent && (jsonData.AutoChange == nil || *jsonData.AutoChange != tt.expect):
+				t.Errorf("expected auto_change %t to be sent, got %v", tt.expect, jsonData.AutoChange)
+			}
+			if tt.credType == "certificate" && (jsonData.PrivateKey != "key" || jsonData.Certificate != "cert") {
+				t.Errorf("expected the private key and the certificate to be sent, got %+v", jsonData)
+			}
+		})
+	}
+}
@@ -38,26 +38,26 @@ func resourceEncryption() *schema.Resource {
 	}
 }
 
-func resourceEncryptionVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceEncryptionVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_encryption is not available with API version %s", version)
+	return fmt.Errorf("resource wallix-bastion_encryption is not available with API version %s", c.bastionAPIVersion)
 }
 
 func resourceEncryptionCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceEncryptionVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceEncryptionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 
 	// Add encryption
 	err := addEncryption(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	// Set a static ID since the API doesn't return one
@@ -66,7 +66,7 @@ func resourceEncryptionCreate(
 	// Set persistent attributes
 	err = d.Set("new_passphrase", d.Get("new_passphrase").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return resourceEncryptionRead(ctx, d, m)
@@ -76,13 +76,13 @@ func resourceEncryptionRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceEncryptionVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceEncryptionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	// Verify existence
 	exists, err := verifyEncryption(ctx, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !exists {
 		// Clear the resource ID if it no longer exists
@@ -93,7 +93,7 @@ func resourceEncryptionRead(
 	d.SetId("encryption")
 	err = d.Set("new_passphrase", d.Get("new_passphrase").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -104,8 +104,8 @@ func resourceEncryptionUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceEncryptionVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceEncryptionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 
 	d.Partial(true)
@@ -113,12 +113,12 @@ func resourceEncryptionUpdate(
 	// Update encryption
 	if d.HasChange("current_passphrase") || d.HasChange("new_passphrase") {
 		if err := updateEncryption(ctx, d, m); err != nil {
-			return diag.FromErr(err)
+			return diagFromAPIError(err)
 		}
 		if d.HasChange("new_passphrase") {
 			err := d.Set("new_passphrase", d.Get("new_passphrase"))
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromAPIError(err)
 			}
 		}
 	}
@@ -138,7 +138,7 @@ func addEncryption(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("API didn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("API didn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -154,7 +154,7 @@ func updateEncryption(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("API didn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("API didn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -179,7 +179,7 @@ func verifyEncryption(
 	}
 
 	if code != http.StatusOK {
-		return false, fmt.Errorf("API didn't return OK: %d with body:\n%s", code, body)
+		return false, newAPIError("API didn't return OK", code, body)
 	}
 
 	// Check if encryption exists
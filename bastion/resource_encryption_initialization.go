@@ -0,0 +1,187 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	encryptionStatusReady          = "ready"
+	encryptionStatusNotInitialized = "not_initialized"
+
+	encryptionSealedStateUnsealed      = "unsealed"
+	encryptionSealedStateUninitialized = "uninitialized"
+)
+
+// jsonEncryptionStatus is the status of the data encryption,
+// in encryption with api v3.8 and in sealed_state with api v3.12.
+type jsonEncryptionStatus struct {
+	Encryption  string `json:"encryption"`
+	SealedState string `json:"sealed_state"`
+}
+
+// initialized returns true if the passphrase of the data encryption has been set.
+func (s jsonEncryptionStatus) initialized() bool {
+	return s.Encryption != encryptionStatusNotInitialized && s.SealedState != encryptionSealedStateUninitialized
+}
+
+// ready returns true if the data encryption is initialized and unlocked.
+func (s jsonEncryptionStatus) ready() bool {
+	return s.Encryption == encryptionStatusReady || s.SealedState == encryptionSealedStateUnsealed
+}
+
+func resourceEncryptionInitialization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEncryptionInitializationCreate,
+		ReadContext:   resourceEncryptionInitializationRead,
+		UpdateContext: resourceEncryptionInitializationUpdate,
+		DeleteContext: resourceEncryptionInitializationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceEncryptionInitializationImport,
+		},
+		CustomizeDiff: forceNewWriteOnlyIfInState("passphrase"),
+		Schema: map[string]*schema.Schema{
+			"passphrase": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"ready": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceEncryptionInitializationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_encryption_initialization not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceEncryptionInitializationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceEncryptionInitializationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	status, err := readEncryptionStatus(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	var diags diag.Diagnostics
+	if status.initialized() {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Encryption already initialized",
+			Detail: "The data encryption of the Bastion is already initialized, " +
+				"wallix-bastion_encryption_initialization adopted it without using passphrase. " +
+				"Change the passphrase with the wallix-bastion_encryption resource.",
+		})
+	} else if err := initializeEncryption(ctx, d.Get("passphrase").(string), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("encryptionInitialization")
+
+	return append(diags, resourceEncryptionInitializationRead(ctx, d, m)...)
+}
+
+func resourceEncryptionInitializationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceEncryptionInitializationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	status, err := readEncryptionStatus(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !status.initialized() {
+		// the appliance has been reinstalled, the next apply initializes the encryption again
+		d.SetId("")
+
+		return nil
+	}
+	if tfErr := d.Set("ready", status.ready()); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+func resourceEncryptionInitializationUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	// Only reached after an import, the passphrase in the configuration is kept in the state
+	// without being sent since the encryption is already initialized
+	return resourceEncryptionInitializationRead(ctx, d, m)
+}
+
+func resourceEncryptionInitializationDelete(
+	_ context.Context, _ *schema.ResourceData, _ interface{},
+) diag.Diagnostics {
+	// The encryption can't be de-initialized, so only remove the resource from the state
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Encryption kept initialized",
+		Detail: "The data encryption of the Bastion can't be de-initialized, " +
+			"destroying wallix-bastion_encryption_initialization only removed it from the state.",
+	}}
+}
+
+func resourceEncryptionInitializationImport(
+	d *schema.ResourceData, _ interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	// Since the resource does not have a unique ID, use the static "encryptionInitialization" ID
+	d.SetId("encryptionInitialization")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readEncryptionStatus(ctx context.Context, m interface{}) (jsonEncryptionStatus, error) {
+	c := m.(*Client)
+	var result jsonEncryptionStatus
+	body, code, err := c.newRequest(ctx, "/encryption", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func initializeEncryption(ctx context.Context, passphrase string, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/encryption", http.MethodPut, jsonEncryption{NewpassPhrase: passphrase})
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestJSONEncryptionStatus(t *testing.T) {
+	tests := map[string]struct {
+		body        string
+		initialized bool
+		ready       bool
+	}{
+		"v3.8 not initialized": {body: `{"encryption":"not_initialized"}`},
+		"v3.8 locked":          {body: `{"encryption":"need_passphrase"}`, initialized: true},
+		"v3.8 ready":           {body: `{"encryption":"ready"}`, initialized: true, ready: true},
+		"v3.12 uninitialized":  {body: `{"sealed_state":"uninitialized"}`},
+		"v3.12 sealed":         {body: `{"sealed_state":"sealed"}`, initialized: true},
+		"v3.12 unsealed":       {body: `{"sealed_state":"unsealed"}`, initialized: true, ready: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var status jsonEncryptionStatus
+			if err := json.Unmarshal([]byte(test.body), &status); err != nil {
+				t.Fatal(err)
+			}
+			if status.initialized() != test.initialized || status.ready() != test.ready {
+				t.Errorf("expected initialized %t and ready %t, got %t and %t",
+					test.initialized, test.ready, status.initialized(), status.ready())
+			}
+		})
+	}
+}
+
+func TestResourceEncryptionInitializationCreate(t *testing.T) {
+	tests := map[string]struct {
+		status        string
+		expectPut     bool
+		expectWarning bool
+	}{
+		"fresh appliance": {
+			status:    encryptionStatusNotInitialized,
+			expectPut: true,
+		},
+		"already initialized": {
+			status:        encryptionStatusReady,
+			expectWarning: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := test.status
+			put := false
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v3.12/encryption":
+					_ = json.NewEncoder(w).Encode(jsonEncryptionStatus{Encryption: status})
+				case r.Method == http.MethodPut && r.URL.Path == "/api/v3.12/encryption":
+					var jsonData jsonEncryption
+					if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+						t.Errorf("decoding request: %s", err)
+					}
+					if jsonData.NewpassPhrase != "secret" || jsonData.Passphrase != "" {
+						t.Errorf("unexpected request %v", jsonData)
+					}
+					put = true
+					status = encryptionStatusReady
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			d := schema.TestResourceDataRaw(t, resourceEncryptionInitialization().Schema, map[string]interface{}{
+				"passphrase": "secret",
+			})
+			diags := resourceEncryptionInitializationCreate(context.Background(), d, c)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if put != test.expectPut {
+				t.Errorf("expected initialization request %t, got %t", test.expectPut, put)
+			}
+			if test.expectWarning && (len(diags) != 1 || diags[0].Severity != diag.Warning) {
+				t.Errorf("expected a warning, got %v", diags)
+			}
+			if !test.expectWarning && len(diags) != 0 {
+				t.Errorf("unexpected diagnostics %v", diags)
+			}
+			if d.Id() != "encryptionInitialization" || !d.Get("ready").(bool) {
+				t.Errorf("expected the encryption to be ready, got %v", d.State())
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceEncryptionInitialization_basic(t *testing.T) {
+	resourceName := "wallix-bastion_encryption_initialization.testacc_EncryptionInitialization"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// the encryption of the test appliance is already initialized, so it's adopted
+				Config: testAccResourceEncryptionInitializationCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "encryptionInitialization"),
+					resource.TestCheckResourceAttr(resourceName, "ready", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"passphrase"},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceEncryptionInitializationCreate() string {
+	return `
+resource "wallix-bastion_encryption_initialization" "testacc_EncryptionInitialization" {
+  passphrase = "testacc_EncryptionInitialization"
+}
+`
+}
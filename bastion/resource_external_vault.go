@@ -0,0 +1,391 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonExternalVault struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	AuthMethod  string `json:"auth_method"`
+	Token       string `json:"token,omitempty"`
+	RoleID      string `json:"role_id,omitempty"`
+	SecretID    string `json:"secret_id,omitempty"`
+	Namespace   string `json:"namespace"`
+	MountPath   string `json:"mount_path"`
+}
+
+type jsonExternalVaultCheck struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func resourceExternalVault() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceExternalVaultCreate,
+		ReadContext:   resourceExternalVaultRead,
+		UpdateContext: resourceExternalVaultUpdate,
+		DeleteContext: resourceExternalVaultDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceExternalVaultImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"auth_method": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"token", "approle"}, false),
+			},
+			"token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"role_id", "secret_id"},
+			},
+			"role_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"secret_id"},
+			},
+			"secret_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"role_id"},
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"mount_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "secret",
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"check_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceExternalVaultVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_external_vault not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceExternalVaultCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceExternalVaultVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceExternalVault(ctx, d.Get("name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("name %s already exists", d.Get("name").(string)))
+	}
+	if err := addExternalVault(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceExternalVault(ctx, d.Get("name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("name %s not found after POST", d.Get("name").(string)))
+	}
+	d.SetId(id)
+	if d.Get("check_connection").(bool) {
+		if err := checkExternalVault(ctx, id, m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+
+	return resourceExternalVaultRead(ctx, d, m)
+}
+
+func resourceExternalVaultRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceExternalVaultVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readExternalVaultOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillExternalVault(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceExternalVaultUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceExternalVaultVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateExternalVault(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+	if d.Get("check_connection").(bool) {
+		if err := checkExternalVault(ctx, d.Id(), m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+
+	return resourceExternalVaultRead(ctx, d, m)
+}
+
+func resourceExternalVaultDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceExternalVaultVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteExternalVault(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceExternalVaultImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceExternalVaultVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceExternalVault(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find name with id %s (id must be <name>)", d.Id())
+	}
+	cfg, err := readExternalVaultOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillExternalVault(d, cfg)
+	if tfErr := d.Set("check_connection", true); tfErr != nil {
+		panic(tfErr)
+	}
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceExternalVault(ctx context.Context, name string, m interface{}) (string, bool, error) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/externalvaults/?q=name="+name, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonExternalVault
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addExternalVault(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData, err := prepareExternalVaultJSON(d, true)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/externalvaults/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateExternalVault(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData, err := prepareExternalVaultJSON(d, false)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/externalvaults/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteExternalVault(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/externalvaults/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+// checkExternalVault asks the plugin to connect to the vault with the saved configuration,
+// the message of the plugin is returned when the connection fails.
+func checkExternalVault(ctx context.Context, vaultID string, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/externalvaults/"+vaultID+"/check", http.MethodPost, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return newAPIError("api doesn't return OK", code, body)
+	}
+	var result jsonExternalVaultCheck
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		return fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if !result.Success {
+		if result.Message == "" {
+			return errors.New("external vault connection check failed")
+		}
+
+		return fmt.Errorf("external vault connection check failed: %s", result.Message)
+	}
+
+	return nil
+}
+
+// prepareExternalVaultJSON sends the token and the secret_id only on creation or when they change,
+// as the API never returns them.
+func prepareExternalVaultJSON(d *schema.ResourceData, newResource bool) (jsonExternalVault, error) {
+	jsonData := jsonExternalVault{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		URL:         d.Get("url").(string),
+		AuthMethod:  d.Get("auth_method").(string),
+		Namespace:   d.Get("namespace").(string),
+		MountPath:   d.Get("mount_path").(string),
+	}
+	switch jsonData.AuthMethod {
+	case "token":
+		if d.Get("role_id").(string) != "" {
+			return jsonData, errors.New("role_id and secret_id can't be set with auth_method token")
+		}
+		if newResource && d.Get("token").(string) == "" {
+			return jsonData, errors.New("token is required with auth_method token")
+		}
+		if newResource || d.HasChange("token") {
+			jsonData.Token = d.Get("token").(string)
+		}
+	case "approle":
+		if d.Get("token").(string) != "" {
+			return jsonData, errors.New("token can't be set with auth_method approle")
+		}
+		if d.Get("role_id").(string) == "" {
+			return jsonData, errors.New("role_id and secret_id are required with auth_method approle")
+		}
+		jsonData.RoleID = d.Get("role_id").(string)
+		if newResource || d.HasChange("secret_id") {
+			jsonData.SecretID = d.Get("secret_id").(string)
+		}
+	}
+
+	return jsonData, nil
+}
+
+func readExternalVaultOptions(ctx context.Context, vaultID string, m interface{}) (jsonExternalVault, error) {
+	c := m.(*Client)
+	var result jsonExternalVault
+	body, code, err := c.newRequest(ctx, "/externalvaults/"+vaultID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+// fillExternalVault doesn't set token and secret_id, the API doesn't return them.
+func fillExternalVault(d *schema.ResourceData, jsonData jsonExternalVault) {
+	if tfErr := d.Set("name", jsonData.Name); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("url", jsonData.URL); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("auth_method", jsonData.AuthMethod); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("role_id", jsonData.RoleID); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("namespace", jsonData.Namespace); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("mount_path", jsonData.MountPath); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,133 @@
+package bastion
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPrepareExternalVaultJSON(t *testing.T) {
+	tests := map[string]struct {
+		raw      map[string]interface{}
+		expected jsonExternalVault
+		errMatch string
+	}{
+		"token": {
+			raw: map[string]interface{}{
+				"name":        "vault",
+				"url":         "https://vault:8200",
+				"auth_method": "token",
+				"token":       "s.xxx",
+				"mount_path":  "secret",
+			},
+			expected: jsonExternalVault{
+				Name:       "vault",
+				URL:        "https://vault:8200",
+				AuthMethod: "token",
+				Token:      "s.xxx",
+				MountPath:  "secret",
+			},
+		},
+		"approle": {
+			raw: map[string]interface{}{
+				"name":        "vault",
+				"url":         "https://vault:8200",
+				"auth_method": "approle",
+				"role_id":     "role",
+				"secret_id":   "secret",
+				"namespace":   "ns1",
+				"mount_path":  "kv",
+			},
+			expected: jsonExternalVault{
+				Name:       "vault",
+				URL:        "https://vault:8200",
+				AuthMethod: "approle",
+				RoleID:     "role",
+				SecretID:   "secret",
+				Namespace:  "ns1",
+				MountPath:  "kv",
+			},
+		},
+		"token without token": {
+			raw: map[string]interface{}{
+				"name":        "vault",
+				"url":         "https://vault:8200",
+				"auth_method": "token",
+			},
+			errMatch: "token is required with auth_method token",
+		},
+		"approle with token": {
+			raw: map[string]interface{}{
+				"name":        "vault",
+				"url":         "https://vault:8200",
+				"auth_method": "approle",
+				"token":       "s.xxx",
+			},
+			errMatch: "token can't be set with auth_method approle",
+		},
+		"approle without role_id": {
+			raw: map[string]interface{}{
+				"name":        "vault",
+				"url":         "https://vault:8200",
+				"auth_method": "approle",
+			},
+			errMatch: "role_id and secret_id are required with auth_method approle",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceExternalVault().Schema, tt.raw)
+			jsonData, err := prepareExternalVaultJSON(d, true)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			case tt.errMatch == "" && jsonData != tt.expected:
+				t.Errorf("expected %+v, got %+v", tt.expected, jsonData)
+			}
+		})
+	}
+}
+
+func TestCheckExternalVault(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		errMatch string
+	}{
+		"success": {
+			body: `{"success": true}`,
+		},
+		"failure": {
+			body:     `{"success": false, "message": "permission denied"}`,
+			errMatch: "external vault connection check failed: permission denied",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v3.12/externalvaults/v1/check" || r.Method != http.MethodPost {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				_, _ = w.Write([]byte(tt.body))
+			})
+			err := checkExternalVault(context.Background(), "v1", c)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceExternalVault_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceExternalVaultInvalid(),
+				ExpectError: regexp.MustCompile(`token is required with auth_method token`),
+			},
+			{
+				Config: testAccResourceExternalVaultCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_external_vault.testacc_ExternalVault",
+						"id"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_external_vault.testacc_ExternalVault",
+						"mount_path", "secret"),
+				),
+			},
+			{
+				Config: testAccResourceExternalVaultUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_external_vault.testacc_ExternalVault",
+						"namespace", "testacc"),
+				),
+			},
+			{
+				ResourceName:            "wallix-bastion_external_vault.testacc_ExternalVault",
+				ImportState:             true,
+				ImportStateId:           "testacc_ExternalVault",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_id"},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceExternalVaultInvalid() string {
+	return `
+resource "wallix-bastion_external_vault" "testacc_ExternalVault" {
+  name             = "testacc_ExternalVault"
+  url              = "https://vault.testacc.local:8200"
+  auth_method      = "token"
+  check_connection = false
+}
+`
+}
+
+func testAccResourceExternalVaultCreate() string {
+	return `
+resource "wallix-bastion_external_vault" "testacc_ExternalVault" {
+  name             = "testacc_ExternalVault"
+  url              = "https://vault.testacc.local:8200"
+  auth_method      = "token"
+  token            = "testacc-token"
+  check_connection = false
+}
+`
+}
+
+func testAccResourceExternalVaultUpdate() string {
+	return `
+resource "wallix-bastion_external_vault" "testacc_ExternalVault" {
+  name             = "testacc_ExternalVault"
+  description      = "testacc ExternalVault"
+  url              = "https://vault.testacc.local:8200"
+  auth_method      = "approle"
+  role_id          = "testacc-role"
+  secret_id        = "testacc-secret"
+  namespace        = "testacc"
+  mount_path       = "kv"
+  check_connection = false
+}
+`
+}
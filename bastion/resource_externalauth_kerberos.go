@@ -79,38 +79,40 @@ func resourceExternalAuthKerberos() *schema.Resource {
 	}
 }
 
-func resourceExternalAuthKerberosVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceExternalAuthKerberosVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_kerberos not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_externalauth_kerberos not available with api version %s",
+		c.bastionAPIVersion)
 }
 
 func resourceExternalAuthKerberosCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthKerberosVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthKerberosVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceExternalAuthKerberos(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
 	}
 	err = addExternalAuthKerberos(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceExternalAuthKerberos(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s not found after POST", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s not found after POST",
+			d.Get("authentication_name").(string)))
 	}
 	d.SetId(id)
 
@@ -121,12 +123,12 @@ func resourceExternalAuthKerberosRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthKerberosVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthKerberosVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readExternalAuthKerberosOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -142,11 +144,11 @@ func resourceExternalAuthKerberosUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceExternalAuthKerberosVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthKerberosVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateExternalAuthKerberos(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -157,11 +159,11 @@ func resourceExternalAuthKerberosDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthKerberosVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthKerberosVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteExternalAuthKerberos(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -174,7 +176,7 @@ func resourceExternalAuthKerberosImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceExternalAuthKerberosVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceExternalAuthKerberosVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceExternalAuthKerberos(ctx, d.Id(), m)
@@ -202,12 +204,13 @@ func searchResourceExternalAuthKerberos(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonExternalAuthKerberos
 	err = json.Unmarshal([]byte(body), &results)
@@ -231,7 +234,7 @@ func addExternalAuthKerberos(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -247,7 +250,7 @@ func updateExternalAuthKerberos(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -262,7 +265,7 @@ func deleteExternalAuthKerberos(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -301,7 +304,7 @@ func readExternalAuthKerberosOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 
 	err = json.Unmarshal([]byte(body), &result)
@@ -137,42 +137,43 @@ func resourceExternalAuthLdap() *schema.Resource {
 	}
 }
 
-func resourceExternalAuthLdapVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceExternalAuthLdapVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_ldap not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_externalauth_ldap not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceExternalAuthLdapCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceExternalAuthLdap(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
 	}
 	if !d.Get("is_anonymous_access").(bool) && (d.Get("login").(string) == "" || d.Get("password").(string) == "") {
-		return diag.FromErr(fmt.Errorf("missing 'login' and/or 'password' on "+
+		return diagFromAPIError(fmt.Errorf("missing 'login' and/or 'password' on "+
 			"externalauth_ldap %s", d.Get("authentication_name").(string)))
 	}
 	err = addExternalAuthLdap(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceExternalAuthLdap(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s not found after POST", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s not found after POST",
+			d.Get("authentication_name").(string)))
 	}
 	d.SetId(id)
 
@@ -183,12 +184,12 @@ func resourceExternalAuthLdapRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readExternalAuthLdapOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -204,15 +205,15 @@ func resourceExternalAuthLdapUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceExternalAuthLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if !d.Get("is_anonymous_access").(bool) && (d.Get("login").(string) == "" || d.Get("password").(string) == "") {
-		return diag.FromErr(fmt.Errorf("missing 'login' and/or 'password' on "+
+		return diagFromAPIError(fmt.Errorf("missing 'login' and/or 'password' on "+
 			"externalauth_ldap %s", d.Get("authentication_name").(string)))
 	}
 	if err := updateExternalAuthLdap(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -223,11 +224,11 @@ func resourceExternalAuthLdapDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthLdapVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthLdapVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteExternalAuthLdap(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -240,7 +241,7 @@ func resourceExternalAuthLdapImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceExternalAuthLdapVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceExternalAuthLdapVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceExternalAuthLdap(ctx, d.Id(), m)
@@ -268,12 +269,13 @@ func searchResourceExternalAuthLdap(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonExternalAuthLdap
 	err = json.Unmarshal([]byte(body), &results)
@@ -297,7 +299,7 @@ func addExternalAuthLdap(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -313,7 +315,7 @@ func updateExternalAuthLdap(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -328,7 +330,7 @@ func deleteExternalAuthLdap(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -375,7 +377,7 @@ func readExternalAuthLdapOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 
 	err = json.Unmarshal([]byte(body), &result)
@@ -0,0 +1,365 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/mod/semver"
+)
+
+type jsonExternalAuthOpenID struct {
+	VerifyCertificate  bool     `json:"verify_certificate"`
+	ID                 string   `json:"id,omitempty"`
+	AuthenticationName string   `json:"authentication_name"`
+	Description        string   `json:"description"`
+	IssuerURL          string   `json:"issuer_url"`
+	ClientID           string   `json:"client_id"`
+	ClientSecret       string   `json:"client_secret,omitempty"`
+	LoginClaim         string   `json:"login_claim"`
+	EmailClaim         string   `json:"email_claim"`
+	CACertificate      string   `json:"ca_certificate"`
+	Type               string   `json:"type"`
+	Scopes             []string `json:"scopes"`
+}
+
+func resourceExternalAuthOpenID() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceExternalAuthOpenIDCreate,
+		ReadContext:   resourceExternalAuthOpenIDRead,
+		UpdateContext: resourceExternalAuthOpenIDUpdate,
+		DeleteContext: resourceExternalAuthOpenIDDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceExternalAuthOpenIDImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"authentication_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"issuer_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"client_secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"scopes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"login_claim": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "preferred_username",
+			},
+			"email_claim": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "email",
+			},
+			"verify_certificate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ca_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceExternalAuthOpenIDVersionCheck(c *Client) error {
+	// OpenID Connect authentications are only available since api v3.12
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) &&
+		semver.Compare(c.bastionAPIVersion, VersionWallixAPI312) >= 0 {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_externalauth_openid not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceExternalAuthOpenIDCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceExternalAuthOpenIDVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceExternalAuthOpenID(ctx, d.Get("authentication_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
+	}
+	err = addExternalAuthOpenID(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceExternalAuthOpenID(ctx, d.Get("authentication_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("authentication_name %s not found after POST",
+			d.Get("authentication_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceExternalAuthOpenIDRead(ctx, d, m)
+}
+
+func resourceExternalAuthOpenIDRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceExternalAuthOpenIDVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readExternalAuthOpenIDOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillExternalAuthOpenID(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceExternalAuthOpenIDUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceExternalAuthOpenIDVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateExternalAuthOpenID(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceExternalAuthOpenIDRead(ctx, d, m)
+}
+
+func resourceExternalAuthOpenIDDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceExternalAuthOpenIDVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteExternalAuthOpenID(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceExternalAuthOpenIDImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceExternalAuthOpenIDVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceExternalAuthOpenID(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find authentication_name with id %s (id must be <authentication_name>)", d.Id())
+	}
+	cfg, err := readExternalAuthOpenIDOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillExternalAuthOpenID(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceExternalAuthOpenID(
+	ctx context.Context, authenticationName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonExternalAuthOpenID
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	// the query can match other names, keep only the exact one
+	for _, v := range results {
+		if v.AuthenticationName == authenticationName {
+			return v.ID, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func addExternalAuthOpenID(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareExternalAuthOpenIDJSON(d)
+	body, code, err := c.newRequest(ctx, "/externalauths/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateExternalAuthOpenID(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareExternalAuthOpenIDJSON(d)
+	body, code, err := c.newRequest(ctx, "/externalauths/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteExternalAuthOpenID(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/externalauths/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareExternalAuthOpenIDJSON(d *schema.ResourceData) jsonExternalAuthOpenID {
+	jsonData := jsonExternalAuthOpenID{
+		AuthenticationName: d.Get("authentication_name").(string),
+		IssuerURL:          d.Get("issuer_url").(string),
+		ClientID:           d.Get("client_id").(string),
+		ClientSecret:       d.Get("client_secret").(string),
+		LoginClaim:         d.Get("login_claim").(string),
+		EmailClaim:         d.Get("email_claim").(string),
+		VerifyCertificate:  d.Get("verify_certificate").(bool),
+		CACertificate:      d.Get("ca_certificate").(string),
+		Description:        d.Get("description").(string),
+		Type:               "OIDC",
+	}
+	listScopes := d.Get("scopes").([]interface{})
+	jsonData.Scopes = make([]string, len(listScopes))
+	for i, v := range listScopes {
+		jsonData.Scopes[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readExternalAuthOpenIDOptions(
+	ctx context.Context, authenticationID string, m interface{},
+) (
+	jsonExternalAuthOpenID, error,
+) {
+	c := m.(*Client)
+	var result jsonExternalAuthOpenID
+	body, code, err := c.newRequest(ctx, "/externalauths/"+authenticationID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+// fillExternalAuthOpenID doesn't set client_secret, the value in state is always the one from the configuration.
+func fillExternalAuthOpenID(d *schema.ResourceData, jsonData jsonExternalAuthOpenID) {
+	if tfErr := d.Set("authentication_name", jsonData.AuthenticationName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("issuer_url", jsonData.IssuerURL); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("client_id", jsonData.ClientID); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("scopes", jsonData.Scopes); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("login_claim", jsonData.LoginClaim); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("email_claim", jsonData.EmailClaim); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("verify_certificate", jsonData.VerifyCertificate); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ca_certificate", jsonData.CACertificate); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,71 @@
+package bastion_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"golang.org/x/mod/semver"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+)
+
+func TestAccResourceExternalAuthOpenID_basic(t *testing.T) {
+	if v := os.Getenv("WALLIX_BASTION_API_VERSION"); semver.Compare(v, bastion.VersionWallixAPI312) >= 0 {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceExternalAuthOpenIDCreate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(
+							"wallix-bastion_externalauth_openid.testacc_ExternalAuthOpenID",
+							"id"),
+						resource.TestCheckResourceAttr(
+							"wallix-bastion_externalauth_openid.testacc_ExternalAuthOpenID",
+							"client_secret", "aSecret"),
+					),
+				},
+				{
+					Config: testAccResourceExternalAuthOpenIDUpdate(),
+				},
+				{
+					ResourceName:            "wallix-bastion_externalauth_openid.testacc_ExternalAuthOpenID",
+					ImportState:             true,
+					ImportStateId:           "testacc_ExternalAuthOpenID",
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"client_secret"},
+				},
+			},
+			PreventPostDestroyRefresh: true,
+		})
+	}
+}
+
+func testAccResourceExternalAuthOpenIDCreate() string {
+	return `
+resource "wallix-bastion_externalauth_openid" "testacc_ExternalAuthOpenID" {
+  authentication_name = "testacc_ExternalAuthOpenID"
+  issuer_url          = "https://keycloak.example.com/realms/bastion"
+  client_id           = "bastion"
+  client_secret       = "aSecret"
+}
+`
+}
+
+func testAccResourceExternalAuthOpenIDUpdate() string {
+	return `
+resource "wallix-bastion_externalauth_openid" "testacc_ExternalAuthOpenID" {
+  authentication_name = "testacc_ExternalAuthOpenID"
+  issuer_url          = "https://keycloak.example.com/realms/bastion"
+  client_id           = "bastion"
+  client_secret       = "aSecret"
+  scopes              = ["openid", "profile", "email"]
+  login_claim         = "sub"
+  email_claim         = "mail"
+  verify_certificate  = false
+  description         = "testacc ExternalAuthOpenID"
+}
+`
+}
@@ -68,38 +68,39 @@ func resourceExternalAuthRadius() *schema.Resource {
 	}
 }
 
-func resourceExternalAuthRadiusVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceExternalAuthRadiusVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_radius not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_externalauth_radius not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceExternalAuthRadiusCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthRadiusVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthRadiusVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceExternalAuthRadius(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
 	}
 	err = addExternalAuthRadius(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceExternalAuthRadius(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s not found after POST", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s not found after POST",
+			d.Get("authentication_name").(string)))
 	}
 	d.SetId(id)
 
@@ -110,12 +111,12 @@ func resourceExternalAuthRadiusRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthRadiusVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthRadiusVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readExternalAuthRadiusOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -131,11 +132,11 @@ func resourceExternalAuthRadiusUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceExternalAuthRadiusVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthRadiusVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateExternalAuthRadius(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -146,11 +147,11 @@ func resourceExternalAuthRadiusDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthRadiusVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthRadiusVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteExternalAuthRadius(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -163,7 +164,7 @@ func resourceExternalAuthRadiusImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceExternalAuthRadiusVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceExternalAuthRadiusVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceExternalAuthRadius(ctx, d.Id(), m)
@@ -191,12 +192,13 @@ func searchResourceExternalAuthRadius(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonExternalAuthRadius
 	err = json.Unmarshal([]byte(body), &results)
@@ -220,7 +222,7 @@ func addExternalAuthRadius(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -236,7 +238,7 @@ func updateExternalAuthRadius(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -251,7 +253,7 @@ func deleteExternalAuthRadius(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -285,7 +287,7 @@ func readExternalAuthRadiusOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 
 	err = json.Unmarshal([]byte(body), &result)
@@ -144,38 +144,39 @@ func resourceExternalAuthSaml() *schema.Resource {
 	}
 }
 
-func resourceExternalAuthSamlVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceExternalAuthSamlVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_saml not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_externalauth_saml not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceExternalAuthSamlCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthSamlVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthSamlVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceExternalAuthSaml(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
 	}
 	err = addExternalAuthSaml(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceExternalAuthSaml(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s not found after POST", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s not found after POST",
+			d.Get("authentication_name").(string)))
 	}
 	d.SetId(id)
 
@@ -186,12 +187,12 @@ func resourceExternalAuthSamlRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthSamlVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthSamlVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readExternalAuthSamlOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -207,11 +208,11 @@ func resourceExternalAuthSamlUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceExternalAuthSamlVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthSamlVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateExternalAuthSaml(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -222,11 +223,11 @@ func resourceExternalAuthSamlDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthSamlVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthSamlVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteExternalAuthSaml(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -239,7 +240,7 @@ func resourceExternalAuthSamlImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceExternalAuthSamlVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceExternalAuthSamlVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceExternalAuthSaml(ctx, d.Id(), m)
@@ -267,12 +268,13 @@ func searchResourceExternalAuthSaml(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonExternalAuthSaml
 	err = json.Unmarshal([]byte(body), &results)
@@ -296,7 +298,7 @@ func addExternalAuthSaml(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -312,7 +314,7 @@ func updateExternalAuthSaml(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -327,7 +329,7 @@ func deleteExternalAuthSaml(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -376,7 +378,7 @@ func readExternalAuthSamlOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 
 	err = json.Unmarshal([]byte(body), &result)
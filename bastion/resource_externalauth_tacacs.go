@@ -63,38 +63,39 @@ func resourceExternalAuthTacacs() *schema.Resource {
 	}
 }
 
-func resourceExternalAuthTacacsVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceExternalAuthTacacsVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_tacacs not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_externalauth_tacacs not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceExternalAuthTacacsCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthTacacsVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthTacacsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceExternalAuthTacacs(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s already exists", d.Get("authentication_name").(string)))
 	}
 	err = addExternalAuthTacacs(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceExternalAuthTacacs(ctx, d.Get("authentication_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("authentication_name %s not found after POST", d.Get("authentication_name").(string)))
+		return diagFromAPIError(fmt.Errorf("authentication_name %s not found after POST",
+			d.Get("authentication_name").(string)))
 	}
 	d.SetId(id)
 
@@ -105,12 +106,12 @@ func resourceExternalAuthTacacsRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthTacacsVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthTacacsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readExternalAuthTacacsOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -126,11 +127,11 @@ func resourceExternalAuthTacacsUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceExternalAuthTacacsVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthTacacsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateExternalAuthTacacs(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -141,11 +142,11 @@ func resourceExternalAuthTacacsDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceExternalAuthTacacsVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceExternalAuthTacacsVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteExternalAuthTacacs(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -158,7 +159,7 @@ func resourceExternalAuthTacacsImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceExternalAuthTacacsVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceExternalAuthTacacsVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceExternalAuthTacacs(ctx, d.Id(), m)
@@ -186,12 +187,13 @@ func searchResourceExternalAuthTacacs(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonExternalAuthTacacs
 	err = json.Unmarshal([]byte(body), &results)
@@ -215,7 +217,7 @@ func addExternalAuthTacacs(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -231,7 +233,7 @@ func updateExternalAuthTacacs(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -246,7 +248,7 @@ func deleteExternalAuthTacacs(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -279,7 +281,7 @@ func readExternalAuthTacacsOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 
 	err = json.Unmarshal([]byte(body), &result)
@@ -0,0 +1,315 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	haConfigurationSyncTimeout      = 10 * time.Minute
+	haConfigurationSyncPollInterval = time.Second
+
+	haSyncStatusDisconnected  = "disconnected"
+	haSyncStatusConnecting    = "connecting"
+	haSyncStatusSynchronizing = "synchronizing"
+	haSyncStatusSynchronized  = "synchronized"
+)
+
+type jsonHAConfiguration struct {
+	PeerAddress          string `json:"peer_address"`
+	SharedSecret         string `json:"shared_secret,omitempty"`
+	ReplicationInterface string `json:"replication_interface,omitempty"`
+	VirtualIP            string `json:"virtual_ip,omitempty"`
+	Role                 string `json:"role"`
+	AutoFailover         bool   `json:"auto_failover"`
+	FailoverTimeout      int    `json:"failover_timeout"`
+	SyncStatus           string `json:"sync_status,omitempty"`
+}
+
+func resourceHAConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHAConfigurationCreate,
+		ReadContext:   resourceHAConfigurationRead,
+		UpdateContext: resourceHAConfigurationUpdate,
+		DeleteContext: resourceHAConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceHAConfigurationImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(haConfigurationSyncTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"peer_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateHostnameOrIP,
+			},
+			"shared_secret": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"replication_interface": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"virtual_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "primary",
+				ValidateFunc: validation.StringInSlice([]string{"primary", "secondary"}, false),
+			},
+			"auto_failover": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"failover_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"sync_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceHAConfigurationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_ha_configuration not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceHAConfigurationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceHAConfigurationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateHAConfiguration(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("haConfiguration")
+	statuses, err := waitHAConfigurationSynchronized(ctx, d.Timeout(schema.TimeoutCreate), m)
+	diags := make(diag.Diagnostics, 0, len(statuses)+1)
+	for _, status := range statuses {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "HA pair not synchronized yet",
+			Detail: fmt.Sprintf("The HA pair of the Bastion reported the sync status %q "+
+				"before being synchronized.", status),
+		})
+	}
+	if err != nil {
+		return append(diags, diagFromAPIError(err)...)
+	}
+
+	return append(diags, resourceHAConfigurationRead(ctx, d, m)...)
+}
+
+func resourceHAConfigurationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceHAConfigurationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readHAConfigurationOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.PeerAddress == "" {
+		// HA has been disabled outside of Terraform
+		d.SetId("")
+
+		return nil
+	}
+	fillHAConfiguration(d, cfg)
+
+	return nil
+}
+
+func resourceHAConfigurationUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceHAConfigurationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateHAConfiguration(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceHAConfigurationRead(ctx, d, m)
+}
+
+func resourceHAConfigurationDelete(
+	ctx context.Context, _ *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceHAConfigurationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteHAConfiguration(ctx, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceHAConfigurationImport(
+	d *schema.ResourceData, _ interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	// Since the resource does not have a unique ID, use the static "haConfiguration" ID
+	d.SetId("haConfiguration")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitHAConfigurationSynchronized polls the HA configuration until the pair reports synchronized,
+// it returns the intermediate sync statuses in the order they were reported.
+func waitHAConfigurationSynchronized(
+	ctx context.Context, timeout time.Duration, m interface{},
+) (
+	[]string, error,
+) {
+	statuses := make([]string, 0)
+	pollCtx := withoutCache(ctx)
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{haSyncStatusDisconnected, haSyncStatusConnecting, haSyncStatusSynchronizing},
+		Target:  []string{haSyncStatusSynchronized},
+		Refresh: func() (interface{}, string, error) {
+			cfg, err := readHAConfigurationOptions(pollCtx, m)
+			if err != nil {
+				return nil, "", err
+			}
+			if cfg.SyncStatus != haSyncStatusSynchronized &&
+				(len(statuses) == 0 || statuses[len(statuses)-1] != cfg.SyncStatus) {
+				statuses = append(statuses, cfg.SyncStatus)
+			}
+
+			return cfg, cfg.SyncStatus, nil
+		},
+		Timeout:      timeout,
+		PollInterval: haConfigurationSyncPollInterval,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return statuses, fmt.Errorf("waiting for the HA pair to be synchronized: %w", err)
+	}
+
+	return statuses, nil
+}
+
+func readHAConfigurationOptions(ctx context.Context, m interface{}) (jsonHAConfiguration, error) {
+	c := m.(*Client)
+	var result jsonHAConfiguration
+	body, code, err := c.newRequest(ctx, "/config/ha", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func updateHAConfiguration(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareHAConfigurationJSON(d)
+	body, code, err := c.newRequest(ctx, "/config/ha", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteHAConfiguration(ctx context.Context, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/config/ha", http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent && code != http.StatusNotFound {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareHAConfigurationJSON(d *schema.ResourceData) jsonHAConfiguration {
+	return jsonHAConfiguration{
+		PeerAddress:          d.Get("peer_address").(string),
+		SharedSecret:         d.Get("shared_secret").(string),
+		ReplicationInterface: d.Get("replication_interface").(string),
+		VirtualIP:            d.Get("virtual_ip").(string),
+		Role:                 d.Get("role").(string),
+		AutoFailover:         d.Get("auto_failover").(bool),
+		FailoverTimeout:      d.Get("failover_timeout").(int),
+	}
+}
+
+func fillHAConfiguration(d *schema.ResourceData, jsonData jsonHAConfiguration) {
+	if tfErr := d.Set("peer_address", jsonData.PeerAddress); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("replication_interface", jsonData.ReplicationInterface); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("virtual_ip", jsonData.VirtualIP); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("role", jsonData.Role); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("auto_failover", jsonData.AutoFailover); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("failover_timeout", jsonData.FailoverTimeout); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("sync_status", jsonData.SyncStatus); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,110 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceHAConfigurationCreate(t *testing.T) {
+	tests := map[string]struct {
+		statuses []string
+		warnings []string
+		errMatch string
+	}{
+		"synchronized": {
+			statuses: []string{"synchronized"},
+		},
+		"synchronizing": {
+			statuses: []string{"connecting", "synchronizing", "synchronized"},
+			warnings: []string{`"connecting"`, `"synchronizing"`},
+		},
+		"failed": {
+			statuses: []string{"connecting", "failed"},
+			warnings: []string{`"connecting"`, `"failed"`},
+			errMatch: "unexpected state 'failed'",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var config jsonHAConfiguration
+			reads := 0
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/api/v3.12/config/ha":
+					if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+						t.Errorf("decoding request: %s", err)
+					}
+					if config.PeerAddress != "192.0.2.2" || config.SharedSecret != "secret" ||
+						config.Role != "primary" || !config.AutoFailover || config.FailoverTimeout != 30 {
+						t.Errorf("unexpected request %v", config)
+					}
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v3.12/config/ha":
+					cfg := config
+					cfg.SharedSecret = ""
+					cfg.SyncStatus = tt.statuses[min(reads, len(tt.statuses)-1)]
+					reads++
+					_ = json.NewEncoder(w).Encode(cfg)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			d := schema.TestResourceDataRaw(t, resourceHAConfiguration().Schema, map[string]interface{}{
+				"peer_address":  "192.0.2.2",
+				"shared_secret": "secret",
+			})
+			diags := resourceHAConfigurationCreate(context.Background(), d, c)
+			warnings := make([]string, 0)
+			for _, v := range diags {
+				if v.Severity == diag.Warning {
+					warnings = append(warnings, v.Detail)
+				}
+			}
+			if len(warnings) != len(tt.warnings) {
+				t.Fatalf("expected %d warnings, got %v", len(tt.warnings), diags)
+			}
+			for i, v := range tt.warnings {
+				if !strings.Contains(warnings[i], v) {
+					t.Errorf("expected warning matching %s, got %s", v, warnings[i])
+				}
+			}
+			if tt.errMatch != "" {
+				if !diags.HasError() || !strings.Contains(diags[len(diags)-1].Summary, tt.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", tt.errMatch, diags)
+				}
+
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != "haConfiguration" || d.Get("sync_status").(string) != haSyncStatusSynchronized {
+				t.Errorf("expected the pair to be synchronized, got %v", d.State())
+			}
+		})
+	}
+}
+
+func TestResourceHAConfigurationReadDisabled(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v3.12/config/ha" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	d := resourceHAConfiguration().TestResourceData()
+	d.SetId("haConfiguration")
+	if diags := resourceHAConfigurationRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the resource to be removed from the state, got id %s", d.Id())
+	}
+}
@@ -0,0 +1,58 @@
+package bastion_test
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceHAConfiguration_basic(t *testing.T) {
+	// the acceptance test needs a second Bastion as peer of the HA pair
+	if peer := os.Getenv("TESTACC_HA_PEER"); peer != "" {
+		resourceName := "wallix-bastion_ha_configuration.testacc_HAConfiguration"
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccResourceHAConfiguration("peer bastion", 30),
+					ExpectError: regexp.MustCompile(`must be a valid hostname or IP address`),
+				},
+				{
+					Config: testAccResourceHAConfiguration(peer, 30),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(resourceName, "id"),
+						resource.TestCheckResourceAttr(resourceName, "sync_status", "synchronized"),
+					),
+				},
+				{
+					Config: testAccResourceHAConfiguration(peer, 60),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "failover_timeout", "60"),
+					),
+				},
+				{
+					ResourceName:            resourceName,
+					ImportState:             true,
+					ImportStateId:           "ha_configuration",
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"shared_secret", "sync_status"},
+				},
+			},
+			PreventPostDestroyRefresh: true,
+		})
+	}
+}
+
+func testAccResourceHAConfiguration(peer string, failoverTimeout int) string {
+	return `
+resource "wallix-bastion_ha_configuration" "testacc_HAConfiguration" {
+  peer_address     = "` + peer + `"
+  shared_secret    = "testacc_HAConfiguration"
+  failover_timeout = ` + strconv.Itoa(failoverTimeout) + `
+}
+`
+}
@@ -0,0 +1,308 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceLdapMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLdapMappingCreate,
+		ReadContext:   resourceLdapMappingRead,
+		UpdateContext: resourceLdapMappingUpdate,
+		DeleteContext: resourceLdapMappingDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceLdapMappingImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"ldap_group": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"target_usergroup": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"domain_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"profile": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLdapMappingVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_ldap_mapping not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceLdapMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceLdapMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	domainID, err := searchLdapMappingDomain(ctx, d.Get("domain").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := checkAuthDomainMappingUserGroup(ctx, d.Get("target_usergroup").(string), m); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceAuthDomainMapping(ctx,
+		domainID, d.Get("target_usergroup").(string), d.Get("ldap_group").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("ldap mapping of ldap_group %s to target_usergroup %s "+
+			"on domain %s already exists",
+			d.Get("ldap_group").(string), d.Get("target_usergroup").(string), d.Get("domain").(string)))
+	}
+	if err := addLdapMapping(ctx, domainID, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceAuthDomainMapping(ctx,
+		domainID, d.Get("target_usergroup").(string), d.Get("ldap_group").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("ldap mapping of ldap_group %s to target_usergroup %s "+
+			"on domain %s not found after POST",
+			d.Get("ldap_group").(string), d.Get("target_usergroup").(string), d.Get("domain").(string)))
+	}
+	d.SetId(id)
+	if tfErr := d.Set("domain_id", domainID); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return resourceLdapMappingRead(ctx, d, m)
+}
+
+func resourceLdapMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceLdapMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readAuthDomainMappingOptions(ctx, d.Get("domain_id").(string), d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+
+		return nil
+	}
+	profile, err := readLdapMappingProfile(ctx, cfg.UserGroup, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillLdapMapping(d, cfg, profile)
+
+	return nil
+}
+
+func resourceLdapMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceLdapMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("target_usergroup") {
+		if err := checkAuthDomainMappingUserGroup(ctx, d.Get("target_usergroup").(string), m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	if err := updateLdapMapping(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceLdapMappingRead(ctx, d, m)
+}
+
+func resourceLdapMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceLdapMappingVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteLdapMapping(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceLdapMappingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceLdapMappingVersionCheck(c); err != nil {
+		return nil, err
+	}
+	// the ldap group is a distinguished name which can contain slashes, keep them in the last part
+	idSplit := strings.SplitN(d.Id(), "/", 3)
+	if len(idSplit) != 3 {
+		return nil, errors.New("id must be <domain>/<target_usergroup>/<ldap_group>")
+	}
+	domainID, err := searchLdapMappingDomain(ctx, idSplit[0], m)
+	if err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceAuthDomainMapping(ctx, domainID, idSplit[1], idSplit[2], m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find ldap mapping with id %s (id must be <domain>/<target_usergroup>/<ldap_group>)",
+			d.Id())
+	}
+	cfg, err := readAuthDomainMappingOptions(ctx, domainID, id, m)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := readLdapMappingProfile(ctx, cfg.UserGroup, m)
+	if err != nil {
+		return nil, err
+	}
+	fillLdapMapping(d, cfg, profile)
+	if tfErr := d.Set("domain", idSplit[0]); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("domain_id", domainID); tfErr != nil {
+		panic(tfErr)
+	}
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+// searchLdapMappingDomain returns the ID of the auth domain with the name domainName,
+// only LDAP and AD domains have a group attribute to map.
+func searchLdapMappingDomain(ctx context.Context, domainName string, m interface{}) (string, error) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
+	if err != nil {
+		return "", err
+	}
+	if code != http.StatusOK {
+		return "", newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonAuthDomainLdap
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) != 1 {
+		return "", fmt.Errorf("auth domain %s doesn't exists", domainName)
+	}
+	if results[0].Type != "LDAP" && results[0].Type != "AD" {
+		return "", fmt.Errorf("auth domain %s is a %s domain, must be a LDAP or AD domain", domainName, results[0].Type)
+	}
+
+	return results[0].ID, nil
+}
+
+// readLdapMappingProfile returns the profile of the user group, which gives the access of the mapped users.
+func readLdapMappingProfile(ctx context.Context, userGroup string, m interface{}) (string, error) {
+	id, ex, err := searchResourceUserGroup(ctx, userGroup, m)
+	if err != nil {
+		return "", err
+	}
+	if !ex {
+		return "", nil
+	}
+	cfg, err := readUserGroupOptions(ctx, id, m)
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.Profile, nil
+}
+
+func addLdapMapping(ctx context.Context, domainID string, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareLdapMappingJSON(d)
+	body, code, err := c.newRequest(ctx, "/authdomains/"+domainID+"/mappings", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateLdapMapping(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	jsonData := prepareLdapMappingJSON(d)
+	body, code, err := c.newRequest(ctx,
+		"/authdomains/"+d.Get("domain_id").(string)+"/mappings/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteLdapMapping(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx,
+		"/authdomains/"+d.Get("domain_id").(string)+"/mappings/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareLdapMappingJSON(d *schema.ResourceData) jsonAuthDomainMapping {
+	return jsonAuthDomainMapping{
+		UserGroup:     d.Get("target_usergroup").(string),
+		ExternalGroup: d.Get("ldap_group").(string),
+	}
+}
+
+func fillLdapMapping(d *schema.ResourceData, jsonData jsonAuthDomainMapping, profile string) {
+	if tfErr := d.Set("target_usergroup", jsonData.UserGroup); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ldap_group", jsonData.ExternalGroup); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("profile", profile); tfErr != nil {
+		panic(tfErr)
+	}
+}
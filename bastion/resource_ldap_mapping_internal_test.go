@@ -0,0 +1,55 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSearchLdapMappingDomain(t *testing.T) {
+	tests := map[string]struct {
+		domains  []jsonAuthDomainLdap
+		errMatch string
+	}{
+		"ldap": {
+			domains: []jsonAuthDomainLdap{{ID: "a1", DomainName: "corp", Type: "LDAP"}},
+		},
+		"ad": {
+			domains: []jsonAuthDomainLdap{{ID: "a1", DomainName: "corp", Type: "AD"}},
+		},
+		"saml": {
+			domains:  []jsonAuthDomainLdap{{ID: "a1", DomainName: "corp", Type: "SAML"}},
+			errMatch: "auth domain corp is a SAML domain, must be a LDAP or AD domain",
+		},
+		"missing": {
+			domains:  []jsonAuthDomainLdap{},
+			errMatch: "auth domain corp doesn't exists",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v3.12/authdomains/" || r.URL.Query().Get("q") != "domain_name=corp" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+				_ = json.NewEncoder(w).Encode(tt.domains)
+			})
+			id, err := searchLdapMappingDomain(context.Background(), "corp", c)
+			switch {
+			case tt.errMatch == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.errMatch == "" && id != "a1":
+				t.Errorf("expected domain ID a1, got %q", id)
+			case tt.errMatch != "" && err == nil:
+				t.Errorf("expected error matching %q, got nil", tt.errMatch)
+			case tt.errMatch != "" && !strings.Contains(err.Error(), tt.errMatch):
+				t.Errorf("expected error matching %q, got: %s", tt.errMatch, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceLdapMapping_basic(t *testing.T) {
+	resourceName := "wallix-bastion_ldap_mapping.testacc_LdapMapping"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceLdapMappingMissingDomain(),
+				ExpectError: regexp.MustCompile(`auth domain testacc.LdapMappingMissing doesn't exists`),
+			},
+			{
+				Config: testAccResourceLdapMappingCreate("testacc_LdapMapping"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "domain_id"),
+					resource.TestCheckResourceAttr(resourceName, "profile", "user"),
+				),
+			},
+			{
+				Config: testAccResourceLdapMappingCreate("testacc_LdapMapping2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "target_usergroup", "testacc_LdapMapping2"),
+					resource.TestCheckResourceAttr(resourceName, "profile", "auditor"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "testacc.LdapMapping/testacc_LdapMapping2/CN=testacc,OU=FR,DC=test,DC=com",
+				ImportStateVerify: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceLdapMappingMissingDomain() string {
+	return `
+resource "wallix-bastion_ldap_mapping" "testacc_LdapMapping" {
+  domain           = "testacc.LdapMappingMissing"
+  ldap_group       = "CN=testacc,OU=FR,DC=test,DC=com"
+  target_usergroup = "testacc_LdapMapping"
+}
+`
+}
+
+func testAccResourceLdapMappingCreate(targetUserGroup string) string {
+	return `
+resource "wallix-bastion_authdomain_ldap" "testacc_LdapMapping" {
+  domain_name          = "testacc.LdapMapping"
+  auth_domain_name     = "test.com"
+  external_auths       = [wallix-bastion_externalauth_ldap.testacc_LdapMapping.authentication_name]
+  default_language     = "fr"
+  default_email_domain = "test.com"
+}
+resource "wallix-bastion_externalauth_ldap" "testacc_LdapMapping" {
+  authentication_name = "testacc_LdapMapping"
+  cn_attribute        = "sAMAccountName"
+  host                = "server1"
+  ldap_base           = "OU=FR,DC=test,DC=com"
+  login_attribute     = "sAMAccountName"
+  port                = 636
+  timeout             = 10
+  is_ssl              = true
+  is_anonymous_access = true
+}
+resource "wallix-bastion_usergroup" "testacc_LdapMapping" {
+  group_name = "testacc_LdapMapping"
+  timeframes = ["allthetime"]
+  profile    = "user"
+}
+resource "wallix-bastion_usergroup" "testacc_LdapMapping2" {
+  group_name = "testacc_LdapMapping2"
+  timeframes = ["allthetime"]
+  profile    = "auditor"
+}
+resource "wallix-bastion_ldap_mapping" "testacc_LdapMapping" {
+  domain           = wallix-bastion_authdomain_ldap.testacc_LdapMapping.domain_name
+  ldap_group       = "CN=testacc,OU=FR,DC=test,DC=com"
+  target_usergroup = "` + targetUserGroup + `"
+
+  depends_on = [
+    wallix-bastion_usergroup.testacc_LdapMapping,
+    wallix-bastion_usergroup.testacc_LdapMapping2,
+  ]
+}
+`
+}
@@ -0,0 +1,233 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonLicense struct {
+	License        string         `json:"license,omitempty"`
+	Serial         string         `json:"serial"`
+	ExpirationDate string         `json:"expiration_date"`
+	LicensedCounts map[string]int `json:"licensed_counts"`
+	Features       []string       `json:"features"`
+	PrimaryCount   int            `json:"primary_count"`
+	IsValid        bool           `json:"is_valid"`
+}
+
+func resourceLicense() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLicenseCreate,
+		ReadContext:   resourceLicenseRead,
+		UpdateContext: resourceLicenseUpdate,
+		DeleteContext: resourceLicenseDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceLicenseImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"license": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"serial": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expiration_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"licensed_counts": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"features": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"primary_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"is_valid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLicenseVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_license not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceLicenseCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceLicenseVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	current, err := readLicenseOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	diags := applyLicense(ctx, d, current.Serial, m)
+	if diags.HasError() {
+		return diags
+	}
+	// Use a static ID since the API does not provide one
+	d.SetId("license")
+
+	return append(diags, resourceLicenseRead(ctx, d, m)...)
+}
+
+func resourceLicenseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceLicenseVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readLicenseOptions(ctx, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	fillLicense(d, cfg)
+
+	return nil
+}
+
+func resourceLicenseUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceLicenseVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	diags := applyLicense(ctx, d, d.Get("serial").(string), m)
+	if diags.HasError() {
+		return diags
+	}
+	d.Partial(false)
+
+	return append(diags, resourceLicenseRead(ctx, d, m)...)
+}
+
+func resourceLicenseDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceLicenseVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "License kept on the Bastion",
+		Detail: "A license can't be removed from the Bastion, " +
+			"destroying wallix-bastion_license only removed it from the state.",
+	}}
+}
+
+func resourceLicenseImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Since the resource does not have a unique ID, use the static "license" ID
+	d.SetId("license")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyLicense uploads the license and returns a warning if the Bastion reports
+// the same serial as before, i.e. the license was already applied.
+func applyLicense(ctx context.Context, d *schema.ResourceData, previousSerial string, m interface{}) diag.Diagnostics {
+	cfg, err := uploadLicense(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if previousSerial != "" && cfg.Serial == previousSerial {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "License already applied",
+			Detail:   fmt.Sprintf("The license with serial %s was already applied on the Bastion, nothing changed.", cfg.Serial),
+		}}
+	}
+
+	return nil
+}
+
+func readLicenseOptions(ctx context.Context, m interface{}) (jsonLicense, error) {
+	c := m.(*Client)
+	var result jsonLicense
+	body, code, err := c.newRequest(ctx, "/license", http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		// no license applied yet
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("API returned error", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func uploadLicense(ctx context.Context, d *schema.ResourceData, m interface{}) (jsonLicense, error) {
+	c := m.(*Client)
+	var result jsonLicense
+	jsonData := jsonLicense{
+		License: d.Get("license").(string),
+	}
+	body, code, err := c.newRequest(ctx, "/license", http.MethodPost, jsonData)
+	if err != nil {
+		return result, err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return result, newAPIError("API returned error", code, body)
+	}
+	if code == http.StatusNoContent {
+		return readLicenseOptions(ctx, m)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// fillLicense sets all the attributes except license which is never returned by the API,
+// so the value in state is kept.
+func fillLicense(d *schema.ResourceData, jsonData jsonLicense) {
+	if tfErr := d.Set("serial", jsonData.Serial); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("expiration_date", jsonData.ExpirationDate); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("licensed_counts", jsonData.LicensedCounts); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("features", jsonData.Features); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("primary_count", jsonData.PrimaryCount); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("is_valid", jsonData.IsValid); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,53 @@
+package bastion_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceLicense_basic(t *testing.T) {
+	if os.Getenv("TESTACC_LICENSE_FILE") != "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceLicenseCreate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(
+							"wallix-bastion_license.testacc_License",
+							"serial"),
+						resource.TestCheckResourceAttrSet(
+							"wallix-bastion_license.testacc_License",
+							"expiration_date"),
+						resource.TestCheckResourceAttr(
+							"wallix-bastion_license.testacc_License",
+							"is_valid", "true"),
+					),
+				},
+				{
+					Config:   testAccResourceLicenseCreate(),
+					PlanOnly: true,
+				},
+				{
+					ResourceName:            "wallix-bastion_license.testacc_License",
+					ImportState:             true,
+					ImportStateId:           "license",
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"license"},
+				},
+			},
+			PreventPostDestroyRefresh: true,
+		})
+	}
+}
+
+func testAccResourceLicenseCreate() string {
+	return `
+resource "wallix-bastion_license" "testacc_License" {
+  license = filebase64("` + os.Getenv("TESTACC_LICENSE_FILE") + `")
+}
+`
+}
@@ -0,0 +1,311 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonMaskingPolicy struct {
+	ID          string   `json:"id,omitempty"`
+	PolicyName  string   `json:"policy_name"`
+	Description string   `json:"description"`
+	Replacement string   `json:"replacement"`
+	Enabled     bool     `json:"enabled"`
+	Patterns    []string `json:"patterns"`
+}
+
+func resourceMaskingPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMaskingPolicyCreate,
+		ReadContext:   resourceMaskingPolicyRead,
+		UpdateContext: resourceMaskingPolicyUpdate,
+		DeleteContext: resourceMaskingPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceMaskingPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"patterns": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsValidRegExp,
+				},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"replacement": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "***",
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceMaskingPolicyVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_masking_policy not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceMaskingPolicyCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceMaskingPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceMaskingPolicy(ctx, d.Get("policy_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("policy_name %s already exists", d.Get("policy_name").(string)))
+	}
+	err = addMaskingPolicy(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceMaskingPolicy(ctx, d.Get("policy_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("policy_name %s not found after POST", d.Get("policy_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceMaskingPolicyRead(ctx, d, m)
+}
+
+func resourceMaskingPolicyRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceMaskingPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readMaskingPolicyOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillMaskingPolicy(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceMaskingPolicyUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceMaskingPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateMaskingPolicy(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceMaskingPolicyRead(ctx, d, m)
+}
+
+func resourceMaskingPolicyDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceMaskingPolicyVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteMaskingPolicy(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceMaskingPolicyImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceMaskingPolicyVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceMaskingPolicy(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find policy_name with id %s (id must be <policy_name>)", d.Id())
+	}
+	cfg, err := readMaskingPolicyOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillMaskingPolicy(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceMaskingPolicy(
+	ctx context.Context, policyName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/maskingpolicies/?q=policy_name="+policyName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonMaskingPolicy
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addMaskingPolicy(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareMaskingPolicyJSON(d)
+	body, code, err := c.newRequest(ctx, "/maskingpolicies/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateMaskingPolicy(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareMaskingPolicyJSON(d)
+	body, code, err := c.newRequest(ctx, "/maskingpolicies/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteMaskingPolicy(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/maskingpolicies/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareMaskingPolicyJSON(d *schema.ResourceData) jsonMaskingPolicy {
+	jsonData := jsonMaskingPolicy{
+		PolicyName:  d.Get("policy_name").(string),
+		Description: d.Get("description").(string),
+		Replacement: d.Get("replacement").(string),
+		Enabled:     d.Get("enabled").(bool),
+	}
+	listPatterns := d.Get("patterns").([]interface{})
+	jsonData.Patterns = make([]string, len(listPatterns))
+	for i, v := range listPatterns {
+		jsonData.Patterns[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readMaskingPolicyOptions(
+	ctx context.Context, policyID string, m interface{},
+) (
+	jsonMaskingPolicy, error,
+) {
+	c := m.(*Client)
+	var result jsonMaskingPolicy
+	body, code, err := c.newRequest(ctx, "/maskingpolicies/"+policyID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillMaskingPolicy(d *schema.ResourceData, jsonData jsonMaskingPolicy) {
+	if tfErr := d.Set("policy_name", jsonData.PolicyName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("replacement", jsonData.Replacement); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("enabled", jsonData.Enabled); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("patterns", jsonData.Patterns); tfErr != nil {
+		panic(tfErr)
+	}
+}
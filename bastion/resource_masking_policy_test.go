@@ -0,0 +1,72 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceMaskingPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceMaskingPolicyInvalid(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`error parsing regexp: missing closing ]`),
+			},
+			{
+				Config: testAccResourceMaskingPolicyCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_masking_policy.testacc_MaskingPolicy",
+						"id"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_masking_policy.testacc_MaskingPolicy",
+						"replacement", "***"),
+				),
+			},
+			{
+				Config: testAccResourceMaskingPolicyUpdate(),
+			},
+			{
+				ResourceName:  "wallix-bastion_masking_policy.testacc_MaskingPolicy",
+				ImportState:   true,
+				ImportStateId: "testacc_MaskingPolicy",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceMaskingPolicyInvalid() string {
+	return `
+resource "wallix-bastion_masking_policy" "testacc_MaskingPolicy" {
+  policy_name = "testacc_MaskingPolicy"
+  patterns    = ["[0-9"]
+}
+`
+}
+
+func testAccResourceMaskingPolicyCreate() string {
+	return `
+resource "wallix-bastion_masking_policy" "testacc_MaskingPolicy" {
+  policy_name = "testacc_MaskingPolicy"
+  patterns    = ["[0-9]{16}"]
+}
+`
+}
+
+func testAccResourceMaskingPolicyUpdate() string {
+	return `
+resource "wallix-bastion_masking_policy" "testacc_MaskingPolicy" {
+  policy_name = "testacc_MaskingPolicy"
+  description = "testacc MaskingPolicy"
+  patterns    = ["[0-9]{16}", "(?i)password=\\S+"]
+  replacement = "[MASKED]"
+  enabled     = false
+}
+`
+}
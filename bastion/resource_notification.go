@@ -0,0 +1,338 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonNotification struct {
+	ID               string   `json:"id,omitempty"`
+	NotificationName string   `json:"notification_name"`
+	Description      string   `json:"description"`
+	Enabled          bool     `json:"enabled"`
+	Type             string   `json:"type"`
+	Language         string   `json:"language"`
+	Destination      []string `json:"destination"`
+	Events           []string `json:"events"`
+}
+
+func resourceNotification() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNotificationCreate,
+		ReadContext:   resourceNotificationRead,
+		UpdateContext: resourceNotificationUpdate,
+		DeleteContext: resourceNotificationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNotificationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"notification_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"approval_request",
+						"primary_connection_failure",
+						"license_expiration",
+					}, false),
+				},
+			},
+			"destination": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^@\s]+@[^@\s]+$`), "must be an email address"),
+				},
+			},
+			"language": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "en",
+				ValidateFunc: validation.StringInSlice([]string{"de", "en", "es", "fr", "ru"}, false),
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceNotificationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_notification not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceNotificationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceNotification(ctx, d.Get("notification_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("notification_name %s already exists", d.Get("notification_name").(string)))
+	}
+	err = addNotification(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceNotification(ctx, d.Get("notification_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("notification_name %s not found after POST", d.Get("notification_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceNotificationRead(ctx, d, m)
+}
+
+func resourceNotificationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readNotificationOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillNotification(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceNotificationUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateNotification(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceNotificationRead(ctx, d, m)
+}
+
+func resourceNotificationDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteNotification(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceNotificationImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceNotificationVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceNotification(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find notification_name with id %s (id must be <notification_name>)", d.Id())
+	}
+	cfg, err := readNotificationOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillNotification(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceNotification(
+	ctx context.Context, notificationName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/notifications/?q=notification_name="+notificationName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonNotification
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	for _, v := range results {
+		if v.NotificationName == notificationName {
+			return v.ID, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func addNotification(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareNotificationJSON(d)
+	body, code, err := c.newRequest(ctx, "/notifications/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateNotification(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareNotificationJSON(d)
+	body, code, err := c.newRequest(ctx, "/notifications/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteNotification(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/notifications/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareNotificationJSON(d *schema.ResourceData) jsonNotification {
+	jsonData := jsonNotification{
+		NotificationName: d.Get("notification_name").(string),
+		Description:      d.Get("description").(string),
+		Enabled:          d.Get("enabled").(bool),
+		Language:         d.Get("language").(string),
+		// only email notifications are managed
+		Type: "email",
+	}
+	listDestination := d.Get("destination").(*schema.Set).List()
+	jsonData.Destination = make([]string, len(listDestination))
+	for i, v := range listDestination {
+		jsonData.Destination[i] = v.(string)
+	}
+	listEvents := d.Get("events").(*schema.Set).List()
+	jsonData.Events = make([]string, len(listEvents))
+	for i, v := range listEvents {
+		jsonData.Events[i] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readNotificationOptions(
+	ctx context.Context, notificationID string, m interface{},
+) (
+	jsonNotification, error,
+) {
+	c := m.(*Client)
+	var result jsonNotification
+	body, code, err := c.newRequest(ctx, "/notifications/"+notificationID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillNotification(d *schema.ResourceData, jsonData jsonNotification) {
+	if tfErr := d.Set("notification_name", jsonData.NotificationName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("enabled", jsonData.Enabled); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("language", jsonData.Language); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("destination", jsonData.Destination); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("events", jsonData.Events); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,61 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceNotification_basic(t *testing.T) {
+	resourceName := "wallix-bastion_notification.testacc_Notification"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceNotificationCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "destination.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceNotificationUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destination.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "events.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "testacc_Notification",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceNotificationCreate() string {
+	return `
+resource "wallix-bastion_notification" "testacc_Notification" {
+  notification_name = "testacc_Notification"
+  events            = ["approval_request"]
+  destination       = ["security@none.none"]
+}
+`
+}
+
+func testAccResourceNotificationUpdate() string {
+	return `
+resource "wallix-bastion_notification" "testacc_Notification" {
+  notification_name = "testacc_Notification"
+  description       = "testacc Notification"
+  events            = ["approval_request", "primary_connection_failure", "license_expiration"]
+  destination       = ["security@none.none", "ops@none.none"]
+  language          = "fr"
+  enabled           = false
+}
+`
+}
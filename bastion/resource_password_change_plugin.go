@@ -0,0 +1,319 @@
+package bastion
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonPasswordChangePlugin struct {
+	ID           string            `json:"id,omitempty"`
+	PluginName   string            `json:"plugin_name"`
+	Description  string            `json:"description"`
+	Protocol     string            `json:"protocol"`
+	PluginScript string            `json:"plugin_script,omitempty"`
+	Parameters   map[string]string `json:"parameters"`
+}
+
+func resourcePasswordChangePlugin() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePasswordChangePluginCreate,
+		ReadContext:   resourcePasswordChangePluginRead,
+		UpdateContext: resourcePasswordChangePluginUpdate,
+		DeleteContext: resourcePasswordChangePluginDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePasswordChangePluginImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"plugin_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"plugin_script": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validateBase64,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// validateBase64 checks that the value is a standard base64 encoded string.
+func validateBase64(val interface{}, key string) ([]string, []error) {
+	v := val.(string)
+	if _, err := base64.StdEncoding.DecodeString(v); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a valid base64 encoded content: %w", key, err)}
+	}
+
+	return nil, nil
+}
+
+func resourcePasswordChangePluginVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_password_change_plugin not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourcePasswordChangePluginCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourcePasswordChangePluginVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourcePasswordChangePlugin(ctx, d.Get("plugin_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("plugin_name %s already exists", d.Get("plugin_name").(string)))
+	}
+	err = addPasswordChangePlugin(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourcePasswordChangePlugin(ctx, d.Get("plugin_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("plugin_name %s not found after POST", d.Get("plugin_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourcePasswordChangePluginRead(ctx, d, m)
+}
+
+func resourcePasswordChangePluginRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourcePasswordChangePluginVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readPasswordChangePluginOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillPasswordChangePlugin(d, cfg)
+	}
+
+	return nil
+}
+
+func resourcePasswordChangePluginUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourcePasswordChangePluginVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updatePasswordChangePlugin(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourcePasswordChangePluginRead(ctx, d, m)
+}
+
+func resourcePasswordChangePluginDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourcePasswordChangePluginVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deletePasswordChangePlugin(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourcePasswordChangePluginImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourcePasswordChangePluginVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourcePasswordChangePlugin(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find plugin_name with id %s (id must be <plugin_name>)", d.Id())
+	}
+	cfg, err := readPasswordChangePluginOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillPasswordChangePlugin(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourcePasswordChangePlugin(
+	ctx context.Context, pluginName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/passwordchangeplugins/?q=plugin_name="+pluginName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonPasswordChangePlugin
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addPasswordChangePlugin(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := preparePasswordChangePluginJSON(d, true)
+	body, code, err := c.newRequest(ctx, "/passwordchangeplugins/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updatePasswordChangePlugin(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := preparePasswordChangePluginJSON(d, false)
+	body, code, err := c.newRequest(ctx, "/passwordchangeplugins/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deletePasswordChangePlugin(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/passwordchangeplugins/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func preparePasswordChangePluginJSON(d *schema.ResourceData, newResource bool) jsonPasswordChangePlugin {
+	jsonData := jsonPasswordChangePlugin{
+		PluginName:  d.Get("plugin_name").(string),
+		Description: d.Get("description").(string),
+		Protocol:    d.Get("protocol").(string),
+		Parameters:  make(map[string]string),
+	}
+	if newResource {
+		jsonData.PluginScript = d.Get("plugin_script").(string)
+	}
+	for k, v := range d.Get("parameters").(map[string]interface{}) {
+		jsonData.Parameters[k] = v.(string)
+	}
+
+	return jsonData
+}
+
+func readPasswordChangePluginOptions(
+	ctx context.Context, pluginID string, m interface{},
+) (
+	jsonPasswordChangePlugin, error,
+) {
+	c := m.(*Client)
+	var result jsonPasswordChangePlugin
+	body, code, err := c.newRequest(ctx, "/passwordchangeplugins/"+pluginID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillPasswordChangePlugin(d *schema.ResourceData, jsonData jsonPasswordChangePlugin) {
+	if tfErr := d.Set("plugin_name", jsonData.PluginName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("protocol", jsonData.Protocol); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("parameters", jsonData.Parameters); tfErr != nil {
+		panic(tfErr)
+	}
+}
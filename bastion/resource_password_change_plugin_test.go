@@ -0,0 +1,72 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourcePasswordChangePlugin_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourcePasswordChangePluginInvalid(),
+				ExpectError: regexp.MustCompile(`must be a valid base64 encoded content`),
+			},
+			{
+				Config: testAccResourcePasswordChangePluginCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_password_change_plugin.testacc_PasswordChangePlugin",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourcePasswordChangePluginUpdate(),
+			},
+			{
+				ResourceName:  "wallix-bastion_password_change_plugin.testacc_PasswordChangePlugin",
+				ImportState:   true,
+				ImportStateId: "testacc_PasswordChangePlugin",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourcePasswordChangePluginInvalid() string {
+	return `
+resource "wallix-bastion_password_change_plugin" "testacc_PasswordChangePlugin" {
+  plugin_name   = "testacc_PasswordChangePlugin"
+  protocol      = "SSH"
+  plugin_script = "#!/bin/sh"
+}
+`
+}
+
+func testAccResourcePasswordChangePluginCreate() string {
+	return `
+resource "wallix-bastion_password_change_plugin" "testacc_PasswordChangePlugin" {
+  plugin_name   = "testacc_PasswordChangePlugin"
+  protocol      = "SSH"
+  plugin_script = base64encode("#!/bin/sh\nexit 0\n")
+}
+`
+}
+
+func testAccResourcePasswordChangePluginUpdate() string {
+	return `
+resource "wallix-bastion_password_change_plugin" "testacc_PasswordChangePlugin" {
+  plugin_name   = "testacc_PasswordChangePlugin"
+  description   = "testacc PasswordChangePlugin"
+  protocol      = "SSH"
+  plugin_script = base64encode("#!/bin/sh\nexit 0\n")
+  parameters = {
+    timeout = "30"
+  }
+}
+`
+}
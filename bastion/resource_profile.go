@@ -270,38 +270,38 @@ func resourceProfile() *schema.Resource {
 	}
 }
 
-func resourceProfileVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceProfileVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_profile not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_profile not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceProfileCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceProfileVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceProfileVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceProfile(ctx, d.Get("profile_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("profile_name %s already exists", d.Get("profile_name").(string)))
+		return diagFromAPIError(fmt.Errorf("profile_name %s already exists", d.Get("profile_name").(string)))
 	}
 	err = addProfile(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceProfile(ctx, d.Get("profile_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("profile_name %s not found after POST", d.Get("profile_name").(string)))
+		return diagFromAPIError(fmt.Errorf("profile_name %s not found after POST", d.Get("profile_name").(string)))
 	}
 	d.SetId(id)
 
@@ -312,12 +312,12 @@ func resourceProfileRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceProfileVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceProfileVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readProfileOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -333,11 +333,11 @@ func resourceProfileUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceProfileVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceProfileVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateProfile(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -348,11 +348,11 @@ func resourceProfileDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceProfileVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceProfileVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteProfile(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -365,7 +365,7 @@ func resourceProfileImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceProfileVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceProfileVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceProfile(ctx, d.Id(), m)
@@ -396,12 +396,12 @@ func searchResourceProfile(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/profiles/?q=profile_name="+profileName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/profiles/?q=profile_name="+profileName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonProfile
 	err = json.Unmarshal([]byte(body), &results)
@@ -425,7 +425,7 @@ func addProfile(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -441,7 +441,7 @@ func updateProfile(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -456,7 +456,7 @@ func deleteProfile(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -618,7 +618,7 @@ func readProfileOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -0,0 +1,304 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonStandaloneRestriction struct {
+	ID              string `json:"id,omitempty"`
+	RestrictionName string `json:"restriction_name"`
+	Description     string `json:"description"`
+	Action          string `json:"action"`
+	SubProtocol     string `json:"subprotocol"`
+	Rules           string `json:"rules"`
+}
+
+func resourceRestriction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRestrictionCreate,
+		ReadContext:   resourceRestrictionRead,
+		UpdateContext: resourceRestrictionUpdate,
+		DeleteContext: resourceRestrictionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceRestrictionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"restriction_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"restriction_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"kill", "notify"}, false),
+			},
+			"subprotocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(restrictionSubProtocolsValid(), false),
+			},
+			"rules": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceRestrictionVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_restriction not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceRestrictionCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceRestrictionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceRestriction(ctx, d.Get("restriction_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("restriction_name %s already exists", d.Get("restriction_name").(string)))
+	}
+	err = addRestriction(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceRestriction(ctx, d.Get("restriction_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("restriction_name %s not found after POST", d.Get("restriction_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceRestrictionRead(ctx, d, m)
+}
+
+func resourceRestrictionRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceRestrictionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readRestrictionOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillRestriction(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceRestrictionUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceRestrictionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateRestriction(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceRestrictionRead(ctx, d, m)
+}
+
+func resourceRestrictionDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceRestrictionVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteRestriction(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceRestrictionImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceRestrictionVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceRestriction(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find restriction_name with id %s (id must be <restriction_name>)", d.Id())
+	}
+	cfg, err := readRestrictionOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillRestriction(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceRestriction(
+	ctx context.Context, restrictionName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/restrictions/?q=restriction_name="+restrictionName,
+		http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonStandaloneRestriction
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addRestriction(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareRestrictionJSON(d)
+	body, code, err := c.newRequest(ctx, "/restrictions/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateRestriction(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareRestrictionJSON(d)
+	body, code, err := c.newRequest(ctx, "/restrictions/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteRestriction(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/restrictions/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareRestrictionJSON(d *schema.ResourceData) jsonStandaloneRestriction {
+	jsonData := jsonStandaloneRestriction{
+		RestrictionName: d.Get("restriction_name").(string),
+		Description:     d.Get("description").(string),
+		Action:          d.Get("restriction_type").(string),
+		SubProtocol:     d.Get("subprotocol").(string),
+		Rules:           d.Get("rules").(string),
+	}
+
+	return jsonData
+}
+
+func readRestrictionOptions(
+	ctx context.Context, restrictionID string, m interface{},
+) (
+	jsonStandaloneRestriction, error,
+) {
+	c := m.(*Client)
+	var result jsonStandaloneRestriction
+	body, code, err := c.newRequest(ctx, "/restrictions/"+restrictionID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillRestriction(d *schema.ResourceData, jsonData jsonStandaloneRestriction) {
+	if tfErr := d.Set("restriction_name", jsonData.RestrictionName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("restriction_type", jsonData.Action); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("subprotocol", jsonData.SubProtocol); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("rules", jsonData.Rules); tfErr != nil {
+		panic(tfErr)
+	}
+}
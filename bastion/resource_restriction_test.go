@@ -0,0 +1,61 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceRestriction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceRestrictionCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_restriction.testacc_Restriction",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceRestrictionUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_restriction.testacc_Restriction",
+						"restriction_type", "kill"),
+				),
+			},
+			{
+				ResourceName:  "wallix-bastion_restriction.testacc_Restriction",
+				ImportState:   true,
+				ImportStateId: "testacc_Restriction",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceRestrictionCreate() string {
+	return `
+resource "wallix-bastion_restriction" "testacc_Restriction" {
+  restriction_name = "testacc_Restriction"
+  restriction_type = "notify"
+  subprotocol      = "SSH_SHELL_SESSION"
+  rules            = "^shutdown"
+}
+`
+}
+
+func testAccResourceRestrictionUpdate() string {
+	return `
+resource "wallix-bastion_restriction" "testacc_Restriction" {
+  restriction_name = "testacc_Restriction"
+  description      = "testacc Restriction"
+  restriction_type = "kill"
+  subprotocol      = "SSH_SHELL_SESSION"
+  rules            = "^(shutdown|reboot)"
+}
+`
+}
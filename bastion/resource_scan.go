@@ -0,0 +1,372 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonScan struct {
+	ID            string   `json:"id,omitempty"`
+	ScanName      string   `json:"scan_name"`
+	Description   string   `json:"description"`
+	Type          string   `json:"type"`
+	Subnets       []string `json:"subnets"`
+	Devices       []string `json:"devices"`
+	Ports         []int    `json:"ports"`
+	MasterAccount string   `json:"master_account"`
+	BannerRegexes []string `json:"banner_regexes"`
+}
+
+func resourceScan() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScanCreate,
+		ReadContext:   resourceScanRead,
+		UpdateContext: resourceScanUpdate,
+		DeleteContext: resourceScanDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceScanImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"scan_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"account_discovery", "network_discovery"}, false),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"subnets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"devices": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ports": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IsPortNumber,
+				},
+			},
+			"master_account": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"banner_regexes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsValidRegExp,
+				},
+			},
+		},
+	}
+}
+
+func resourceScanVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_scan not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceScanCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceScanVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceScan(ctx, d.Get("scan_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("scan_name %s already exists", d.Get("scan_name").(string)))
+	}
+	err = addScan(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceScan(ctx, d.Get("scan_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("scan_name %s not found after POST", d.Get("scan_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceScanRead(ctx, d, m)
+}
+
+func resourceScanRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceScanVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readScanOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillScan(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceScanUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceScanVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateScan(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceScanRead(ctx, d, m)
+}
+
+func resourceScanDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceScanVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteScan(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceScanImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceScanVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceScan(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find scan_name with id %s (id must be <scan_name>)", d.Id())
+	}
+	cfg, err := readScanOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillScan(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceScan(
+	ctx context.Context, scanName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/scans/?q=scan_name="+scanName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonScan
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	for _, v := range results {
+		if v.ScanName == scanName {
+			return v.ID, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func addScan(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData, err := prepareScanJSON(d)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/scans/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateScan(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData, err := prepareScanJSON(d)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/scans/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteScan(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/scans/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareScanJSON(d *schema.ResourceData) (jsonScan, error) {
+	jsonData := jsonScan{
+		ScanName:      d.Get("scan_name").(string),
+		Description:   d.Get("description").(string),
+		Type:          d.Get("type").(string),
+		MasterAccount: d.Get("master_account").(string),
+	}
+	listSubnets := d.Get("subnets").(*schema.Set).List()
+	jsonData.Subnets = make([]string, len(listSubnets))
+	for i, v := range listSubnets {
+		jsonData.Subnets[i] = v.(string)
+	}
+	listDevices := d.Get("devices").(*schema.Set).List()
+	jsonData.Devices = make([]string, len(listDevices))
+	for i, v := range listDevices {
+		jsonData.Devices[i] = v.(string)
+	}
+	listBannerRegexes := d.Get("banner_regexes").([]interface{})
+	jsonData.BannerRegexes = make([]string, len(listBannerRegexes))
+	for i, v := range listBannerRegexes {
+		jsonData.BannerRegexes[i] = v.(string)
+	}
+	listPorts := d.Get("ports").(*schema.Set).List()
+	jsonData.Ports = make([]int, len(listPorts))
+	for i, v := range listPorts {
+		jsonData.Ports[i] = v.(int)
+	}
+	switch {
+	case jsonData.Type == "network_discovery" && len(jsonData.Subnets) == 0:
+		return jsonData, fmt.Errorf("subnets must be set with type %q", jsonData.Type)
+	case jsonData.Type == "account_discovery" && len(jsonData.Devices) == 0:
+		return jsonData, fmt.Errorf("devices must be set with type %q", jsonData.Type)
+	}
+
+	return jsonData, nil
+}
+
+func readScanOptions(
+	ctx context.Context, scanID string, m interface{},
+) (
+	jsonScan, error,
+) {
+	c := m.(*Client)
+	var result jsonScan
+	body, code, err := c.newRequest(ctx, "/scans/"+scanID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillScan(d *schema.ResourceData, jsonData jsonScan) {
+	if tfErr := d.Set("scan_name", jsonData.ScanName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("type", jsonData.Type); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("subnets", jsonData.Subnets); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("devices", jsonData.Devices); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("ports", jsonData.Ports); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("master_account", jsonData.MasterAccount); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("banner_regexes", jsonData.BannerRegexes); tfErr != nil {
+		panic(tfErr)
+	}
+}
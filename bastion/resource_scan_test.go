@@ -0,0 +1,62 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceScan_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceScanCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_scan.testacc_Scan",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceScanUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_scan.testacc_Scan",
+						"subnets.#", "2"),
+				),
+			},
+			{
+				ResourceName:  "wallix-bastion_scan.testacc_Scan",
+				ImportState:   true,
+				ImportStateId: "testacc_Scan",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceScanCreate() string {
+	return `
+resource "wallix-bastion_scan" "testacc_Scan" {
+  scan_name = "testacc_Scan"
+  type      = "network_discovery"
+  subnets   = ["192.0.2.0/24"]
+  ports     = [22]
+}
+`
+}
+
+func testAccResourceScanUpdate() string {
+	return `
+resource "wallix-bastion_scan" "testacc_Scan" {
+  scan_name      = "testacc_Scan"
+  description    = "testacc Scan"
+  type           = "network_discovery"
+  subnets        = ["192.0.2.0/24", "198.51.100.0/24"]
+  ports          = [22, 3389]
+  banner_regexes = ["^SSH-2\\.0-OpenSSH"]
+}
+`
+}
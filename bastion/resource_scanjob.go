@@ -0,0 +1,314 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonScanjob struct {
+	ID          string `json:"id,omitempty"`
+	ScanName    string `json:"scan_name"`
+	Periodicity string `json:"periodicity"`
+	Enabled     bool   `json:"enabled"`
+	LastRun     string `json:"last_run,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+func resourceScanjob() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScanjobCreate,
+		ReadContext:   resourceScanjobRead,
+		UpdateContext: resourceScanjobUpdate,
+		DeleteContext: resourceScanjobDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceScanjobImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"scan_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"periodicity": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[0-9*,/-]+( [0-9*,/-]+){4}$`),
+					"must be a cron expression with 5 fields (minute hour day month weekday)"),
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"last_run": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceScanjobVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_scanjob not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceScanjobCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceScanjobVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceScan(ctx, d.Get("scan_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("scan_name %s doesn't exists", d.Get("scan_name").(string)))
+	}
+	_, ex, err = searchResourceScanjob(ctx, d.Get("scan_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("scan job of scan_name %s already exists", d.Get("scan_name").(string)))
+	}
+	err = addScanjob(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceScanjob(ctx, d.Get("scan_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("scan job of scan_name %s not found after POST",
+			d.Get("scan_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceScanjobRead(ctx, d, m)
+}
+
+func resourceScanjobRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceScanjobVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readScanjobOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillScanjob(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceScanjobUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceScanjobVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateScanjob(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceScanjobRead(ctx, d, m)
+}
+
+func resourceScanjobDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceScanjobVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteScanjob(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceScanjobImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceScanjobVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceScanjob(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find scan job of scan_name with id %s (id must be <scan_name>)", d.Id())
+	}
+	cfg, err := readScanjobOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillScanjob(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceScanjob(
+	ctx context.Context, scanName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx, "/scanjobs/?q=scan_name="+scanName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonScanjob
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	for _, v := range results {
+		if v.ScanName == scanName {
+			return v.ID, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func addScanjob(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareScanjobJSON(d)
+	body, code, err := c.newRequest(ctx, "/scanjobs/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateScanjob(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData := prepareScanjobJSON(d)
+	body, code, err := c.newRequest(ctx, "/scanjobs/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteScanjob(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/scanjobs/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareScanjobJSON(d *schema.ResourceData) jsonScanjob {
+	jsonData := jsonScanjob{
+		ScanName:    d.Get("scan_name").(string),
+		Periodicity: d.Get("periodicity").(string),
+		Enabled:     d.Get("enabled").(bool),
+	}
+
+	return jsonData
+}
+
+func readScanjobOptions(
+	ctx context.Context, scanjobID string, m interface{},
+) (
+	jsonScanjob, error,
+) {
+	c := m.(*Client)
+	var result jsonScanjob
+	body, code, err := c.newRequest(ctx, "/scanjobs/"+scanjobID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillScanjob(d *schema.ResourceData, jsonData jsonScanjob) {
+	if tfErr := d.Set("scan_name", jsonData.ScanName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("periodicity", jsonData.Periodicity); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("enabled", jsonData.Enabled); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("last_run", jsonData.LastRun); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("status", jsonData.Status); tfErr != nil {
+		panic(tfErr)
+	}
+}
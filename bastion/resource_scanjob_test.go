@@ -0,0 +1,88 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceScanjob_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceScanjobCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_scanjob.testacc_Scanjob",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceScanjobUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_scanjob.testacc_Scanjob",
+						"enabled", "false"),
+				),
+			},
+			{
+				ResourceName:  "wallix-bastion_scanjob.testacc_Scanjob",
+				ImportState:   true,
+				ImportStateId: "testacc_Scanjob",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func TestAccResourceScanjob_missingScan(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "wallix-bastion_scanjob" "testacc_ScanjobMissing" {
+  scan_name   = "testacc_ScanjobMissing"
+  periodicity = "0 2 * * *"
+}
+`,
+				ExpectError: regexp.MustCompile(`scan_name testacc_ScanjobMissing doesn't exists`),
+			},
+		},
+	})
+}
+
+func testAccResourceScanjobCreate() string {
+	return `
+resource "wallix-bastion_scan" "testacc_Scanjob" {
+  scan_name = "testacc_Scanjob"
+  type      = "network_discovery"
+  subnets   = ["192.0.2.0/24"]
+  ports     = [22]
+}
+resource "wallix-bastion_scanjob" "testacc_Scanjob" {
+  scan_name   = wallix-bastion_scan.testacc_Scanjob.scan_name
+  periodicity = "0 2 * * *"
+}
+`
+}
+
+func testAccResourceScanjobUpdate() string {
+	return `
+resource "wallix-bastion_scan" "testacc_Scanjob" {
+  scan_name = "testacc_Scanjob"
+  type      = "network_discovery"
+  subnets   = ["192.0.2.0/24"]
+  ports     = [22]
+}
+resource "wallix-bastion_scanjob" "testacc_Scanjob" {
+  scan_name   = wallix-bastion_scan.testacc_Scanjob.scan_name
+  periodicity = "0 3 * * 1-5"
+  enabled     = false
+}
+`
+}
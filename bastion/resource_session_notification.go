@@ -0,0 +1,340 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type jsonSessionNotification struct {
+	ID                  string   `json:"id,omitempty"`
+	NotificationName    string   `json:"notification_name"`
+	Description         string   `json:"description"`
+	TargetAuthorization string   `json:"target_authorization"`
+	DeliveryMethod      string   `json:"delivery_method"`
+	TriggerEvents       []string `json:"trigger_events"`
+	Recipients          []string `json:"recipients"`
+}
+
+func resourceSessionNotification() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSessionNotificationCreate,
+		ReadContext:   resourceSessionNotificationRead,
+		UpdateContext: resourceSessionNotificationUpdate,
+		DeleteContext: resourceSessionNotificationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSessionNotificationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"notification_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"target_authorization": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"trigger_events": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"session_start",
+						"session_end",
+						"suspicious_command",
+						"data_transfer",
+					}, false),
+				},
+			},
+			"delivery_method": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"email", "syslog"}, false),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"recipients": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceSessionNotificationVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_session_notification not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceSessionNotificationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceSessionNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceSessionNotification(ctx, d.Get("notification_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("notification_name %s already exists", d.Get("notification_name").(string)))
+	}
+	err = addSessionNotification(ctx, d, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	id, ex, err := searchResourceSessionNotification(ctx, d.Get("notification_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("notification_name %s not found after POST", d.Get("notification_name").(string)))
+	}
+	d.SetId(id)
+
+	return resourceSessionNotificationRead(ctx, d, m)
+}
+
+func resourceSessionNotificationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceSessionNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	cfg, err := readSessionNotificationOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if cfg.ID == "" {
+		d.SetId("")
+	} else {
+		fillSessionNotification(d, cfg)
+	}
+
+	return nil
+}
+
+func resourceSessionNotificationUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceSessionNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateSessionNotification(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceSessionNotificationRead(ctx, d, m)
+}
+
+func resourceSessionNotificationDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceSessionNotificationVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteSessionNotification(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceSessionNotificationImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceSessionNotificationVersionCheck(c); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceSessionNotification(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find notification_name with id %s (id must be <notification_name>)", d.Id())
+	}
+	cfg, err := readSessionNotificationOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	fillSessionNotification(d, cfg)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func searchResourceSessionNotification(
+	ctx context.Context, notificationName string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx,
+		"/sessionnotifications/?q=notification_name="+notificationName, http.MethodGet, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if code != http.StatusOK {
+		return "", false, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonSessionNotification
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+func addSessionNotification(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData, err := prepareSessionNotificationJSON(d)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/sessionnotifications/", http.MethodPost, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func updateSessionNotification(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	jsonData, err := prepareSessionNotificationJSON(d)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.newRequest(ctx, "/sessionnotifications/"+d.Id(), http.MethodPut, jsonData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func deleteSessionNotification(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	body, code, err := c.newRequest(ctx, "/sessionnotifications/"+d.Id(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func prepareSessionNotificationJSON(d *schema.ResourceData) (jsonSessionNotification, error) {
+	jsonData := jsonSessionNotification{
+		NotificationName:    d.Get("notification_name").(string),
+		Description:         d.Get("description").(string),
+		TargetAuthorization: d.Get("target_authorization").(string),
+		DeliveryMethod:      d.Get("delivery_method").(string),
+	}
+	listTriggerEvents := d.Get("trigger_events").(*schema.Set).List()
+	jsonData.TriggerEvents = make([]string, len(listTriggerEvents))
+	for i, v := range listTriggerEvents {
+		jsonData.TriggerEvents[i] = v.(string)
+	}
+	listRecipients := d.Get("recipients").(*schema.Set).List()
+	jsonData.Recipients = make([]string, len(listRecipients))
+	for i, v := range listRecipients {
+		jsonData.Recipients[i] = v.(string)
+	}
+	if jsonData.DeliveryMethod == "email" && len(jsonData.Recipients) == 0 {
+		return jsonData, fmt.Errorf("recipients must be set with delivery_method %q", jsonData.DeliveryMethod)
+	}
+
+	return jsonData, nil
+}
+
+func readSessionNotificationOptions(
+	ctx context.Context, notificationID string, m interface{},
+) (
+	jsonSessionNotification, error,
+) {
+	c := m.(*Client)
+	var result jsonSessionNotification
+	body, code, err := c.newRequest(ctx, "/sessionnotifications/"+notificationID, http.MethodGet, nil)
+	if err != nil {
+		return result, err
+	}
+	if code == http.StatusNotFound {
+		return result, nil
+	}
+	if code != http.StatusOK {
+		return result, newAPIError("api doesn't return OK", code, body)
+	}
+	err = json.Unmarshal([]byte(body), &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return result, nil
+}
+
+func fillSessionNotification(d *schema.ResourceData, jsonData jsonSessionNotification) {
+	if tfErr := d.Set("notification_name", jsonData.NotificationName); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("target_authorization", jsonData.TargetAuthorization); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("delivery_method", jsonData.DeliveryMethod); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("trigger_events", jsonData.TriggerEvents); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("recipients", jsonData.Recipients); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,101 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSessionNotification_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceSessionNotificationInvalid(),
+				ExpectError: regexp.MustCompile(`expected trigger_events\.[0-9]+ to be one of`),
+			},
+			{
+				Config: testAccResourceSessionNotificationCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"wallix-bastion_session_notification.testacc_SessionNotification",
+						"id"),
+				),
+			},
+			{
+				Config: testAccResourceSessionNotificationUpdate(),
+			},
+			{
+				ResourceName:  "wallix-bastion_session_notification.testacc_SessionNotification",
+				ImportState:   true,
+				ImportStateId: "testacc_SessionNotification",
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceSessionNotificationInvalid() string {
+	return `
+resource "wallix-bastion_session_notification" "testacc_SessionNotification" {
+  notification_name    = "testacc_SessionNotification"
+  target_authorization = "testacc_SessionNotification"
+  trigger_events       = ["session_pause"]
+  delivery_method      = "syslog"
+}
+`
+}
+
+func testAccResourceSessionNotificationCreate() string {
+	return `
+resource "wallix-bastion_usergroup" "testacc_SessionNotification" {
+  group_name = "testacc_SessionNotification"
+  timeframes = ["allthetime"]
+}
+resource "wallix-bastion_targetgroup" "testacc_SessionNotification" {
+  group_name = "testacc_SessionNotification"
+}
+resource "wallix-bastion_authorization" "testacc_SessionNotification" {
+  authorization_name = "testacc_SessionNotification"
+  user_group         = wallix-bastion_usergroup.testacc_SessionNotification.group_name
+  target_group       = wallix-bastion_targetgroup.testacc_SessionNotification.group_name
+  authorize_sessions = true
+  subprotocols       = ["SSH_SHELL_SESSION"]
+}
+resource "wallix-bastion_session_notification" "testacc_SessionNotification" {
+  notification_name    = "testacc_SessionNotification"
+  target_authorization = wallix-bastion_authorization.testacc_SessionNotification.authorization_name
+  trigger_events       = ["session_start", "session_end"]
+  delivery_method      = "syslog"
+}
+`
+}
+
+func testAccResourceSessionNotificationUpdate() string {
+	return `
+resource "wallix-bastion_usergroup" "testacc_SessionNotification" {
+  group_name = "testacc_SessionNotification"
+  timeframes = ["allthetime"]
+}
+resource "wallix-bastion_targetgroup" "testacc_SessionNotification" {
+  group_name = "testacc_SessionNotification"
+}
+resource "wallix-bastion_authorization" "testacc_SessionNotification" {
+  authorization_name = "testacc_SessionNotification"
+  user_group         = wallix-bastion_usergroup.testacc_SessionNotification.group_name
+  target_group       = wallix-bastion_targetgroup.testacc_SessionNotification.group_name
+  authorize_sessions = true
+  subprotocols       = ["SSH_SHELL_SESSION"]
+}
+resource "wallix-bastion_session_notification" "testacc_SessionNotification" {
+  notification_name    = "testacc_SessionNotification"
+  description          = "testacc SessionNotification"
+  target_authorization = wallix-bastion_authorization.testacc_SessionNotification.authorization_name
+  trigger_events       = ["suspicious_command", "data_transfer"]
+  delivery_method      = "email"
+  recipients           = ["security@example.com"]
+}
+`
+}
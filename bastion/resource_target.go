@@ -0,0 +1,666 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTarget() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTargetCreate,
+		ReadContext:   resourceTargetRead,
+		UpdateContext: resourceTargetUpdate,
+		DeleteContext: resourceTargetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTargetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"device_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "SSH",
+				ValidateFunc: validation.StringInSlice(
+					[]string{"SSH", "RAWTCPIP", "RDP", "RLOGIN", "TELNET", "VNC"},
+					false,
+				),
+			},
+			"service_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"connection_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "local",
+			},
+			"account_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"account_login": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "private_key"},
+			},
+			"private_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"passphrase": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"private_key"},
+			},
+			"device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"service_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"credential_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTargetVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_target not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceTargetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceTargetVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := checkDeviceServiceProtocol(c.bastionAPIVersion, d.Get("protocol").(string)); err != nil {
+		return diagFromAPIError(err)
+	}
+	_, ex, err := searchResourceDevice(ctx, d.Get("device_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if ex {
+		return diagFromAPIError(fmt.Errorf("device_name %s already exists", d.Get("device_name").(string)))
+	}
+	if err := addTargetChildren(ctx, d, m); err != nil {
+		if cleanupErr := deleteTargetChildren(ctx, d, m); cleanupErr != nil {
+			// keep the created children in state, the resource is tainted and replaced on the next apply
+			return diagFromAPIError(fmt.Errorf("%w, cleaning up the created objects: %w", err, cleanupErr))
+		}
+		d.SetId("")
+
+		return diagFromAPIError(err)
+	}
+
+	return resourceTargetRead(ctx, d, m)
+}
+
+func resourceTargetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceTargetVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := readTarget(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceTargetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	c := m.(*Client)
+	if err := resourceTargetVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("host") {
+		if err := updateTargetObject(ctx, d,
+			"/devices/"+d.Get("device_id").(string), prepareDeviceJSON(targetDeviceData(d)), m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	if d.Get("service_id").(string) != "" && d.HasChanges("port", "connection_policy") {
+		jsonData, err := prepareDeviceServiceJSON(targetServiceData(d), false)
+		if err != nil {
+			return diagFromAPIError(err)
+		}
+		if err := updateTargetObject(ctx, d,
+			"/devices/"+d.Get("device_id").(string)+"/services/"+d.Get("service_id").(string)+"?force=true",
+			jsonData, m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	if d.Get("account_id").(string) != "" && d.HasChange("account_login") {
+		if err := updateDeviceLocalDomainAccount(ctx, targetAccountData(d), m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	if d.Get("credential_id").(string) != "" && d.HasChanges("password", "private_key", "passphrase") {
+		if err := updateTargetCredential(ctx, d, m); err != nil {
+			return diagFromAPIError(err)
+		}
+	}
+	// recreate the children removed outside of Terraform
+	if err := addTargetChildren(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.Partial(false)
+
+	return resourceTargetRead(ctx, d, m)
+}
+
+func resourceTargetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceTargetVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := deleteTargetChildren(ctx, d, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceTargetImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceTargetVersionCheck(c); err != nil {
+		return nil, err
+	}
+	deviceID, ex, err := searchResourceDevice(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find device_name with id %s (id must be <device_name>)", d.Id())
+	}
+	device, err := readDeviceOptions(ctx, deviceID, m)
+	if err != nil {
+		return nil, err
+	}
+	if device.Services == nil || len(*device.Services) != 1 {
+		return nil, fmt.Errorf("device %s must have exactly one service to be imported as a target", d.Id())
+	}
+	if device.LocalDomains == nil || len(*device.LocalDomains) != 1 {
+		return nil, fmt.Errorf("device %s must have exactly one local domain to be imported as a target", d.Id())
+	}
+	domainID := (*device.LocalDomains)[0].ID
+	accounts, err := listTargetAccounts(ctx, deviceID, domainID, m)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) != 1 {
+		return nil, fmt.Errorf("device %s must have exactly one account to be imported as a target", d.Id())
+	}
+	credentialID, err := searchTargetCredential(ctx, deviceID, domainID, accounts[0].ID, m)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range map[string]string{
+		"device_id":     deviceID,
+		"service_id":    (*device.Services)[0].ID,
+		"protocol":      (*device.Services)[0].Protocol,
+		"domain_id":     domainID,
+		"domain_name":   (*device.LocalDomains)[0].DomainName,
+		"account_id":    accounts[0].ID,
+		"credential_id": credentialID,
+	} {
+		if tfErr := d.Set(k, v); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	deviceName := d.Id()
+	d.SetId(deviceID)
+	if err := readTarget(ctx, d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("device %s removed during the import", deviceName)
+	}
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+// addTargetChildren creates, in order, the device, service, local domain, account and credential
+// which don't have an ID in state yet. The ID of each child is recorded as soon as it's created,
+// so a failure leaves in state the children to clean up.
+func addTargetChildren(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.Get("device_id").(string) == "" {
+		if err := addDevice(ctx, targetDeviceData(d), m); err != nil {
+			return err
+		}
+		id, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+			return searchResourceDevice(ctx, d.Get("device_name").(string), m)
+		})
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("device_name %s not found after POST", d.Get("device_name").(string))
+		}
+		d.SetId(id)
+		setTargetChildID(d, "device_id", id)
+	}
+	deviceID := d.Get("device_id").(string)
+	if d.Get("service_id").(string) == "" {
+		if err := addDeviceService(ctx, targetServiceData(d), m); err != nil {
+			return err
+		}
+		id, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+			return searchResourceDeviceService(ctx, deviceID, targetServiceName(d), m)
+		})
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("service_name %s on device %s not found after POST", targetServiceName(d), deviceID)
+		}
+		setTargetChildID(d, "service_id", id)
+	}
+	if d.Get("domain_id").(string) == "" {
+		if err := addDeviceLocalDomain(ctx, targetDomainData(d), m); err != nil {
+			return err
+		}
+		id, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+			return searchResourceDeviceLocalDomain(ctx, deviceID, d.Get("domain_name").(string), m)
+		})
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("domain_name %s on device %s not found after POST", d.Get("domain_name").(string), deviceID)
+		}
+		setTargetChildID(d, "domain_id", id)
+		// a new domain has no account
+		setTargetChildID(d, "account_id", "")
+	}
+	domainID := d.Get("domain_id").(string)
+	if d.Get("account_id").(string) == "" {
+		if err := addDeviceLocalDomainAccount(ctx, targetAccountData(d), m); err != nil {
+			return err
+		}
+		id, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+			return searchResourceDeviceLocalDomainAccount(ctx, deviceID, domainID, targetAccountName(d), m)
+		})
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("account_name %s on device %s not found after POST", targetAccountName(d), deviceID)
+		}
+		setTargetChildID(d, "account_id", id)
+		// a new account has no credential
+		setTargetChildID(d, "credential_id", "")
+	}
+	accountID := d.Get("account_id").(string)
+	if d.Get("credential_id").(string) == "" {
+		credential := targetCredentialData(d)
+		if err := addDeviceLocalDomainAccountCredential(ctx, credential, m); err != nil {
+			return err
+		}
+		id, ex, err := searchAfterCreate(ctx, func(ctx context.Context) (string, bool, error) {
+			return searchResourceDeviceLocalDomainAccountCredential(
+				ctx, deviceID, domainID, accountID, credential.Get("type").(string), m)
+		})
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("credential of account_name %s on device %s not found after POST",
+				targetAccountName(d), deviceID)
+		}
+		setTargetChildID(d, "credential_id", id)
+	}
+
+	return nil
+}
+
+// deleteTargetChildren deletes the children in the reverse order of their creation,
+// the ID of each deleted child is removed from state so a retry only deletes the remaining ones.
+func deleteTargetChildren(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.Get("credential_id").(string) != "" {
+		if err := deleteDeviceLocalDomainAccountCredential(ctx, targetCredentialData(d), m); err != nil {
+			return err
+		}
+		setTargetChildID(d, "credential_id", "")
+	}
+	if d.Get("account_id").(string) != "" {
+		if err := deleteDeviceLocalDomainAccount(ctx, targetAccountData(d), m); err != nil {
+			return err
+		}
+		setTargetChildID(d, "account_id", "")
+	}
+	if d.Get("domain_id").(string) != "" {
+		if err := deleteDeviceLocalDomain(ctx, targetDomainData(d), m); err != nil {
+			return err
+		}
+		setTargetChildID(d, "domain_id", "")
+	}
+	if d.Get("service_id").(string) != "" {
+		if err := deleteDeviceService(ctx, targetServiceData(d), m); err != nil {
+			return err
+		}
+		setTargetChildID(d, "service_id", "")
+	}
+	if d.Get("device_id").(string) != "" {
+		if err := deleteDevice(ctx, targetDeviceData(d), m); err != nil {
+			return err
+		}
+		setTargetChildID(d, "device_id", "")
+	}
+
+	return nil
+}
+
+// readTarget reads all the children, a missing child has its ID removed from state
+// and its attributes emptied so the next plan recreates it.
+func readTarget(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	device, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
+	if err != nil {
+		return err
+	}
+	if device.ID == "" {
+		d.SetId("")
+
+		return nil
+	}
+	fillTarget(d, map[string]interface{}{
+		"device_name": device.DeviceName,
+		"host":        device.Host,
+	})
+	deviceID := device.ID
+	service := jsonDeviceService{}
+	if id := d.Get("service_id").(string); id != "" {
+		service, err = readDeviceServiceOptions(ctx, deviceID, id, m)
+		if err != nil {
+			return err
+		}
+	}
+	fillTarget(d, map[string]interface{}{
+		"service_id":        service.ID,
+		"port":              service.Port,
+		"connection_policy": service.ConnectionPolicy,
+	})
+	if service.ID != "" {
+		fillTarget(d, map[string]interface{}{
+			"service_name": service.ServiceName,
+			"protocol":     service.Protocol,
+		})
+	}
+	domain := jsonDeviceLocalDomain{}
+	if id := d.Get("domain_id").(string); id != "" {
+		domain, err = readDeviceLocalDomainOptions(ctx, deviceID, id, m)
+		if err != nil {
+			return err
+		}
+	}
+	setTargetChildID(d, "domain_id", domain.ID)
+	account := jsonDeviceLocalDomainAccount{}
+	if id := d.Get("account_id").(string); id != "" && domain.ID != "" {
+		account, err = readDeviceLocalDomainAccountOptions(ctx, deviceID, domain.ID, id, m)
+		if err != nil {
+			return err
+		}
+	}
+	fillTarget(d, map[string]interface{}{
+		"account_id":    account.ID,
+		"account_login": account.AccountLogin,
+	})
+	if account.ID != "" {
+		fillTarget(d, map[string]interface{}{
+			"account_name": account.AccountName,
+		})
+	}
+	credential := jsonCredential{}
+	if id := d.Get("credential_id").(string); id != "" && account.ID != "" {
+		credential, err = readDeviceLocalDomainAccountCredentialOptions(ctx, deviceID, domain.ID, account.ID, id, m)
+		if err != nil {
+			return err
+		}
+	}
+	setTargetChildID(d, "credential_id", credential.ID)
+	if credential.ID == "" {
+		// the credential is never returned by the api, empty it only when it's missing
+		fillTarget(d, map[string]interface{}{
+			"password":    "",
+			"private_key": "",
+		})
+	}
+
+	return nil
+}
+
+// updateTargetObject updates a child with PUT, or with PATCH and only the fields changed on the target
+// as the child data built from the target has no plan to compare.
+func updateTargetObject(ctx context.Context, d *schema.ResourceData, path string, jsonData, m interface{}) error {
+	c := m.(*Client)
+	method := http.MethodPut
+	requestData := jsonData
+	if patchSupported(c.bastionAPIVersion) {
+		patchData, err := prepareJSONPatch(d, jsonData)
+		if err != nil {
+			return err
+		}
+		method = http.MethodPatch
+		requestData = patchData
+	}
+	body, code, err := c.newRequest(ctx, path, method, requestData)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return newAPIError("api doesn't return OK or NoContent", code, body)
+	}
+
+	return nil
+}
+
+func listTargetAccounts(
+	ctx context.Context, deviceID, domainID string, m interface{},
+) (
+	[]jsonDeviceLocalDomainAccount, error,
+) {
+	c := m.(*Client)
+	body, code, err := c.newRequestPaged(ctx,
+		"/devices/"+deviceID+"/localdomains/"+domainID+"/accounts/", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, newAPIError("api doesn't return OK", code, body)
+	}
+	var results []jsonDeviceLocalDomainAccount
+	err = json.Unmarshal([]byte(body), &results)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return results, nil
+}
+
+// searchTargetCredential returns the ID of the credential of the account,
+// a password is preferred over a SSH key.
+func searchTargetCredential(ctx context.Context, deviceID, domainID, accountID string, m interface{}) (string, error) {
+	for _, typeCred := range []string{"password", "ssh_key"} {
+		id, ex, err := searchResourceDeviceLocalDomainAccountCredential(ctx, deviceID, domainID, accountID, typeCred, m)
+		if err != nil {
+			return "", err
+		}
+		if ex {
+			return id, nil
+		}
+	}
+
+	return "", errors.New("account has no password or ssh_key credential")
+}
+
+// updateTargetCredential updates the credential in place, or deletes it to be recreated
+// by addTargetChildren when the type changes between password and ssh_key.
+func updateTargetCredential(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	oldKey, newKey := d.GetChange("private_key")
+	if (oldKey.(string) == "") == (newKey.(string) == "") {
+		return updateDeviceLocalDomainAccountCredential(ctx, targetCredentialData(d), m)
+	}
+	if err := deleteDeviceLocalDomainAccountCredential(ctx, targetCredentialData(d), m); err != nil {
+		return err
+	}
+	setTargetChildID(d, "credential_id", "")
+
+	return nil
+}
+
+func targetServiceName(d *schema.ResourceData) string {
+	if v := d.Get("service_name").(string); v != "" {
+		return v
+	}
+
+	return d.Get("protocol").(string)
+}
+
+func targetAccountName(d *schema.ResourceData) string {
+	if v := d.Get("account_name").(string); v != "" {
+		return v
+	}
+
+	return d.Get("account_login").(string)
+}
+
+// targetChildData returns the data of a child resource, to use the helpers of the child resource.
+func targetChildData(r *schema.Resource, id string, values map[string]interface{}) *schema.ResourceData {
+	child := r.Data(nil)
+	child.SetId(id)
+	for k, v := range values {
+		if tfErr := child.Set(k, v); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return child
+}
+
+func targetDeviceData(d *schema.ResourceData) *schema.ResourceData {
+	return targetChildData(resourceDevice(), d.Get("device_id").(string), map[string]interface{}{
+		"device_name": d.Get("device_name").(string),
+		"host":        d.Get("host").(string),
+	})
+}
+
+func targetServiceData(d *schema.ResourceData) *schema.ResourceData {
+	return targetChildData(resourceDeviceService(), d.Get("service_id").(string), map[string]interface{}{
+		"device_id":         d.Get("device_id").(string),
+		"service_name":      targetServiceName(d),
+		"protocol":          d.Get("protocol").(string),
+		"port":              d.Get("port").(int),
+		"connection_policy": d.Get("connection_policy").(string),
+	})
+}
+
+func targetDomainData(d *schema.ResourceData) *schema.ResourceData {
+	return targetChildData(resourceDeviceLocalDomain(), d.Get("domain_id").(string), map[string]interface{}{
+		"device_id":   d.Get("device_id").(string),
+		"domain_name": d.Get("domain_name").(string),
+	})
+}
+
+func targetAccountData(d *schema.ResourceData) *schema.ResourceData {
+	return targetChildData(resourceDeviceLocalDomainAccount(), d.Get("account_id").(string), map[string]interface{}{
+		"device_id":       d.Get("device_id").(string),
+		"domain_id":       d.Get("domain_id").(string),
+		"account_name":    targetAccountName(d),
+		"account_login":   d.Get("account_login").(string),
+		"checkout_policy": "default",
+		"services":        []interface{}{targetServiceName(d)},
+	})
+}
+
+func targetCredentialData(d *schema.ResourceData) *schema.ResourceData {
+	values := map[string]interface{}{
+		"device_id":  d.Get("device_id").(string),
+		"domain_id":  d.Get("domain_id").(string),
+		"account_id": d.Get("account_id").(string),
+		"type":       "password",
+		"password":   d.Get("password").(string),
+	}
+	if d.Get("private_key").(string) != "" {
+		values["type"] = "ssh_key"
+		values["private_key"] = d.Get("private_key").(string)
+		values["passphrase"] = d.Get("passphrase").(string)
+	}
+
+	return targetChildData(resourceDeviceLocalDomainAccountCredential(), d.Get("credential_id").(string), values)
+}
+
+func setTargetChildID(d *schema.ResourceData, key, id string) {
+	if tfErr := d.Set(key, id); tfErr != nil {
+		panic(tfErr)
+	}
+}
+
+func fillTarget(d *schema.ResourceData, values map[string]interface{}) {
+	for k, v := range values {
+		if tfErr := d.Set(k, v); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+}
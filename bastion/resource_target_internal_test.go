@@ -0,0 +1,100 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestResourceTargetCreateCleanup(t *testing.T) {
+	deviceCreated := false
+	var deleted []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3.12/devices/":
+			results := []jsonDevice{}
+			if deviceCreated {
+				results = append(results, jsonDevice{ID: "d1", DeviceName: "srv1"})
+			}
+			_ = json.NewEncoder(w).Encode(results)
+		case "POST /api/v3.12/devices/":
+			deviceCreated = true
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /api/v3.12/devices/d1/services/":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid connection policy"}`))
+		case "DELETE /api/v3.12/devices/d1":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	d := resourceTarget().TestResourceData()
+	for k, v := range map[string]interface{}{
+		"device_name":   "srv1",
+		"host":          "srv1.example.com",
+		"port":          22,
+		"protocol":      "SSH",
+		"domain_name":   "local",
+		"account_login": "root",
+		"password":      "secret",
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("setting %s: %s", k, err)
+		}
+	}
+	diags := resourceTargetCreate(context.Background(), d, c)
+	if !diags.HasError() {
+		t.Fatal("expected error on service creation, got none")
+	}
+	if d.Id() != "" {
+		t.Errorf("expected empty ID after the cleanup, got %q", d.Id())
+	}
+	if !reflect.DeepEqual(deleted, []string{"/api/v3.12/devices/d1"}) {
+		t.Errorf("expected the created device to be deleted, got %v", deleted)
+	}
+}
+
+func TestResourceTargetDeleteOrder(t *testing.T) {
+	var deleted []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	d := resourceTarget().TestResourceData()
+	d.SetId("d1")
+	for k, v := range map[string]interface{}{
+		"device_id":     "d1",
+		"service_id":    "s1",
+		"domain_id":     "l1",
+		"account_id":    "a1",
+		"credential_id": "c1",
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("setting %s: %s", k, err)
+		}
+	}
+	if diags := resourceTargetDelete(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := []string{
+		"/api/v3.12/devices/d1/localdomains/l1/accounts/a1/credentials/c1",
+		"/api/v3.12/devices/d1/localdomains/l1/accounts/a1",
+		"/api/v3.12/devices/d1/localdomains/l1",
+		"/api/v3.12/devices/d1/services/s1",
+		"/api/v3.12/devices/d1",
+	}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected deletions in order %v, got %v", expected, deleted)
+	}
+}
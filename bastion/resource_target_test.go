@@ -0,0 +1,57 @@
+package bastion_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceTarget_basic(t *testing.T) {
+	resourceName := "wallix-bastion_target.testacc_Target"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTargetCreate(22),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "device_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "service_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "domain_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "account_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "credential_id"),
+					resource.TestCheckResourceAttr(resourceName, "service_name", "SSH"),
+					resource.TestCheckResourceAttr(resourceName, "connection_policy", "SSH"),
+					resource.TestCheckResourceAttr(resourceName, "account_name", "root"),
+				),
+			},
+			{
+				Config: testAccResourceTargetCreate(2222),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "port", "2222"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "testacc_Target",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceTargetCreate(port int) string {
+	return `
+resource "wallix-bastion_target" "testacc_Target" {
+  device_name   = "testacc_Target"
+  host          = "testacc_target.device"
+  port          = ` + strconv.Itoa(port) + `
+  account_login = "root"
+  password      = "testacc_Target"
+}
+`
+}
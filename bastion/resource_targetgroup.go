@@ -134,21 +134,9 @@ func resourceTargetGroup() *schema.Resource {
 							Required: true,
 						},
 						"subprotocol": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice(
-								[]string{
-									"SSH_SHELL_SESSION",
-									"SSH_REMOTE_COMMAND",
-									"SSH_SCP_UP",
-									"SSH_SCP_DOWN",
-									"SFTP_SESSION",
-									"RLOGIN",
-									"TELNET",
-									"RDP",
-								},
-								false,
-							),
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(restrictionSubProtocolsValid(), false),
 						},
 					},
 				},
@@ -270,38 +258,38 @@ func resourceTargetGroup() *schema.Resource {
 	}
 }
 
-func resourceTargetGroupVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceTargetGroupVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_targetgroup not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_targetgroup not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceTargetGroupCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceTargetGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTargetGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceTargetGroup(ctx, d.Get("group_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("group_name %s already exists", d.Get("group_name").(string)))
+		return diagFromAPIError(fmt.Errorf("group_name %s already exists", d.Get("group_name").(string)))
 	}
 	err = addTargetGroup(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceTargetGroup(ctx, d.Get("group_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("group_name %s not found after POST", d.Get("group_name").(string)))
+		return diagFromAPIError(fmt.Errorf("group_name %s not found after POST", d.Get("group_name").(string)))
 	}
 	d.SetId(id)
 
@@ -312,12 +300,12 @@ func resourceTargetGroupRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceTargetGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTargetGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readTargetGroupOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -333,11 +321,11 @@ func resourceTargetGroupUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceTargetGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTargetGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateTargetGroup(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -348,11 +336,11 @@ func resourceTargetGroupDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceTargetGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTargetGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteTargetGroup(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -365,7 +353,7 @@ func resourceTargetGroupImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceTargetGroupVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceTargetGroupVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceTargetGroup(ctx, d.Id(), m)
@@ -393,12 +381,12 @@ func searchResourceTargetGroup(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/targetgroups/?q=group_name="+groupName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/targetgroups/?q=group_name="+groupName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonTargetGroup
 	err = json.Unmarshal([]byte(body), &results)
@@ -425,7 +413,7 @@ func addTargetGroup(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -444,7 +432,7 @@ func updateTargetGroup(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -459,7 +447,7 @@ func deleteTargetGroup(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -645,7 +633,7 @@ func readTargetGroupOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -0,0 +1,71 @@
+package bastion
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPrepareTargetGroupJSONSession(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceTargetGroup().Schema, map[string]interface{}{
+		"group_name": "servers",
+		"session_accounts": []interface{}{
+			map[string]interface{}{
+				"account":     "admin",
+				"domain":      "global",
+				"domain_type": "global",
+				"device":      "srv1",
+				"service":     "SSH",
+			},
+		},
+		"session_account_mappings": []interface{}{
+			map[string]interface{}{
+				"device":  "srv2",
+				"service": "SSH",
+			},
+		},
+		"session_interactive_logins": []interface{}{
+			map[string]interface{}{
+				"device":  "srv3",
+				"service": "RDP",
+			},
+		},
+	})
+	jsonData, err := prepareTargetGroupJSON(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body, err := json.Marshal(jsonData)
+	if err != nil {
+		t.Fatalf("marshaling json: %s", err)
+	}
+	var result struct {
+		Session struct {
+			Accounts          []map[string]string `json:"accounts"`
+			AccountMappings   []map[string]string `json:"account_mappings"`
+			InteractiveLogins []map[string]string `json:"interactive_logins"`
+		} `json:"session"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("unmarshaling json: %s", err)
+	}
+	expected := map[string]struct {
+		got     []map[string]string
+		device  string
+		service string
+		account string
+	}{
+		"accounts":           {got: result.Session.Accounts, device: "srv1", service: "SSH", account: "admin"},
+		"account_mappings":   {got: result.Session.AccountMappings, device: "srv2", service: "SSH"},
+		"interactive_logins": {got: result.Session.InteractiveLogins, device: "srv3", service: "RDP"},
+	}
+	for key, v := range expected {
+		if len(v.got) != 1 {
+			t.Fatalf("expected one item in session.%s, got %v", key, v.got)
+		}
+		if v.got[0]["device"] != v.device || v.got[0]["service"] != v.service || v.got[0]["account"] != v.account {
+			t.Errorf("unexpected session.%s: %v", key, v.got[0])
+		}
+	}
+}
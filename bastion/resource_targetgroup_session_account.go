@@ -0,0 +1,387 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	targetGroupSessionTypeAccount          = "account"
+	targetGroupSessionTypeAccountMapping   = "account_mapping"
+	targetGroupSessionTypeInteractiveLogin = "interactive_login"
+
+	// targetGroupSessionAccountMaxAttempts is the number of updates of the group before giving up
+	// when the session of the group is modified concurrently.
+	targetGroupSessionAccountMaxAttempts = 5
+)
+
+func resourceTargetGroupSessionAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTargetGroupSessionAccountCreate,
+		ReadContext:   resourceTargetGroupSessionAccountRead,
+		DeleteContext: resourceTargetGroupSessionAccountDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTargetGroupSessionAccountImport,
+		},
+		CustomizeDiff: resourceTargetGroupSessionAccountCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					targetGroupSessionTypeAccount,
+					targetGroupSessionTypeAccountMapping,
+					targetGroupSessionTypeInteractiveLogin,
+				}, false),
+			},
+			"entry": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"domain_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      domainTypeLocal,
+				ValidateFunc: validation.StringInSlice([]string{domainTypeLocal, domainTypeGlobal}, false),
+			},
+		},
+	}
+}
+
+// resourceTargetGroupSessionAccountCustomizeDiff checks the syntax of entry for the type at plan time,
+// before any call to the API.
+func resourceTargetGroupSessionAccountCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("entry") {
+		return nil
+	}
+	typ := d.Get("type").(string)
+	if _, err := parseTargetGroupSessionEntry(typ, d.Get("entry").(string)); err != nil {
+		return err
+	}
+	if typ != targetGroupSessionTypeAccount && d.Get("domain_type").(string) != domainTypeLocal {
+		return fmt.Errorf("domain_type can only be set with type %s", targetGroupSessionTypeAccount)
+	}
+
+	return nil
+}
+
+func resourceTargetGroupSessionAccountVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_targetgroup_session_account not available with api version %s",
+		c.bastionAPIVersion)
+}
+
+func resourceTargetGroupSessionAccountCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceTargetGroupSessionAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	groupName := d.Get("group_name").(string)
+	typ := d.Get("type").(string)
+	entry := d.Get("entry").(string)
+	jsonEntry, err := parseTargetGroupSessionEntry(typ, entry)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if typ == targetGroupSessionTypeAccount {
+		jsonEntry.DomainType = d.Get("domain_type").(string)
+	}
+	if err := updateTargetGroupSessionEntries(ctx, groupName, typ, jsonEntry, true, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(groupName + "/" + typ + "/" + entry)
+
+	return resourceTargetGroupSessionAccountRead(ctx, d, m)
+}
+
+func resourceTargetGroupSessionAccountRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceTargetGroupSessionAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	typ := d.Get("type").(string)
+	jsonEntry, ex, err := checkTargetGroupSessionEntry(
+		ctx, d.Get("group_name").(string), typ, d.Get("entry").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		d.SetId("")
+
+		return nil
+	}
+	if typ == targetGroupSessionTypeAccount {
+		if tfErr := d.Set("domain_type", jsonEntry.DomainType); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return nil
+}
+
+func resourceTargetGroupSessionAccountDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceTargetGroupSessionAccountVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	typ := d.Get("type").(string)
+	jsonEntry, err := parseTargetGroupSessionEntry(typ, d.Get("entry").(string))
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateTargetGroupSessionEntries(
+		ctx, d.Get("group_name").(string), typ, jsonEntry, false, m,
+	); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceTargetGroupSessionAccountImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceTargetGroupSessionAccountVersionCheck(c); err != nil {
+		return nil, err
+	}
+	idSplit := strings.SplitN(d.Id(), "/", 3)
+	if len(idSplit) != 3 || idSplit[0] == "" || idSplit[1] == "" || idSplit[2] == "" {
+		return nil, errors.New("id must be <group_name>/<type>/<entry>")
+	}
+	if _, err := parseTargetGroupSessionEntry(idSplit[1], idSplit[2]); err != nil {
+		return nil, err
+	}
+	jsonEntry, ex, err := checkTargetGroupSessionEntry(ctx, idSplit[0], idSplit[1], idSplit[2], m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find entry in group_name with id %s (id must be <group_name>/<type>/<entry>)",
+			d.Id())
+	}
+	if tfErr := d.Set("group_name", idSplit[0]); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("type", idSplit[1]); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("entry", idSplit[2]); tfErr != nil {
+		panic(tfErr)
+	}
+	domainType := domainTypeLocal
+	if idSplit[1] == targetGroupSessionTypeAccount {
+		domainType = jsonEntry.DomainType
+	}
+	if tfErr := d.Set("domain_type", domainType); tfErr != nil {
+		panic(tfErr)
+	}
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+// parseTargetGroupSessionEntry parses entry with the syntax of the type:
+// <account>@<domain>@<device>:<service> or <account>@<domain>@<application> for an account,
+// <device>:<service> or <application> for an account mapping or an interactive login.
+func parseTargetGroupSessionEntry(typ, entry string) (jsonTargetGroupSessionAccount, error) {
+	var jsonEntry jsonTargetGroupSessionAccount
+	target := entry
+	switch typ {
+	case targetGroupSessionTypeAccount:
+		entrySplit := strings.Split(entry, "@")
+		if len(entrySplit) != 3 || entrySplit[0] == "" || entrySplit[1] == "" {
+			return jsonEntry, fmt.Errorf("entry %q must be <account>@<domain>@<device>:<service> "+
+				"or <account>@<domain>@<application> with type %s", entry, typ)
+		}
+		jsonEntry.Account = entrySplit[0]
+		jsonEntry.Domain = entrySplit[1]
+		target = entrySplit[2]
+	case targetGroupSessionTypeAccountMapping, targetGroupSessionTypeInteractiveLogin:
+		if strings.Contains(entry, "@") {
+			return jsonEntry, fmt.Errorf("entry %q must be <device>:<service> or <application> with type %s",
+				entry, typ)
+		}
+	default:
+		return jsonEntry, fmt.Errorf("type %q must be %s, %s or %s", typ, targetGroupSessionTypeAccount,
+			targetGroupSessionTypeAccountMapping, targetGroupSessionTypeInteractiveLogin)
+	}
+	if device, service, ok := strings.Cut(target, ":"); ok {
+		if device == "" || service == "" || strings.Contains(service, ":") {
+			return jsonEntry, fmt.Errorf("bad entry %q: target must be <device>:<service> or <application>", entry)
+		}
+		jsonEntry.Device = device
+		jsonEntry.Service = service
+	} else {
+		if strings.TrimSpace(target) == "" {
+			return jsonEntry, fmt.Errorf("bad entry %q: target must be <device>:<service> or <application>", entry)
+		}
+		jsonEntry.Application = target
+	}
+
+	return jsonEntry, nil
+}
+
+// formatTargetGroupSessionEntry returns the entry of a session account, account mapping or interactive login
+// with the syntax of the entry attribute.
+func formatTargetGroupSessionEntry(account, domain, device, service, application string) string {
+	target := application
+	if device != "" {
+		target = device + ":" + service
+	}
+	if account != "" {
+		return account + "@" + domain + "@" + target
+	}
+
+	return target
+}
+
+// targetGroupSessionEntries returns the entries of the type in the session of the group,
+// in the same order as the list of the session.
+func targetGroupSessionEntries(session jsonTargetGroupSession, typ string) []string {
+	var entries []string
+	switch typ {
+	case targetGroupSessionTypeAccount:
+		for _, v := range session.Accounts {
+			entries = append(entries,
+				formatTargetGroupSessionEntry(v.Account, v.Domain, v.Device, v.Service, v.Application))
+		}
+	case targetGroupSessionTypeAccountMapping:
+		for _, v := range session.AccountMappings {
+			entries = append(entries, formatTargetGroupSessionEntry("", "", v.Device, v.Service, v.Application))
+		}
+	case targetGroupSessionTypeInteractiveLogin:
+		for _, v := range session.InteractiveLogins {
+			entries = append(entries, formatTargetGroupSessionEntry("", "", v.Device, v.Service, v.Application))
+		}
+	}
+
+	return entries
+}
+
+// checkTargetGroupSessionEntry returns true if the group exists with the entry in its session,
+// with the session account of the entry when the type is account.
+func checkTargetGroupSessionEntry(
+	ctx context.Context, groupName, typ, entry string, m interface{},
+) (
+	jsonTargetGroupSessionAccount, bool, error,
+) {
+	id, ex, err := searchResourceTargetGroup(ctx, groupName, m)
+	if err != nil || !ex {
+		return jsonTargetGroupSessionAccount{}, false, err
+	}
+	cfg, err := readTargetGroupOptions(ctx, id, m)
+	if err != nil {
+		return jsonTargetGroupSessionAccount{}, false, err
+	}
+	i := slices.Index(targetGroupSessionEntries(cfg.Session, typ), entry)
+	if i == -1 {
+		return jsonTargetGroupSessionAccount{}, false, nil
+	}
+	if typ == targetGroupSessionTypeAccount {
+		return cfg.Session.Accounts[i], true, nil
+	}
+
+	return jsonTargetGroupSessionAccount{}, true, nil
+}
+
+// updateTargetGroupSessionEntries adds (or removes) only jsonEntry in the session of the group with
+// a read-modify-write of the group. The group is read again after each update, so the update is sent again
+// if it has been overwritten by a concurrent modification or rejected with a Conflict.
+func updateTargetGroupSessionEntries( //nolint: gocognit
+	ctx context.Context, groupName, typ string, jsonEntry jsonTargetGroupSessionAccount, add bool, m interface{},
+) error {
+	c := m.(*Client)
+	entry := formatTargetGroupSessionEntry(
+		jsonEntry.Account, jsonEntry.Domain, jsonEntry.Device, jsonEntry.Service, jsonEntry.Application)
+	for attempt := 0; ; attempt++ {
+		id, ex, err := searchResourceTargetGroup(ctx, groupName, m)
+		if err != nil {
+			return err
+		}
+		if !ex {
+			if !add {
+				return nil
+			}
+
+			return fmt.Errorf("group_name %s doesn't exist", groupName)
+		}
+		cfg, err := readTargetGroupOptions(ctx, id, m)
+		if err != nil {
+			return err
+		}
+		i := slices.Index(targetGroupSessionEntries(cfg.Session, typ), entry)
+		if (i != -1) == add {
+			return nil
+		}
+		if attempt == targetGroupSessionAccountMaxAttempts {
+			return fmt.Errorf("session of group_name %s modified concurrently, "+
+				"entry %s not updated after %d attempts", groupName, entry, attempt)
+		}
+		switch {
+		case typ == targetGroupSessionTypeAccount && add:
+			cfg.Session.Accounts = append(cfg.Session.Accounts, jsonEntry)
+		case typ == targetGroupSessionTypeAccount:
+			cfg.Session.Accounts = slices.Delete(cfg.Session.Accounts, i, i+1)
+		case typ == targetGroupSessionTypeAccountMapping && add:
+			cfg.Session.AccountMappings = append(cfg.Session.AccountMappings, jsonTargetGroupSessionAccountMapping{
+				Device:      jsonEntry.Device,
+				Service:     jsonEntry.Service,
+				Application: jsonEntry.Application,
+			})
+		case typ == targetGroupSessionTypeAccountMapping:
+			cfg.Session.AccountMappings = slices.Delete(cfg.Session.AccountMappings, i, i+1)
+		case typ == targetGroupSessionTypeInteractiveLogin && add:
+			cfg.Session.InteractiveLogins = append(cfg.Session.InteractiveLogins,
+				jsonTargetGroupSessionInteractiveLogin{
+					Device:      jsonEntry.Device,
+					Service:     jsonEntry.Service,
+					Application: jsonEntry.Application,
+				})
+		case typ == targetGroupSessionTypeInteractiveLogin:
+			cfg.Session.InteractiveLogins = slices.Delete(cfg.Session.InteractiveLogins, i, i+1)
+		}
+		cfg.ID = ""
+		body, code, err := c.newRequest(ctx, "/targetgroups/"+id+"?force=true", http.MethodPut, cfg)
+		if err != nil {
+			return err
+		}
+		if code == http.StatusConflict {
+			continue
+		}
+		if code != http.StatusOK && code != http.StatusNoContent {
+			return newAPIError("api doesn't return OK or NoContent", code, body)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testTargetGroupSessionHandler returns a handler of the target group tg1 with session,
+// the concurrent function is called on each PUT and returns the status code of the response.
+func testTargetGroupSessionHandler(
+	t *testing.T, session *jsonTargetGroupSession, concurrent func() int,
+) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3.12/targetgroups/":
+			_, _ = w.Write([]byte(`[{"id":"tg1","group_name":"group"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3.12/targetgroups/tg1":
+			_ = json.NewEncoder(w).Encode(jsonTargetGroup{
+				ID: "tg1", GroupName: "group", Description: "shared", Session: *session,
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v3.12/targetgroups/tg1":
+			var jsonData jsonTargetGroup
+			if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+				t.Errorf("decoding request: %s", err)
+			}
+			if jsonData.Description != "shared" {
+				t.Errorf("expected the other attributes of the group to be kept, got %v", jsonData)
+			}
+			code := concurrent()
+			if code == http.StatusNoContent {
+				*session = jsonData.Session
+			}
+			w.WriteHeader(code)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestParseTargetGroupSessionEntry(t *testing.T) {
+	tests := []struct {
+		typ      string
+		entry    string
+		expected jsonTargetGroupSessionAccount
+		errMatch string
+	}{
+		{
+			typ:      targetGroupSessionTypeAccount,
+			entry:    "root@local@srv1:SSH",
+			expected: jsonTargetGroupSessionAccount{Account: "root", Domain: "local", Device: "srv1", Service: "SSH"},
+		},
+		{
+			typ:      targetGroupSessionTypeAccount,
+			entry:    "admin@corp@app1",
+			expected: jsonTargetGroupSessionAccount{Account: "admin", Domain: "corp", Application: "app1"},
+		},
+		{
+			typ:      targetGroupSessionTypeAccountMapping,
+			entry:    "srv1:RDP",
+			expected: jsonTargetGroupSessionAccount{Device: "srv1", Service: "RDP"},
+		},
+		{
+			typ:      targetGroupSessionTypeInteractiveLogin,
+			entry:    "app1",
+			expected: jsonTargetGroupSessionAccount{Application: "app1"},
+		},
+		{typ: targetGroupSessionTypeAccount, entry: "root@srv1:SSH", errMatch: "must be <account>@<domain>@"},
+		{typ: targetGroupSessionTypeAccount, entry: "@local@srv1:SSH", errMatch: "must be <account>@<domain>@"},
+		{typ: targetGroupSessionTypeAccount, entry: "root@local@srv1:", errMatch: "target must be"},
+		{typ: targetGroupSessionTypeAccount, entry: "root@local@", errMatch: "target must be"},
+		{
+			typ:      targetGroupSessionTypeAccountMapping,
+			entry:    "root@local@srv1:SSH",
+			errMatch: "must be <device>:<service>",
+		},
+		{typ: targetGroupSessionTypeInteractiveLogin, entry: ":SSH", errMatch: "target must be"},
+		{typ: "scenario_account", entry: "srv1:SSH", errMatch: "type \"scenario_account\" must be"},
+	}
+	for _, test := range tests {
+		t.Run(test.typ+"/"+test.entry, func(t *testing.T) {
+			jsonEntry, err := parseTargetGroupSessionEntry(test.typ, test.entry)
+			if test.errMatch != "" {
+				if err == nil || !strings.Contains(err.Error(), test.errMatch) {
+					t.Fatalf("expected error matching %q, got %v", test.errMatch, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if jsonEntry != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, jsonEntry)
+			}
+			if entry := formatTargetGroupSessionEntry(jsonEntry.Account, jsonEntry.Domain,
+				jsonEntry.Device, jsonEntry.Service, jsonEntry.Application); entry != test.entry {
+				t.Errorf("expected the entry to be formatted as %q, got %q", test.entry, entry)
+			}
+		})
+	}
+}
+
+func TestResourceTargetGroupSessionAccountCustomizeDiff(t *testing.T) {
+	tests := map[string]struct {
+		cfg      map[string]interface{}
+		errMatch string
+	}{
+		"account": {
+			cfg: map[string]interface{}{"type": "account", "entry": "root@corp@srv1:SSH", "domain_type": "global"},
+		},
+		"bad entry": {
+			cfg:      map[string]interface{}{"type": "account", "entry": "srv1:SSH"},
+			errMatch: "must be <account>@<domain>@",
+		},
+		"domain_type with mapping": {
+			cfg:      map[string]interface{}{"type": "account_mapping", "entry": "srv1:SSH", "domain_type": "global"},
+			errMatch: "domain_type can only be set with type account",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.cfg["group_name"] = "group"
+			_, err := resourceTargetGroupSessionAccount().Diff(
+				context.Background(), nil, terraform.NewResourceConfigRaw(test.cfg), nil)
+			if test.errMatch == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.errMatch) {
+				t.Fatalf("expected error matching %q, got %v", test.errMatch, err)
+			}
+		})
+	}
+}
+
+func TestUpdateTargetGroupSessionEntries(t *testing.T) {
+	t.Run("add account with conflict", func(t *testing.T) {
+		session := jsonTargetGroupSession{
+			Accounts: []jsonTargetGroupSessionAccount{
+				{Account: "root", Domain: "local", DomainType: "local", Device: "srv1", Service: "SSH"},
+			},
+		}
+		puts := 0
+		c := newTestClient(t, testTargetGroupSessionHandler(t, &session, func() int {
+			puts++
+			if puts == 1 {
+				// entry added by another workspace before the update
+				session.AccountMappings = append(session.AccountMappings,
+					jsonTargetGroupSessionAccountMapping{Device: "srv2", Service: "RDP"})
+
+				return http.StatusConflict
+			}
+
+			return http.StatusNoContent
+		}))
+		jsonEntry := jsonTargetGroupSessionAccount{
+			Account: "admin", Domain: "corp", DomainType: "global", Device: "srv1", Service: "SSH",
+		}
+		if err := updateTargetGroupSessionEntries(
+			context.Background(), "group", targetGroupSessionTypeAccount, jsonEntry, true, c,
+		); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !slices.Equal(targetGroupSessionEntries(session, targetGroupSessionTypeAccount),
+			[]string{"root@local@srv1:SSH", "admin@corp@srv1:SSH"}) ||
+			len(session.AccountMappings) != 1 {
+			t.Errorf("expected only the account to be added, got %v", session)
+		}
+		if session.Accounts[1].DomainType != "global" {
+			t.Errorf("expected domain_type global, got %v", session.Accounts[1])
+		}
+	})
+	t.Run("add overwritten", func(t *testing.T) {
+		session := jsonTargetGroupSession{}
+		puts := 0
+		c := newTestClient(t, testTargetGroupSessionHandler(t, &session, func() int {
+			puts++
+			if puts == 1 {
+				// update accepted but overwritten by a concurrent update
+				return http.StatusOK
+			}
+
+			return http.StatusNoContent
+		}))
+		jsonEntry := jsonTargetGroupSessionAccount{Application: "app1"}
+		if err := updateTargetGroupSessionEntries(
+			context.Background(), "group", targetGroupSessionTypeInteractiveLogin, jsonEntry, true, c,
+		); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if puts != 2 || !slices.Equal(targetGroupSessionEntries(session, targetGroupSessionTypeInteractiveLogin),
+			[]string{"app1"}) {
+			t.Errorf("expected the interactive login to be added again, got %v after %d updates", session, puts)
+		}
+	})
+	t.Run("remove mapping", func(t *testing.T) {
+		session := jsonTargetGroupSession{
+			AccountMappings: []jsonTargetGroupSessionAccountMapping{
+				{Device: "srv1", Service: "SSH"}, {Device: "srv2", Service: "RDP"}, {Application: "app1"},
+			},
+		}
+		c := newTestClient(t, testTargetGroupSessionHandler(t, &session, func() int {
+			return http.StatusNoContent
+		}))
+		jsonEntry := jsonTargetGroupSessionAccount{Device: "srv2", Service: "RDP"}
+		if err := updateTargetGroupSessionEntries(
+			context.Background(), "group", targetGroupSessionTypeAccountMapping, jsonEntry, false, c,
+		); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !slices.Equal(targetGroupSessionEntries(session, targetGroupSessionTypeAccountMapping),
+			[]string{"srv1:SSH", "app1"}) {
+			t.Errorf("expected only srv2:RDP to be removed, got %v", session)
+		}
+	})
+	t.Run("too many conflicts", func(t *testing.T) {
+		session := jsonTargetGroupSession{}
+		c := newTestClient(t, testTargetGroupSessionHandler(t, &session, func() int {
+			return http.StatusConflict
+		}))
+		jsonEntry := jsonTargetGroupSessionAccount{Device: "srv1", Service: "SSH"}
+		err := updateTargetGroupSessionEntries(
+			context.Background(), "group", targetGroupSessionTypeAccountMapping, jsonEntry, true, c)
+		if err == nil || !strings.Contains(err.Error(), "not updated after 5 attempts") {
+			t.Fatalf("expected an error after the attempts, got %v", err)
+		}
+	})
+}
+
+func TestResourceTargetGroupSessionAccountImportID(t *testing.T) {
+	session := jsonTargetGroupSession{
+		Accounts: []jsonTargetGroupSessionAccount{
+			{Account: "admin", Domain: "corp", DomainType: "global", Device: "srv1", Service: "SSH"},
+		},
+	}
+	c := newTestClient(t, testTargetGroupSessionHandler(t, &session, func() int {
+		return http.StatusNoContent
+	}))
+	tests := map[string]string{
+		"group/account/admin@corp@srv1:SSH":          "",
+		"group/account":                              "id must be <group_name>/<type>/<entry>",
+		"group//admin@corp@srv1:SSH":                 "id must be <group_name>/<type>/<entry>",
+		"group/account/srv1:SSH":                     "must be <account>@<domain>@",
+		"group/account/root@local@srv1:SSH":          "don't find entry in group_name with id group/account/root",
+		"group/interactive_login/srv1:SSH":           "don't find entry in group_name",
+		"group/scenario_account/admin@corp@srv1:SSH": "type \"scenario_account\" must be",
+	}
+	for id, errMatch := range tests {
+		t.Run(id, func(t *testing.T) {
+			d := resourceTargetGroupSessionAccount().TestResourceData()
+			d.SetId(id)
+			_, err := resourceTargetGroupSessionAccountImport(d, c)
+			if errMatch == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if d.Get("group_name").(string) != "group" || d.Get("type").(string) != "account" ||
+					d.Get("entry").(string) != "admin@corp@srv1:SSH" || d.Get("domain_type").(string) != "global" {
+					t.Errorf("unexpected attributes after import: %v", d.State())
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), errMatch) {
+				t.Fatalf("expected error matching %q, got %v", errMatch, err)
+			}
+		})
+	}
+}
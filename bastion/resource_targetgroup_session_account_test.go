@@ -0,0 +1,122 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceTargetGroupSessionAccount_basic(t *testing.T) {
+	resourceName := "wallix-bastion_targetgroup_session_account.testacc_TargetgroupSessionAccount"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTargetGroupSessionAccountCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id",
+						"testacc_TargetgroupSessionAccount/account/"+
+							"testacc_TargetgroupSessionAccount@testacc_TargetgroupSessionAccount@"+
+							"testacc_TargetgroupSessionAccount:testacc_TargetgroupSessionAccount"),
+					resource.TestCheckResourceAttr(resourceName, "domain_type", "global"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_targetgroup_session_account.testacc_TargetgroupSessionAccount_login",
+						"id", "testacc_TargetgroupSessionAccount/interactive_login/"+
+							"testacc_TargetgroupSessionAccount:testacc_TargetgroupSessionAccount"),
+				),
+			},
+			{
+				// refresh the group to read the entries added by the incremental resources
+				Config: testAccResourceTargetGroupSessionAccountCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_targetgroup.testacc_TargetgroupSessionAccount", "session_accounts.#", "1"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_targetgroup.testacc_TargetgroupSessionAccount",
+						"session_interactive_logins.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "testacc_TargetgroupSessionAccount/account/testacc_TargetgroupSessionAccount",
+				ExpectError:   regexp.MustCompile(`must be <account>@<domain>@<device>:<service>`),
+			},
+			{
+				Config:      testAccResourceTargetGroupSessionAccountBadEntry(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be <device>:<service> or <application>`),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceTargetGroupSessionAccountCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_TargetgroupSessionAccount" {
+  device_name = "testacc_TargetgroupSessionAccount"
+  host        = "testacc_TargetgroupSessionAccount.device"
+}
+resource "wallix-bastion_device_service" "testacc_TargetgroupSessionAccount" {
+  device_id         = wallix-bastion_device.testacc_TargetgroupSessionAccount.id
+  service_name      = "testacc_TargetgroupSessionAccount"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  subprotocols      = ["SSH_SHELL_SESSION"]
+  global_domains    = [wallix-bastion_domain.testacc_TargetgroupSessionAccount.domain_name]
+}
+resource "wallix-bastion_domain" "testacc_TargetgroupSessionAccount" {
+  domain_name = "testacc_TargetgroupSessionAccount"
+}
+resource "wallix-bastion_domain_account" "testacc_TargetgroupSessionAccount" {
+  domain_id     = wallix-bastion_domain.testacc_TargetgroupSessionAccount.id
+  account_name  = "testacc_TargetgroupSessionAccount"
+  account_login = "admin"
+}
+resource "wallix-bastion_targetgroup" "testacc_TargetgroupSessionAccount" {
+  group_name = "testacc_TargetgroupSessionAccount"
+
+  lifecycle {
+    ignore_changes = [session_accounts, session_interactive_logins]
+  }
+}
+resource "wallix-bastion_targetgroup_session_account" "testacc_TargetgroupSessionAccount" {
+  group_name  = wallix-bastion_targetgroup.testacc_TargetgroupSessionAccount.group_name
+  type        = "account"
+  entry = format("%s@%s@%s:%s",
+    wallix-bastion_domain_account.testacc_TargetgroupSessionAccount.account_name,
+    wallix-bastion_domain.testacc_TargetgroupSessionAccount.domain_name,
+    wallix-bastion_device.testacc_TargetgroupSessionAccount.device_name,
+    wallix-bastion_device_service.testacc_TargetgroupSessionAccount.service_name,
+  )
+  domain_type = "global"
+}
+resource "wallix-bastion_targetgroup_session_account" "testacc_TargetgroupSessionAccount_login" {
+  group_name = wallix-bastion_targetgroup.testacc_TargetgroupSessionAccount.group_name
+  type       = "interactive_login"
+  entry = format("%s:%s",
+    wallix-bastion_device.testacc_TargetgroupSessionAccount.device_name,
+    wallix-bastion_device_service.testacc_TargetgroupSessionAccount.service_name,
+  )
+}
+`
+}
+
+func testAccResourceTargetGroupSessionAccountBadEntry() string {
+	return `
+resource "wallix-bastion_targetgroup_session_account" "testacc_TargetgroupSessionAccount_bad" {
+  group_name = "testacc_TargetgroupSessionAccount"
+  type       = "account_mapping"
+  entry      = "admin@local@testacc_TargetgroupSessionAccount:SSH"
+}
+`
+}
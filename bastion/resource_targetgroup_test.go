@@ -21,6 +21,17 @@ func TestAccResourceTargetgroup_basic(t *testing.T) {
 			},
 			{
 				Config: testAccResourceTargetgroupUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_targetgroup.testacc_Targetgroup",
+						"session_accounts.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"wallix-bastion_targetgroup.testacc_Targetgroup",
+						"session_interactive_logins.*", map[string]string{
+							"device":  "testacc_Targetgroup",
+							"service": "testacc_Targetgroup",
+						}),
+				),
 			},
 			{
 				ResourceName:  "wallix-bastion_targetgroup.testacc_Targetgroup",
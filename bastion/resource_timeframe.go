@@ -91,38 +91,38 @@ func resourceTimeframe() *schema.Resource {
 	}
 }
 
-func resourceTimeframeVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceTimeframeVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_timeframe not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_timeframe not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceTimeframeCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceTimeframeVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTimeframeVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	ex, err := checkResourceTimeframeExits(ctx, d.Get("timeframe_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("timeframe_name %s already exists", d.Get("timeframe_name").(string)))
+		return diagFromAPIError(fmt.Errorf("timeframe_name %s already exists", d.Get("timeframe_name").(string)))
 	}
 	err = addTimeframe(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	ex, err = checkResourceTimeframeExits(ctx, d.Get("timeframe_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("timeframe_name %s not found after POST", d.Get("timeframe_name").(string)))
+		return diagFromAPIError(fmt.Errorf("timeframe_name %s not found after POST", d.Get("timeframe_name").(string)))
 	}
 	d.SetId(d.Get("timeframe_name").(string))
 
@@ -133,12 +133,12 @@ func resourceTimeframeRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceTimeframeVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTimeframeVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readTimeframeOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.TimeframeName == "" {
 		d.SetId("")
@@ -154,11 +154,11 @@ func resourceTimeframeUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceTimeframeVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTimeframeVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateTimeframe(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -169,11 +169,11 @@ func resourceTimeframeDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceTimeframeVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceTimeframeVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteTimeframe(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -186,7 +186,7 @@ func resourceTimeframeImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceTimeframeVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceTimeframeVersionCheck(c); err != nil {
 		return nil, err
 	}
 	ex, err := checkResourceTimeframeExits(ctx, d.Id(), m)
@@ -222,7 +222,7 @@ func checkResourceTimeframeExits(
 		return false, nil
 	}
 	if code != http.StatusOK {
-		return false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return false, newAPIError("api doesn't return OK", code, body)
 	}
 
 	return true, nil
@@ -241,7 +241,7 @@ func addTimeframe(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -260,7 +260,7 @@ func updateTimeframe(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -275,7 +275,7 @@ func deleteTimeframe(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -335,7 +335,7 @@ func readTimeframeOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"slices"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -27,6 +28,9 @@ type jsonUser struct {
 	SSHPublicKey      string    `json:"ssh_public_key"`
 	UserAuths         []string  `json:"user_auths"`
 	Groups            *[]string `json:"groups,omitempty"`
+
+	IsServiceAccount        *bool `json:"is_service_account,omitempty"`
+	TwoFactorAuthentication *bool `json:"two_factor_authentication,omitempty"`
 }
 
 func resourceUser() *schema.Resource {
@@ -38,6 +42,9 @@ func resourceUser() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceUserImport,
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateUserTwoFactorAuthentication,
+		},
 		Schema: map[string]*schema.Schema{
 			"user_name": {
 				Type:     schema.TypeString,
@@ -70,8 +77,18 @@ func resourceUser() *schema.Resource {
 				Optional: true,
 			},
 			"force_change_pwd": {
-				Type:     schema.TypeBool,
-				Optional: true,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"force_change_password"},
+				Deprecated: "Use force_change_password instead," +
+					" the attribute will be removed in the next major version of the provider.",
+			},
+			"force_change_password": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"force_change_pwd"},
+				DiffSuppressFunc: suppressUserForceChangePasswordDiff,
 			},
 			"groups": {
 				Type:     schema.TypeSet,
@@ -87,6 +104,11 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"is_service_account": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
 			"password": {
 				Type:      schema.TypeString,
 				Optional:  true,
@@ -102,35 +124,63 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"two_factor_authentication": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceUserVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+// validateUserTwoFactorAuthentication warns at plan time when the MFA is required on a service account,
+// the service accounts often can't complete the TOTP challenges.
+func validateUserTwoFactorAuthentication(
+	_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse,
+) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+	twoFactorAuthentication := req.RawConfig.GetAttr("two_factor_authentication")
+	isServiceAccount := req.RawConfig.GetAttr("is_service_account")
+	if !twoFactorAuthentication.IsKnown() || twoFactorAuthentication.IsNull() || twoFactorAuthentication.False() ||
+		!isServiceAccount.IsKnown() || isServiceAccount.IsNull() || isServiceAccount.False() {
+		return
+	}
+	resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Two factor authentication enabled on a service account",
+		Detail: "The service accounts often can't complete the TOTP challenges, " +
+			"two_factor_authentication = true may prevent the user from connecting to the Bastion.",
+		AttributePath: cty.GetAttrPath("two_factor_authentication"),
+	})
+}
+
+func resourceUserVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_user not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_user not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceUserCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceUserVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	ex, err := checkResourceUserExists(ctx, d.Get("user_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("user_name %s already exists", d.Get("user_name").(string)))
+		return diagFromAPIError(fmt.Errorf("user_name %s already exists", d.Get("user_name").(string)))
 	}
 	err = addUser(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.SetId(d.Get("user_name").(string))
 
@@ -141,12 +191,12 @@ func resourceUserRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceUserVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readUserOptions(ctx, d.Get("user_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.UserName == "" {
 		d.SetId("")
@@ -162,11 +212,16 @@ func resourceUserUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceUserVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateUser(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
+	}
+	if d.HasChange("groups") {
+		if err := updateUserGroupsDelta(ctx, d, m); err != nil {
+			return diagFromAPIError(err)
+		}
 	}
 	d.Partial(false)
 
@@ -177,11 +232,11 @@ func resourceUserDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceUserVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteUser(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -194,7 +249,7 @@ func resourceUserImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceUserVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceUserVersionCheck(c); err != nil {
 		return nil, err
 	}
 	ex, err := checkResourceUserExists(ctx, d.Id(), m)
@@ -229,7 +284,7 @@ func checkResourceUserExists(
 		return false, nil
 	}
 	if code != http.StatusOK {
-		return false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return false, newAPIError("api doesn't return OK", code, body)
 	}
 
 	return true, nil
@@ -245,7 +300,7 @@ func addUser(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -261,7 +316,7 @@ func updateUser(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -276,12 +331,54 @@ func deleteUser(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
 }
 
+// updateUserGroupsDelta adds the user only to the groups added to groups and removes it only from the groups
+// removed from groups, the memberships of the other groups aren't sent so they can't be overwritten.
+func updateUserGroupsDelta(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	userName := d.Get("user_name").(string)
+	oldGroups, newGroups := d.GetChange("groups")
+	addGroups := newGroups.(*schema.Set).Difference(oldGroups.(*schema.Set)).List()
+	removeGroups := oldGroups.(*schema.Set).Difference(newGroups.(*schema.Set)).List()
+	for _, v := range addGroups {
+		if err := updateUserGroupUsers(ctx, v.(string), userName, true, m); err != nil {
+			return err
+		}
+	}
+	for _, v := range removeGroups {
+		if err := updateUserGroupUsers(ctx, v.(string), userName, false, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// suppressUserForceChangePasswordDiff suppresses the diff of force_change_password when the API has reset the flag
+// after the first login of an existing user, a change of password with the flag forces a new password change.
+func suppressUserForceChangePasswordDiff(_, oldValue, newValue string, d *schema.ResourceData) bool {
+	return d.Id() != "" && oldValue == "false" && newValue == "true" && !d.HasChange("password")
+}
+
+// userForceChangePasswordConfig returns true if the configuration forces the password change on the next login,
+// with force_change_password or the deprecated force_change_pwd, the value read from the Bastion is ignored.
+func userForceChangePasswordConfig(d *schema.ResourceData) bool {
+	if d.Get("force_change_pwd").(bool) {
+		return true
+	}
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return false
+	}
+	v := rawConfig.GetAttr("force_change_password")
+
+	return v.IsKnown() && !v.IsNull() && v.True()
+}
+
 func prepareUserJSON(d *schema.ResourceData, newResource bool) jsonUser {
 	b := true
 	jsonData := jsonUser{
@@ -299,16 +396,28 @@ func prepareUserJSON(d *schema.ResourceData, newResource bool) jsonUser {
 	if newResource {
 		jsonData.PreferredLanguage = d.Get("preferred_language").(string)
 		jsonData.Password = d.Get("password").(string)
-		if d.Get("force_change_pwd").(bool) {
+		if d.Get("force_change_password").(bool) || d.Get("force_change_pwd").(bool) {
 			jsonData.ForceChangePwd = &b
 		}
-	} else if d.HasChange("password") && !d.Get("force_change_pwd").(bool) {
-		if v := d.Get("password").(string); v != "" {
-			jsonData.Password = v
+	} else {
+		forceChangePassword := false
+		if d.HasChange("force_change_password") {
+			forceChangePassword = d.Get("force_change_password").(bool)
+			jsonData.ForceChangePwd = &forceChangePassword
+		}
+		// the password of a user forced to change it is only sent with a new forced change
+		if d.HasChange("password") && (forceChangePassword || !userForceChangePasswordConfig(d)) {
+			if v := d.Get("password").(string); v != "" {
+				jsonData.Password = v
+			}
 		}
 	}
 
-	if d.HasChanges("groups") {
+	jsonData.IsServiceAccount = userBoolOption(d, "is_service_account", newResource)
+	jsonData.TwoFactorAuthentication = userBoolOption(d, "two_factor_authentication", newResource)
+
+	// after the creation, the groups are updated with updateUserGroupsDelta
+	if newResource && d.HasChange("groups") {
 		listGroups := d.Get("groups").(*schema.Set).List()
 		groups := make([]string, len(listGroups))
 		for i, v := range listGroups {
@@ -326,6 +435,26 @@ func prepareUserJSON(d *schema.ResourceData, newResource bool) jsonUser {
 	return jsonData
 }
 
+// userBoolOption returns the value of the optional boolean key to send,
+// or nil to leave the value of the Bastion unchanged.
+func userBoolOption(d *schema.ResourceData, key string, newResource bool) *bool {
+	if newResource {
+		if v, ok := d.GetOk(key); ok {
+			b := v.(bool)
+
+			return &b
+		}
+
+		return nil
+	}
+	if !d.HasChange(key) {
+		return nil
+	}
+	b := d.Get(key).(bool)
+
+	return &b
+}
+
 func readUserOptions(
 	ctx context.Context, userName string, m interface{},
 ) (
@@ -341,7 +470,7 @@ func readUserOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 
 	err = json.Unmarshal([]byte(body), &result)
@@ -374,6 +503,11 @@ func fillUser(d *schema.ResourceData, jsonData jsonUser) {
 	if tfErr := d.Set("expiration_date", jsonData.ExpirationDate); tfErr != nil {
 		panic(tfErr)
 	}
+	if jsonData.ForceChangePwd != nil {
+		if tfErr := d.Set("force_change_password", *jsonData.ForceChangePwd); tfErr != nil {
+			panic(tfErr)
+		}
+	}
 	if tfErr := d.Set("groups", jsonData.Groups); tfErr != nil {
 		panic(tfErr)
 	}
@@ -383,10 +517,20 @@ func fillUser(d *schema.ResourceData, jsonData jsonUser) {
 	if tfErr := d.Set("is_disabled", jsonData.IsDisabled); tfErr != nil {
 		panic(tfErr)
 	}
+	if jsonData.IsServiceAccount != nil {
+		if tfErr := d.Set("is_service_account", *jsonData.IsServiceAccount); tfErr != nil {
+			panic(tfErr)
+		}
+	}
 	if tfErr := d.Set("preferred_language", jsonData.PreferredLanguage); tfErr != nil {
 		panic(tfErr)
 	}
 	if tfErr := d.Set("ssh_public_key", jsonData.SSHPublicKey); tfErr != nil {
 		panic(tfErr)
 	}
+	if jsonData.TwoFactorAuthentication != nil {
+		if tfErr := d.Set("two_factor_authentication", *jsonData.TwoFactorAuthentication); tfErr != nil {
+			panic(tfErr)
+		}
+	}
 }
@@ -0,0 +1,362 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUpdateUserGroupsDelta(t *testing.T) {
+	groups := map[string][]string{
+		"g1": {"alice", "bob"},
+		"g2": {"alice", "carol"},
+		"g3": {"carol"},
+	}
+	puts := make([]string, 0)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/api/v3.12/usergroups/")
+		switch {
+		case r.Method == http.MethodGet && id == "":
+			groupName := strings.TrimPrefix(r.URL.Query().Get("q"), "group_name=")
+			if _, ok := groups[groupName]; !ok {
+				_, _ = w.Write([]byte(`[]`))
+
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]jsonUserGroup{{ID: groupName, GroupName: groupName}})
+		case r.Method == http.MethodGet:
+			users := slices.Clone(groups[id])
+			_ = json.NewEncoder(w).Encode(jsonUserGroup{ID: id, GroupName: id, Users: &users})
+		case r.Method == http.MethodPut:
+			var jsonData jsonUserGroup
+			if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+				t.Errorf("decoding request: %s", err)
+			}
+			groups[id] = *jsonData.Users
+			puts = append(puts, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d := testResourceUserGroupsChange(t, []string{"g1", "g2"}, []string{"g2", "g3"})
+	if err := updateUserGroupsDelta(context.Background(), d, c); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	slices.Sort(puts)
+	if !slices.Equal(puts, []string{"g1", "g3"}) {
+		t.Errorf("expected only the groups of the delta to be updated, got %v", puts)
+	}
+	expected := map[string][]string{
+		"g1": {"bob"},
+		"g2": {"alice", "carol"},
+		"g3": {"carol", "alice"},
+	}
+	if !maps.EqualFunc(groups, expected, slices.Equal) {
+		t.Errorf("expected groups %v, got %v", expected, groups)
+	}
+}
+
+func TestPrepareUserJSONGroups(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"user_name":  "alice",
+		"email":      "alice@none.none",
+		"profile":    "user",
+		"user_auths": []interface{}{"local_password"},
+		"groups":     []interface{}{"g1"},
+	})
+	if jsonData := prepareUserJSON(d, true); jsonData.Groups == nil || !slices.Equal(*jsonData.Groups, []string{"g1"}) {
+		t.Errorf("expected the groups to be sent on creation, got %v", jsonData.Groups)
+	}
+	d = testResourceUserGroupsChange(t, []string{"g1"}, []string{"g1", "g2"})
+	if jsonData := prepareUserJSON(d, false); jsonData.Groups != nil {
+		t.Errorf("expected the groups not to be sent on update, got %v", *jsonData.Groups)
+	}
+}
+
+// testResourceUserGroupsChange returns the data of a user alice during the update of groups
+// from oldGroups to newGroups.
+func testResourceUserGroupsChange(t *testing.T, oldGroups, newGroups []string) *schema.ResourceData {
+	t.Helper()
+	r := resourceUser()
+	cfg := map[string]interface{}{
+		"user_name":  "alice",
+		"email":      "alice@none.none",
+		"profile":    "user",
+		"user_auths": []interface{}{"local_password"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	d.SetId("alice")
+	if tfErr := d.Set("groups", oldGroups); tfErr != nil {
+		t.Fatal(tfErr)
+	}
+	state := d.State()
+	groups := make([]interface{}, len(newGroups))
+	for i, v := range newGroups {
+		groups[i] = v
+	}
+	cfg["groups"] = groups
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+func TestPrepareUserJSONForceChangePassword(t *testing.T) {
+	for _, attr := range []string{"force_change_password", "force_change_pwd"} {
+		t.Run(attr, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+				"user_name":  "alice",
+				"email":      "alice@none.none",
+				"profile":    "user",
+				"user_auths": []interface{}{"local_password"},
+				"password":   "secret",
+				attr:         true,
+			})
+			jsonData := prepareUserJSON(d, true)
+			if jsonData.ForceChangePwd == nil || !*jsonData.ForceChangePwd {
+				t.Errorf("expected force_change_pwd to be sent on creation, got %v", jsonData.ForceChangePwd)
+			}
+		})
+	}
+}
+
+func TestFillUserForceChangePassword(t *testing.T) {
+	d := resourceUser().TestResourceData()
+	forceChangePwd := false
+	fillUser(d, jsonUser{UserName: "alice", ForceChangePwd: &forceChangePwd})
+	if v, ok := d.GetOk("force_change_password"); ok || v.(bool) {
+		t.Errorf("expected force_change_password false after the first login, got %v", v)
+	}
+	forceChangePwd = true
+	fillUser(d, jsonUser{UserName: "alice", ForceChangePwd: &forceChangePwd})
+	if !d.Get("force_change_password").(bool) {
+		t.Error("expected force_change_password true before the first login")
+	}
+	// the diff of the flag reset by the API isn't shown on an existing user
+	d.SetId("alice")
+	if !suppressUserForceChangePasswordDiff("force_change_password", "false", "true", d) {
+		t.Error("expected the diff to be suppressed on an existing user")
+	}
+}
+
+func TestResourceUserForceChangePasswordUpdate(t *testing.T) {
+	tests := map[string]struct {
+		stateForce  bool
+		configForce cty.Value
+		password    string
+		expectDiff  bool
+		expectSent  bool
+		expectForce bool
+	}{
+		"reset after the first login": {
+			configForce: cty.True,
+			password:    "secret",
+		},
+		"new forced change": {
+			configForce: cty.True,
+			password:    "new-secret",
+			expectDiff:  true,
+			expectSent:  true,
+			expectForce: true,
+		},
+		"forced change removed": {
+			stateForce:  true,
+			configForce: cty.False,
+			password:    "secret",
+			expectDiff:  true,
+			expectSent:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := testResourceUserUpdate(t, tt.stateForce, map[string]cty.Value{
+				"password":              cty.StringVal(tt.password),
+				"force_change_password": tt.configForce,
+			})
+			if d.HasChange("force_change_password") != tt.expectDiff {
+				t.Errorf("expected diff of force_change_password %t, got %t",
+					tt.expectDiff, d.HasChange("force_change_password"))
+			}
+			jsonData := prepareUserJSON(d, false)
+			switch {
+			case !tt.expectSent && jsonData.ForceChangePwd != nil:
+				t.Errorf("expected force_change_pwd not to be sent, got %t", *jsonData.ForceChangePwd)
+			case tt.expectSent && (jsonData.ForceChangePwd == nil || *jsonData.ForceChangePwd != tt.expectForce):
+				t.Errorf("expected force_change_pwd %t to be sent, got %v", tt.expectForce, jsonData.ForceChangePwd)
+			}
+			if tt.expectForce && jsonData.Password != tt.password {
+				t.Errorf("expected the new password to be sent with the forced change, got %q", jsonData.Password)
+			}
+		})
+	}
+}
+
+func TestPrepareUserJSONPasswordUpdate(t *testing.T) {
+	tests := map[string]struct {
+		config     map[string]cty.Value
+		expectSent bool
+	}{
+		"not forced in the configuration": {
+			config: map[string]cty.Value{
+				"password": cty.StringVal("new-secret"),
+			},
+			expectSent: true,
+		},
+		"forced in the configuration": {
+			config: map[string]cty.Value{
+				"password":              cty.StringVal("new-secret"),
+				"force_change_password": cty.True,
+			},
+		},
+		"forced with force_change_pwd": {
+			config: map[string]cty.Value{
+				"password":         cty.StringVal("new-secret"),
+				"force_change_pwd": cty.True,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// the user has not logged in yet, the Bastion still reports the forced change
+			d := testResourceUserUpdate(t, true, tt.config)
+			jsonData := prepareUserJSON(d, false)
+			if sent := jsonData.Password != ""; sent != tt.expectSent {
+				t.Errorf("expected password sent %t, got %q", tt.expectSent, jsonData.Password)
+			}
+		})
+	}
+}
+
+// testResourceUserUpdate returns the data of a user alice with the password secret during an update
+// to the config, forceChangePassword is the value of force_change_password read from the Bastion.
+func testResourceUserUpdate(t *testing.T, forceChangePassword bool, config map[string]cty.Value) *schema.ResourceData {
+	t.Helper()
+	r := resourceUser()
+	coreSchema := r.CoreConfigSchema()
+	attrs := make(map[string]cty.Value)
+	for name, attrType := range coreSchema.ImpliedType().AttributeTypes() {
+		attrs[name] = cty.NullVal(attrType)
+	}
+	attrs["user_name"] = cty.StringVal("alice")
+	attrs["email"] = cty.StringVal("alice@none.none")
+	attrs["profile"] = cty.StringVal("user")
+	attrs["user_auths"] = cty.SetVal([]cty.Value{cty.StringVal("local_password")})
+	maps.Copy(attrs, config)
+	rawConfig := cty.ObjectVal(attrs)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"user_name":  "alice",
+		"email":      "alice@none.none",
+		"profile":    "user",
+		"user_auths": []interface{}{"local_password"},
+		"password":   "secret",
+	})
+	d.SetId("alice")
+	if tfErr := d.Set("force_change_password", forceChangePassword); tfErr != nil {
+		t.Fatal(tfErr)
+	}
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(rawConfig, coreSchema), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil {
+		diff = &terraform.InstanceDiff{}
+	}
+	// the raw configuration is set in the diff by the gRPC server of the SDK
+	diff.RawConfig = rawConfig
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+func TestValidateUserTwoFactorAuthentication(t *testing.T) {
+	coreSchema := resourceUser().CoreConfigSchema()
+	config := func(twoFactorAuthentication, isServiceAccount cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for name, attrType := range coreSchema.ImpliedType().AttributeTypes() {
+			attrs[name] = cty.NullVal(attrType)
+		}
+		attrs["user_name"] = cty.StringVal("svc")
+		attrs["two_factor_authentication"] = twoFactorAuthentication
+		attrs["is_service_account"] = isServiceAccount
+
+		return cty.ObjectVal(attrs)
+	}
+	tests := map[string]struct {
+		twoFactorAuthentication cty.Value
+		isServiceAccount        cty.Value
+		expectWarning           bool
+	}{
+		"service account with mfa": {
+			twoFactorAuthentication: cty.True,
+			isServiceAccount:        cty.True,
+			expectWarning:           true,
+		},
+		"user with mfa": {
+			twoFactorAuthentication: cty.True,
+			isServiceAccount:        cty.NullVal(cty.Bool),
+		},
+		"service account without mfa": {
+			twoFactorAuthentication: cty.False,
+			isServiceAccount:        cty.True,
+		},
+		"unknown service account": {
+			twoFactorAuthentication: cty.True,
+			isServiceAccount:        cty.UnknownVal(cty.Bool),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateUserTwoFactorAuthentication(context.Background(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: config(test.twoFactorAuthentication, test.isServiceAccount),
+			}, resp)
+			if test.expectWarning && (len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != diag.Warning) {
+				t.Errorf("expected a warning, got %v", resp.Diagnostics)
+			}
+			if !test.expectWarning && len(resp.Diagnostics) != 0 {
+				t.Errorf("unexpected diagnostics %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPrepareUserJSONTwoFactorAuthentication(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"user_name":                 "alice",
+		"email":                     "alice@none.none",
+		"profile":                   "user",
+		"user_auths":                []interface{}{"local_password"},
+		"two_factor_authentication": true,
+	})
+	jsonData := prepareUserJSON(d, true)
+	if jsonData.TwoFactorAuthentication == nil || !*jsonData.TwoFactorAuthentication {
+		t.Errorf("expected two_factor_authentication to be sent on creation, got %v",
+			jsonData.TwoFactorAuthentication)
+	}
+	if jsonData.IsServiceAccount != nil {
+		t.Errorf("expected is_service_account not to be sent when unset, got %v", *jsonData.IsServiceAccount)
+	}
+}
@@ -25,10 +25,22 @@ func TestAccResourceUser_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(
 						"wallix-bastion_user.testacc_User",
 						"id"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_user.testacc_User",
+						"force_change_password", "true"),
 				),
 			},
 			{
+				// force_change_password isn't set anymore, the value read from the Bastion is kept
 				Config: testAccResourceUserUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_user.testacc_User",
+						"force_change_password", "true"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_user.testacc_User",
+						"two_factor_authentication", "true"),
+				),
 			},
 			{
 				ResourceName:  "wallix-bastion_user.testacc_User",
@@ -40,6 +52,48 @@ func TestAccResourceUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceUser_groups(t *testing.T) {
+	resourceName := "wallix-bastion_user.testacc_UserGroups"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"random": {
+				Source: "hashicorp/random",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserGroups(`"testacc_UserGroups1", "testacc_UserGroups2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "groups.*", "testacc_UserGroups1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "groups.*", "testacc_UserGroups2"),
+				),
+			},
+			{
+				Config: testAccResourceUserGroups(`"testacc_UserGroups2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "groups.*", "testacc_UserGroups2"),
+				),
+			},
+			{
+				// the groups read from the Bastion after the update are the same as the configuration
+				Config:   testAccResourceUserGroups(`"testacc_UserGroups2"`),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
 func testAccResourceUserCreate() string {
 	return `
 resource "wallix-bastion_usergroup" "testacc_User" {
@@ -63,9 +117,9 @@ resource "wallix-bastion_user" "testacc_User" {
   groups = [
     wallix-bastion_usergroup.testacc_User.group_name,
   ]
-  force_change_pwd   = true
-  preferred_language = "fr"
-  password           = random_password.testacc_User.result
+  force_change_password = true
+  preferred_language    = "fr"
+  password              = random_password.testacc_User.result
 }
 `
 }
@@ -95,6 +149,43 @@ resource "wallix-bastion_user" "testacc_User" {
   ip_source       = "127.0.0.1"
   is_disabled     = true
   ssh_public_key  = tls_private_key.testacc_User.public_key_openssh
+
+  two_factor_authentication = true
+}
+`
+}
+
+func testAccResourceUserGroups(groups string) string {
+	return `
+resource "wallix-bastion_usergroup" "testacc_UserGroups1" {
+  group_name = "testacc_UserGroups1"
+  timeframes = ["allthetime"]
+}
+resource "wallix-bastion_usergroup" "testacc_UserGroups2" {
+  group_name = "testacc_UserGroups2"
+  timeframes = ["allthetime"]
+}
+resource "random_password" "testacc_UserGroups" {
+  length           = 12
+  special          = true
+  override_special = "_%@"
+  min_upper        = 1
+  min_numeric      = 1
+  min_special      = 1
+}
+
+resource "wallix-bastion_user" "testacc_UserGroups" {
+  user_name  = "testacc_UserGroups"
+  email      = "testacc-usergroups@none.none"
+  profile    = "user"
+  user_auths = ["local_password"]
+  groups     = [` + groups + `]
+  password   = random_password.testacc_UserGroups.result
+
+  depends_on = [
+    wallix-bastion_usergroup.testacc_UserGroups1,
+    wallix-bastion_usergroup.testacc_UserGroups2,
+  ]
 }
 `
 }
@@ -93,38 +93,38 @@ func resourceUserGroup() *schema.Resource {
 	}
 }
 
-func resourceUserGroupVersionCheck(version string) error {
-	if slices.Contains(defaultVersionsValid(), version) {
+func resourceUserGroupVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_usergroup not available with api version %s", version)
+	return fmt.Errorf("resource wallix-bastion_usergroup not available with api version %s", c.bastionAPIVersion)
 }
 
 func resourceUserGroupCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceUserGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	_, ex, err := searchResourceUserGroup(ctx, d.Get("group_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if ex {
-		return diag.FromErr(fmt.Errorf("group_name %s already exists", d.Get("group_name").(string)))
+		return diagFromAPIError(fmt.Errorf("group_name %s already exists", d.Get("group_name").(string)))
 	}
 	err = addUserGroup(ctx, d, m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	id, ex, err := searchResourceUserGroup(ctx, d.Get("group_name").(string), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if !ex {
-		return diag.FromErr(fmt.Errorf("group_name %s not found after POST", d.Get("group_name").(string)))
+		return diagFromAPIError(fmt.Errorf("group_name %s not found after POST", d.Get("group_name").(string)))
 	}
 	d.SetId(id)
 
@@ -135,12 +135,12 @@ func resourceUserGroupRead(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceUserGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	cfg, err := readUserGroupOptions(ctx, d.Id(), m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	if cfg.ID == "" {
 		d.SetId("")
@@ -156,11 +156,11 @@ func resourceUserGroupUpdate(
 ) diag.Diagnostics {
 	d.Partial(true)
 	c := m.(*Client)
-	if err := resourceUserGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := updateUserGroup(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 	d.Partial(false)
 
@@ -171,11 +171,11 @@ func resourceUserGroupDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) diag.Diagnostics {
 	c := m.(*Client)
-	if err := resourceUserGroupVersionCheck(c.bastionAPIVersion); err != nil {
-		return diag.FromErr(err)
+	if err := resourceUserGroupVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
 	}
 	if err := deleteUserGroup(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIError(err)
 	}
 
 	return nil
@@ -188,7 +188,7 @@ func resourceUserGroupImport(
 ) {
 	ctx := context.Background()
 	c := m.(*Client)
-	if err := resourceUserGroupVersionCheck(c.bastionAPIVersion); err != nil {
+	if err := resourceUserGroupVersionCheck(c); err != nil {
 		return nil, err
 	}
 	id, ex, err := searchResourceUserGroup(ctx, d.Id(), m)
@@ -216,12 +216,12 @@ func searchResourceUserGroup(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/usergroups/?q=group_name="+groupName, http.MethodGet, nil)
+	body, code, err := c.newRequestPaged(ctx, "/usergroups/?q=group_name="+groupName, http.MethodGet, nil)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
-		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return "", false, newAPIError("api doesn't return OK", code, body)
 	}
 	var results []jsonUserGroup
 	err = json.Unmarshal([]byte(body), &results)
@@ -245,7 +245,7 @@ func addUserGroup(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -261,7 +261,7 @@ func updateUserGroup(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -276,7 +276,7 @@ func deleteUserGroup(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return newAPIError("api doesn't return OK or NoContent", code, body)
 	}
 
 	return nil
@@ -333,7 +333,7 @@ func readUserGroupOptions(
 		return result, nil
 	}
 	if code != http.StatusOK {
-		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		return result, newAPIError("api doesn't return OK", code, body)
 	}
 	err = json.Unmarshal([]byte(body), &result)
 	if err != nil {
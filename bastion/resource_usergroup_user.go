@@ -0,0 +1,212 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// userGroupUserMaxAttempts is the number of updates of the group before giving up
+// when the users of the group are modified concurrently.
+const userGroupUserMaxAttempts = 5
+
+func resourceUserGroupUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserGroupUserCreate,
+		ReadContext:   resourceUserGroupUserRead,
+		DeleteContext: resourceUserGroupUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceUserGroupUserImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+	}
+}
+
+func resourceUserGroupUserVersionCheck(c *Client) error {
+	if slices.Contains(c.versionsValid(), c.bastionAPIVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("resource wallix-bastion_usergroup_user not available with api version %s", c.bastionAPIVersion)
+}
+
+func resourceUserGroupUserCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceUserGroupUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	groupName := d.Get("group_name").(string)
+	userName := d.Get("user_name").(string)
+	ex, err := checkResourceUserExists(ctx, userName, m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		return diagFromAPIError(fmt.Errorf("user_name %s doesn't exist", userName))
+	}
+	if err := updateUserGroupUsers(ctx, groupName, userName, true, m); err != nil {
+		return diagFromAPIError(err)
+	}
+	d.SetId(groupName + "/" + userName)
+
+	return resourceUserGroupUserRead(ctx, d, m)
+}
+
+func resourceUserGroupUserRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceUserGroupUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	ex, err := checkUserGroupUser(ctx, d.Get("group_name").(string), d.Get("user_name").(string), m)
+	if err != nil {
+		return diagFromAPIError(err)
+	}
+	if !ex {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceUserGroupUserDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceUserGroupUserVersionCheck(c); err != nil {
+		return diagFromAPIError(err)
+	}
+	if err := updateUserGroupUsers(ctx, d.Get("group_name").(string), d.Get("user_name").(string), false, m); err != nil {
+		return diagFromAPIError(err)
+	}
+
+	return nil
+}
+
+func resourceUserGroupUserImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceUserGroupUserVersionCheck(c); err != nil {
+		return nil, err
+	}
+	idSplit := strings.Split(d.Id(), "/")
+	if len(idSplit) != 2 || idSplit[0] == "" || idSplit[1] == "" {
+		return nil, errors.New("id must be <group_name>/<user_name>")
+	}
+	ex, err := checkUserGroupUser(ctx, idSplit[0], idSplit[1], m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find user_name in group_name with id %s (id must be <group_name>/<user_name>)", d.Id())
+	}
+	if tfErr := d.Set("group_name", idSplit[0]); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("user_name", idSplit[1]); tfErr != nil {
+		panic(tfErr)
+	}
+	result := make([]*schema.ResourceData, 1)
+	result[0] = d
+
+	return result, nil
+}
+
+// checkUserGroupUser returns true if the group exists with the user in its users.
+func checkUserGroupUser(
+	ctx context.Context, groupName, userName string, m interface{},
+) (
+	bool, error,
+) {
+	id, ex, err := searchResourceUserGroup(ctx, groupName, m)
+	if err != nil || !ex {
+		return false, err
+	}
+	cfg, err := readUserGroupOptions(ctx, id, m)
+	if err != nil {
+		return false, err
+	}
+
+	return cfg.Users != nil && slices.Contains(*cfg.Users, userName), nil
+}
+
+// updateUserGroupUsers adds (or removes) only userName in the users of the group with a read-modify-write
+// of the group. The group is read again after each update, so the update is sent again if it has been
+// overwritten by a concurrent modification or rejected with a Conflict.
+func updateUserGroupUsers(
+	ctx context.Context, groupName, userName string, add bool, m interface{},
+) error {
+	c := m.(*Client)
+	for attempt := 0; ; attempt++ {
+		id, ex, err := searchResourceUserGroup(ctx, groupName, m)
+		if err != nil {
+			return err
+		}
+		if !ex {
+			if !add {
+				return nil
+			}
+
+			return fmt.Errorf("group_name %s doesn't exist", groupName)
+		}
+		cfg, err := readUserGroupOptions(ctx, id, m)
+		if err != nil {
+			return err
+		}
+		var users []string
+		if cfg.Users != nil {
+			users = *cfg.Users
+		}
+		if slices.Contains(users, userName) == add {
+			return nil
+		}
+		if attempt == userGroupUserMaxAttempts {
+			return fmt.Errorf("users of group_name %s modified concurrently, "+
+				"user_name %s not updated after %d attempts", groupName, userName, attempt)
+		}
+		if add {
+			users = append(users, userName)
+		} else {
+			users = slices.DeleteFunc(users, func(v string) bool { return v == userName })
+		}
+		cfg.Users = &users
+		cfg.ID = ""
+		body, code, err := c.newRequest(ctx, "/usergroups/"+id+"?force=true", http.MethodPut, cfg)
+		if err != nil {
+			return err
+		}
+		if code == http.StatusConflict {
+			continue
+		}
+		if code != http.StatusOK && code != http.StatusNoContent {
+			return newAPIError("api doesn't return OK or NoContent", code, body)
+		}
+	}
+}
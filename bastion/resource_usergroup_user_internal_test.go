@@ -0,0 +1,146 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testUserGroupUsersHandler returns a handler of the group g1 with users,
+// the concurrent function is called on each PUT and returns the status code of the response.
+func testUserGroupUsersHandler(
+	t *testing.T, users *[]string, concurrent func(put []string) int,
+) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3.12/usergroups/":
+			_, _ = w.Write([]byte(`[{"id":"g1","group_name":"group"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3.12/usergroups/g1":
+			_ = json.NewEncoder(w).Encode(jsonUserGroup{
+				ID: "g1", GroupName: "group", TimeFrames: []string{"allthetime"}, Users: users,
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v3.12/usergroups/g1":
+			var jsonData jsonUserGroup
+			if err := json.NewDecoder(r.Body).Decode(&jsonData); err != nil {
+				t.Errorf("decoding request: %s", err)
+			}
+			if !slices.Equal(jsonData.TimeFrames, []string{"allthetime"}) {
+				t.Errorf("expected the other attributes of the group to be kept, got %v", jsonData)
+			}
+			code := concurrent(*jsonData.Users)
+			if code == http.StatusNoContent {
+				*users = *jsonData.Users
+			}
+			w.WriteHeader(code)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestUpdateUserGroupUsers(t *testing.T) {
+	t.Run("add with conflict", func(t *testing.T) {
+		users := []string{"alice"}
+		puts := 0
+		c := newTestClient(t, testUserGroupUsersHandler(t, &users, func(_ []string) int {
+			puts++
+			if puts == 1 {
+				// user added by another workspace before the update
+				users = append(users, "carol")
+
+				return http.StatusConflict
+			}
+
+			return http.StatusNoContent
+		}))
+		if err := updateUserGroupUsers(context.Background(), "group", "bob", true, c); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !slices.Equal(users, []string{"alice", "carol", "bob"}) {
+			t.Errorf("expected only bob to be added, got %v", users)
+		}
+	})
+	t.Run("add overwritten", func(t *testing.T) {
+		users := []string{"alice"}
+		puts := 0
+		c := newTestClient(t, testUserGroupUsersHandler(t, &users, func(_ []string) int {
+			puts++
+			if puts == 1 {
+				// update accepted but overwritten by a concurrent update
+				users = []string{"alice", "carol"}
+
+				return http.StatusOK
+			}
+
+			return http.StatusNoContent
+		}))
+		if err := updateUserGroupUsers(context.Background(), "group", "bob", true, c); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if puts != 2 || !slices.Equal(users, []string{"alice", "carol", "bob"}) {
+			t.Errorf("expected bob to be added again, got %v after %d updates", users, puts)
+		}
+	})
+	t.Run("remove", func(t *testing.T) {
+		users := []string{"alice", "bob", "carol"}
+		c := newTestClient(t, testUserGroupUsersHandler(t, &users, func(_ []string) int {
+			return http.StatusNoContent
+		}))
+		if err := updateUserGroupUsers(context.Background(), "group", "bob", false, c); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !slices.Equal(users, []string{"alice", "carol"}) {
+			t.Errorf("expected only bob to be removed, got %v", users)
+		}
+	})
+	t.Run("too many conflicts", func(t *testing.T) {
+		users := []string{"alice"}
+		c := newTestClient(t, testUserGroupUsersHandler(t, &users, func(_ []string) int {
+			return http.StatusConflict
+		}))
+		err := updateUserGroupUsers(context.Background(), "group", "bob", true, c)
+		if err == nil || !strings.Contains(err.Error(), "not updated after 5 attempts") {
+			t.Fatalf("expected an error after the attempts, got %v", err)
+		}
+	})
+}
+
+func TestResourceUserGroupUserImportID(t *testing.T) {
+	users := []string{"alice"}
+	c := newTestClient(t, testUserGroupUsersHandler(t, &users, func(_ []string) int {
+		return http.StatusNoContent
+	}))
+	tests := map[string]string{
+		"group/alice":   "",
+		"group":         "id must be <group_name>/<user_name>",
+		"group/":        "id must be <group_name>/<user_name>",
+		"group/bob":     "don't find user_name in group_name with id group/bob",
+		"group/alice/x": "id must be <group_name>/<user_name>",
+	}
+	for id, errMatch := range tests {
+		t.Run(id, func(t *testing.T) {
+			d := resourceUserGroupUser().TestResourceData()
+			d.SetId(id)
+			_, err := resourceUserGroupUserImport(d, c)
+			if errMatch == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if d.Get("group_name").(string) != "group" || d.Get("user_name").(string) != "alice" {
+					t.Errorf("unexpected attributes after import: %v", d.State())
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), errMatch) {
+				t.Fatalf("expected error matching %q, got %v", errMatch, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package bastion_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceUserGroupUser_basic(t *testing.T) {
+	resourceName := "wallix-bastion_usergroup_user.testacc_UsergroupUser"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"random": {
+				Source: "hashicorp/random",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserGroupUserCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "testacc_UsergroupUser/testacc_UsergroupUser"),
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_usergroup.testacc_UsergroupUser", "users.#", "0"),
+				),
+			},
+			{
+				// refresh the group to read the member added by the membership resource
+				Config: testAccResourceUserGroupUserCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_usergroup.testacc_UsergroupUser", "users.#", "1"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "testacc_UsergroupUser/testacc_UsergroupUser",
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "testacc_UsergroupUser",
+				ExpectError:   regexp.MustCompile(`id must be <group_name>/<user_name>`),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceUserGroupUserCreate() string {
+	return `
+resource "random_password" "testacc_UsergroupUser" {
+  length           = 12
+  special          = true
+  override_special = "_%@"
+  min_upper        = 1
+  min_numeric      = 1
+  min_special      = 1
+}
+resource "wallix-bastion_user" "testacc_UsergroupUser" {
+  user_name  = "testacc_UsergroupUser"
+  email      = "testacc-usergroupuser@none.none"
+  profile    = "user"
+  user_auths = ["local_password"]
+  password   = random_password.testacc_UsergroupUser.result
+}
+resource "wallix-bastion_usergroup" "testacc_UsergroupUser" {
+  group_name = "testacc_UsergroupUser"
+  timeframes = ["allthetime"]
+}
+resource "wallix-bastion_usergroup_user" "testacc_UsergroupUser" {
+  group_name = wallix-bastion_usergroup.testacc_UsergroupUser.group_name
+  user_name  = wallix-bastion_user.testacc_UsergroupUser.user_name
+}
+`
+}